@@ -1,54 +1,376 @@
+// Package config loads the configuration of the content service from
+// environment variables, the .env file and an optional YAML or TOML file.
 package config
 
 import (
+	"errors"
+	"fmt"
 	"log"
+	"log/slog"
 	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"time"
 
+	"github.com/BurntSushi/toml"
 	"github.com/joho/godotenv"
 	"github.com/spf13/cast"
+	"gopkg.in/yaml.v3"
 )
 
 type Config struct {
-	AUTH_SERVICE_PORT    string
-	USER_SERVICE_PORT    string
-	CONTENT_SERVICE_PORT string
-	API_GATEWAY_PORT     string
-	DB_HOST              string
-	DB_PORT              string
-	DB_USER              string
-	DB_NAME              string
-	DB_PASSWORD          string
-	SINGNING_KEY_ACCESS  string
-	SINGNING_KEY_REFRESH string
-	EMAIL                string
-	PASSWORD             string
-}
-
-func Load() *Config {
+	AUTH_SERVICE_PORT             string
+	USER_SERVICE_PORT             string
+	USER_SERVICE_FAKE             bool
+	USER_SERVICE_FAKE_SEED        string
+	USER_SERVICE_TIMEOUT          time.Duration
+	USER_SERVICE_RETRIES          int
+	USER_SERVICE_TLS              bool
+	USER_SERVICE_CA_FILE          string
+	USER_SERVICE_BREAKER_FAILURES int
+	USER_SERVICE_BREAKER_COOLDOWN time.Duration
+	CONTENT_SERVICE_PORT          string
+	ADMIN_ADDR                    string
+	GRPC_REFLECTION               bool
+	SHUTDOWN_TIMEOUT              time.Duration
+	METRICS_ADDR                  string
+	SCHEDULER_INTERVAL            time.Duration
+	DELETED_GRACE_PERIOD          time.Duration
+	PURGE_RETENTION               time.Duration
+	PURGE_INTERVAL                time.Duration
+	PURGE_BATCH_SIZE              int
+	USER_EVENTS_STREAM            string
+	USER_EVENTS_GROUP             string
+	USER_EVENTS_MODE              string
+	LOG_LEVEL                     string
+	LOG_FORMAT                    string
+	LOG_OUTPUT                    string
+	TRACING_EXPORTER              string
+	TRACING_FILE                  string
+	TRACING_OTLP_ENDPOINT         string
+	TRACING_OTLP_INSECURE         bool
+	TRACING_SAMPLE_RATIO          float64
+	API_GATEWAY_PORT              string
+	DB_HOST                       string
+	DB_PORT                       string
+	DB_USER                       string
+	DB_NAME                       string
+	DB_PASSWORD                   string `secret:"true"`
+	DB_AUTO_MIGRATE               bool
+	DB_SSL_MODE                   string
+	DB_MAX_OPEN_CONNS             int
+	DB_MAX_IDLE_CONNS             int
+	DB_CONN_MAX_LIFETIME          time.Duration
+	DB_CONN_MAX_IDLE_TIME         time.Duration
+	DB_CONNECT_RETRIES            int
+	DB_CONNECT_BACKOFF            time.Duration
+	DB_QUERY_TIMEOUT              time.Duration
+	SINGNING_KEY_ACCESS           string `secret:"true"`
+	SINGNING_KEY_REFRESH          string `secret:"true"`
+	EMAIL                         string
+	PASSWORD                      string `secret:"true"`
+	CACHE_BACKEND                 string
+	REDIS_ADDR                    string
+	REDIS_DB                      int
+	REDIS_PASSWORD                string `secret:"true"`
+	REDIS_TLS                     bool
+	REDIS_POOL_SIZE               int
+	MEDIA_STORE                   string
+	MEDIA_DIR                     string
+	MEDIA_BASE_URL                string
+	MEDIA_ADDR                    string
+	MEDIA_MAX_IMAGE_BYTES         int
+	MEDIA_THUMBNAIL_SIZE          int
+}
+
+// Load reads the configuration once at startup. Environment variables, also
+// read from .env, take precedence over the file in CONFIG_FILE, which takes
+// precedence over the defaults. It fails with every invalid or missing value.
+func Load() (*Config, error) {
 	if err := godotenv.Load(".env"); err != nil {
 		log.Printf(".env file not found: %s", err)
 	}
+
+	file := map[string]interface{}{}
+	if path := os.Getenv("CONFIG_FILE"); path != "" {
+		var err error
+		file, err = readFile(path)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return load(os.LookupEnv, file)
+}
+
+func load(lookupEnv func(string) (string, bool), file map[string]interface{}) (
+	*Config, error) {
+	l := loader{lookupEnv: lookupEnv, file: file}
+
 	config := Config{}
-	config.AUTH_SERVICE_PORT = cast.ToString(coalesce("AUTH_SERVICE_PORT", ":8080"))
-	config.USER_SERVICE_PORT = cast.ToString(coalesce("USER_SERVICE_PORT", ":8080"))
-	config.CONTENT_SERVICE_PORT = cast.ToString(coalesce("CONTENT_SERVICE_PORT", ":8080"))
-	config.API_GATEWAY_PORT = cast.ToString(coalesce("API_GATEWAY_PORT", ":8080"))
-	config.DB_HOST = cast.ToString(coalesce("DB_HOST", ":8080"))
-	config.DB_PORT = cast.ToString(coalesce("DB_PORT", ":8080"))
-	config.DB_USER = cast.ToString(coalesce("DB_USER", ":8080"))
-	config.DB_NAME = cast.ToString(coalesce("DB_NAME", ":8080"))
-	config.DB_PASSWORD = cast.ToString(coalesce("DB_PASSWORD", ":8080"))
-	config.SINGNING_KEY_ACCESS = cast.ToString(coalesce("SINGNING_KEY_ACCESS", ":8080"))
-	config.SINGNING_KEY_REFRESH = cast.ToString(coalesce("SINGNING_KEY_REFRESH", ":8080"))
-	config.EMAIL = cast.ToString(coalesce("EMAIL", ":8080"))
-	config.PASSWORD = cast.ToString(coalesce("PASSWORD", ":8080"))
-
-	return &config
-}
-
-func coalesce(key string, defaultValue interface{}) interface{} {
-	if res, exists := os.LookupEnv(key); exists {
+	config.AUTH_SERVICE_PORT = l.String("AUTH_SERVICE_PORT", "")
+	config.USER_SERVICE_PORT = l.String("USER_SERVICE_PORT", "")
+	config.USER_SERVICE_FAKE = l.Bool("USER_SERVICE_FAKE", false)
+	config.USER_SERVICE_FAKE_SEED = l.String("USER_SERVICE_FAKE_SEED", "")
+	config.USER_SERVICE_TIMEOUT = l.Duration("USER_SERVICE_TIMEOUT", "2s")
+	config.USER_SERVICE_RETRIES = l.Int("USER_SERVICE_RETRIES", 3)
+	config.USER_SERVICE_TLS = l.Bool("USER_SERVICE_TLS", false)
+	config.USER_SERVICE_CA_FILE = l.String("USER_SERVICE_CA_FILE", "")
+	config.USER_SERVICE_BREAKER_FAILURES = l.Int("USER_SERVICE_BREAKER_FAILURES", 5)
+	config.USER_SERVICE_BREAKER_COOLDOWN = l.Duration("USER_SERVICE_BREAKER_COOLDOWN", "10s")
+	config.CONTENT_SERVICE_PORT = l.String("CONTENT_SERVICE_PORT", ":8080")
+	config.ADMIN_ADDR = l.String("ADMIN_ADDR", "127.0.0.1:8081")
+	config.GRPC_REFLECTION = l.Bool("GRPC_REFLECTION", false)
+	config.SHUTDOWN_TIMEOUT = l.Duration("SHUTDOWN_TIMEOUT", "15s")
+	config.METRICS_ADDR = l.String("METRICS_ADDR", ":9090")
+	config.SCHEDULER_INTERVAL = l.Duration("SCHEDULER_INTERVAL", "30s")
+	config.DELETED_GRACE_PERIOD = l.Duration("DELETED_GRACE_PERIOD", "720h")
+	config.PURGE_RETENTION = l.Duration("PURGE_RETENTION", "2160h")
+	config.PURGE_INTERVAL = l.Duration("PURGE_INTERVAL", "1h")
+	config.PURGE_BATCH_SIZE = l.Int("PURGE_BATCH_SIZE", 100)
+	config.USER_EVENTS_STREAM = l.String("USER_EVENTS_STREAM", "")
+	config.USER_EVENTS_GROUP = l.String("USER_EVENTS_GROUP", "content-service")
+	config.USER_EVENTS_MODE = l.String("USER_EVENTS_MODE", "anonymize")
+	config.LOG_LEVEL = l.String("LOG_LEVEL", "info")
+	config.LOG_FORMAT = l.String("LOG_FORMAT", "text")
+	config.LOG_OUTPUT = l.String("LOG_OUTPUT", "stdout")
+	config.TRACING_EXPORTER = l.String("TRACING_EXPORTER", "none")
+	config.TRACING_FILE = l.String("TRACING_FILE", "traces.json")
+	config.TRACING_OTLP_ENDPOINT = l.String("TRACING_OTLP_ENDPOINT", "localhost:4317")
+	config.TRACING_OTLP_INSECURE = l.Bool("TRACING_OTLP_INSECURE", true)
+	config.TRACING_SAMPLE_RATIO = l.Float64("TRACING_SAMPLE_RATIO", 1.0)
+	config.API_GATEWAY_PORT = l.String("API_GATEWAY_PORT", "")
+	config.DB_HOST = l.String("DB_HOST", "localhost")
+	config.DB_PORT = l.String("DB_PORT", "5432")
+	config.DB_USER = l.String("DB_USER", "postgres")
+	config.DB_NAME = l.String("DB_NAME", "travel")
+	config.DB_PASSWORD = l.String("DB_PASSWORD", "")
+	config.DB_AUTO_MIGRATE = l.Bool("DB_AUTO_MIGRATE", false)
+	config.DB_SSL_MODE = l.String("DB_SSL_MODE", "disable")
+	config.DB_MAX_OPEN_CONNS = l.Int("DB_MAX_OPEN_CONNS", 25)
+	config.DB_MAX_IDLE_CONNS = l.Int("DB_MAX_IDLE_CONNS", 25)
+	config.DB_CONN_MAX_LIFETIME = l.Duration("DB_CONN_MAX_LIFETIME", "30m")
+	config.DB_CONN_MAX_IDLE_TIME = l.Duration("DB_CONN_MAX_IDLE_TIME", "5m")
+	config.DB_CONNECT_RETRIES = l.Int("DB_CONNECT_RETRIES", 5)
+	config.DB_CONNECT_BACKOFF = l.Duration("DB_CONNECT_BACKOFF", "500ms")
+	config.DB_QUERY_TIMEOUT = l.Duration("DB_QUERY_TIMEOUT", "5s")
+	config.SINGNING_KEY_ACCESS = l.String("SINGNING_KEY_ACCESS", "")
+	config.SINGNING_KEY_REFRESH = l.String("SINGNING_KEY_REFRESH", "")
+	config.EMAIL = l.String("EMAIL", "")
+	config.PASSWORD = l.String("PASSWORD", "")
+	config.CACHE_BACKEND = l.String("CACHE_BACKEND", "redis")
+	config.REDIS_ADDR = l.String("REDIS_ADDR", "localhost:6379")
+	config.REDIS_DB = l.Int("REDIS_DB", 0)
+	config.REDIS_PASSWORD = l.String("REDIS_PASSWORD", "")
+	config.REDIS_TLS = l.Bool("REDIS_TLS", false)
+	config.REDIS_POOL_SIZE = l.Int("REDIS_POOL_SIZE", 10)
+	config.MEDIA_STORE = l.String("MEDIA_STORE", "local")
+	config.MEDIA_DIR = l.String("MEDIA_DIR", "media")
+	config.MEDIA_BASE_URL = l.String("MEDIA_BASE_URL", "http://localhost:9091/media")
+	config.MEDIA_ADDR = l.String("MEDIA_ADDR", ":9091")
+	config.MEDIA_MAX_IMAGE_BYTES = l.Int("MEDIA_MAX_IMAGE_BYTES", 5<<20)
+	config.MEDIA_THUMBNAIL_SIZE = l.Int("MEDIA_THUMBNAIL_SIZE", 320)
+
+	l.errs = append(l.errs, config.validate()...)
+	if len(l.errs) > 0 {
+		return nil, fmt.Errorf("invalid config: %w", errors.Join(l.errs...))
+	}
+	return &config, nil
+}
+
+func (c *Config) validate() []error {
+	errs := []error{}
+	required := func(key, val string) {
+		if val == "" {
+			errs = append(errs, fmt.Errorf("%s is required", key))
+		}
+	}
+	oneOf := func(key, val string, allowed ...string) {
+		for _, a := range allowed {
+			if val == a {
+				return
+			}
+		}
+		errs = append(errs, fmt.Errorf("%s must be one of %s, got %q", key,
+			strings.Join(allowed, ", "), val))
+	}
+	positive := func(key string, val int) {
+		if val <= 0 {
+			errs = append(errs, fmt.Errorf("%s must be positive, got %d", key, val))
+		}
+	}
+
+	required("CONTENT_SERVICE_PORT", c.CONTENT_SERVICE_PORT)
+	if !c.USER_SERVICE_FAKE {
+		required("USER_SERVICE_PORT", c.USER_SERVICE_PORT)
+	}
+	positive("USER_SERVICE_RETRIES", c.USER_SERVICE_RETRIES)
+	if c.USER_SERVICE_RETRIES > 5 {
+		errs = append(errs, fmt.Errorf("USER_SERVICE_RETRIES must be at most 5, got %d",
+			c.USER_SERVICE_RETRIES))
+	}
+	positive("USER_SERVICE_BREAKER_FAILURES", c.USER_SERVICE_BREAKER_FAILURES)
+	if c.SCHEDULER_INTERVAL <= 0 {
+		errs = append(errs, fmt.Errorf("SCHEDULER_INTERVAL must be positive, got %s",
+			c.SCHEDULER_INTERVAL))
+	}
+	if c.DELETED_GRACE_PERIOD <= 0 {
+		errs = append(errs, fmt.Errorf("DELETED_GRACE_PERIOD must be positive, got %s",
+			c.DELETED_GRACE_PERIOD))
+	}
+	// content must not be purged while its author can still restore it
+	if c.PURGE_RETENTION < c.DELETED_GRACE_PERIOD {
+		errs = append(errs, fmt.Errorf(
+			"PURGE_RETENTION must be at least DELETED_GRACE_PERIOD, got %s", c.PURGE_RETENTION))
+	}
+	if c.PURGE_INTERVAL <= 0 {
+		errs = append(errs, fmt.Errorf("PURGE_INTERVAL must be positive, got %s",
+			c.PURGE_INTERVAL))
+	}
+	positive("PURGE_BATCH_SIZE", c.PURGE_BATCH_SIZE)
+	// the events of deleted users are only consumed when a stream is set
+	if c.USER_EVENTS_STREAM != "" {
+		required("USER_EVENTS_GROUP", c.USER_EVENTS_GROUP)
+		required("REDIS_ADDR", c.REDIS_ADDR)
+		oneOf("USER_EVENTS_MODE", c.USER_EVENTS_MODE, "anonymize", "delete")
+	}
+	required("DB_HOST", c.DB_HOST)
+	required("DB_PORT", c.DB_PORT)
+	required("DB_USER", c.DB_USER)
+	required("DB_NAME", c.DB_NAME)
+	required("DB_PASSWORD", c.DB_PASSWORD)
+	oneOf("DB_SSL_MODE", c.DB_SSL_MODE, "disable", "allow", "prefer", "require",
+		"verify-ca", "verify-full")
+	positive("DB_MAX_OPEN_CONNS", c.DB_MAX_OPEN_CONNS)
+	positive("DB_CONNECT_RETRIES", c.DB_CONNECT_RETRIES)
+
+	oneOf("CACHE_BACKEND", c.CACHE_BACKEND, "redis", "memory", "none")
+	if c.CACHE_BACKEND == "redis" {
+		required("REDIS_ADDR", c.REDIS_ADDR)
+		positive("REDIS_POOL_SIZE", c.REDIS_POOL_SIZE)
+	}
+
+	oneOf("MEDIA_STORE", c.MEDIA_STORE, "local")
+	if c.MEDIA_STORE == "local" {
+		required("MEDIA_DIR", c.MEDIA_DIR)
+	}
+	required("MEDIA_BASE_URL", c.MEDIA_BASE_URL)
+	positive("MEDIA_MAX_IMAGE_BYTES", c.MEDIA_MAX_IMAGE_BYTES)
+	positive("MEDIA_THUMBNAIL_SIZE", c.MEDIA_THUMBNAIL_SIZE)
+
+	oneOf("LOG_LEVEL", strings.ToLower(c.LOG_LEVEL), "debug", "info", "warn", "error")
+	oneOf("LOG_FORMAT", strings.ToLower(c.LOG_FORMAT), "text", "json")
+	oneOf("TRACING_EXPORTER", c.TRACING_EXPORTER, "none", "stdout", "file", "otlp")
+	if c.TRACING_SAMPLE_RATIO < 0 || c.TRACING_SAMPLE_RATIO > 1 {
+		errs = append(errs, fmt.Errorf("TRACING_SAMPLE_RATIO must be between 0 and 1, got %v",
+			c.TRACING_SAMPLE_RATIO))
+	}
+	return errs
+}
+
+// LogValue logs the config with the secrets redacted.
+func (c *Config) LogValue() slog.Value {
+	attrs := []slog.Attr{}
+	val := reflect.ValueOf(c).Elem()
+	for n := 0; n < val.NumField(); n++ {
+		field := val.Type().Field(n)
+		if field.Tag.Get("secret") == "true" {
+			redacted := ""
+			if !val.Field(n).IsZero() {
+				redacted = "[REDACTED]"
+			}
+			attrs = append(attrs, slog.String(field.Name, redacted))
+			continue
+		}
+		attrs = append(attrs, slog.Any(field.Name, val.Field(n).Interface()))
+	}
+	return slog.GroupValue(attrs...)
+}
+
+// String formats the config with the secrets redacted, so printing it can't
+// leak them either.
+func (c *Config) String() string {
+	return c.LogValue().String()
+}
+
+// readFile reads a YAML or TOML config file, by its extension. Keys are the
+// names of the environment variables, in any case.
+func readFile(path string) (map[string]interface{}, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("error with reading config file: %s", err)
+	}
+
+	values := map[string]interface{}{}
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		err = yaml.Unmarshal(data, &values)
+	case ".toml":
+		err = toml.Unmarshal(data, &values)
+	default:
+		return nil, fmt.Errorf("unsupported config file format: %s", path)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error with parsing config file %s: %s", path, err)
+	}
+
+	res := map[string]interface{}{}
+	for key, val := range values {
+		res[strings.ToUpper(key)] = val
+	}
+	return res, nil
+}
+
+// loader looks keys up in the environment, then in the config file, and
+// collects the values that fail to parse.
+type loader struct {
+	lookupEnv func(string) (string, bool)
+	file      map[string]interface{}
+	errs      []error
+}
+
+func (l *loader) coalesce(key string, defaultValue interface{}) interface{} {
+	if res, exists := l.lookupEnv(key); exists {
+		return res
+	}
+	if res, exists := l.file[key]; exists {
 		return res
 	}
 	return defaultValue
 }
+
+func (l *loader) check(key string, err error) {
+	if err != nil {
+		l.errs = append(l.errs, fmt.Errorf("%s: %s", key, err))
+	}
+}
+
+func (l *loader) String(key string, defaultValue string) string {
+	res, err := cast.ToStringE(l.coalesce(key, defaultValue))
+	l.check(key, err)
+	return res
+}
+
+func (l *loader) Bool(key string, defaultValue bool) bool {
+	res, err := cast.ToBoolE(l.coalesce(key, defaultValue))
+	l.check(key, err)
+	return res
+}
+
+func (l *loader) Int(key string, defaultValue int) int {
+	res, err := cast.ToIntE(l.coalesce(key, defaultValue))
+	l.check(key, err)
+	return res
+}
+
+func (l *loader) Float64(key string, defaultValue float64) float64 {
+	res, err := cast.ToFloat64E(l.coalesce(key, defaultValue))
+	l.check(key, err)
+	return res
+}
+
+func (l *loader) Duration(key string, defaultValue string) time.Duration {
+	res, err := cast.ToDurationE(l.coalesce(key, defaultValue))
+	l.check(key, err)
+	return res
+}
@@ -0,0 +1,132 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func env(values map[string]string) func(string) (string, bool) {
+	return func(key string) (string, bool) {
+		val, ok := values[key]
+		return val, ok
+	}
+}
+
+var validEnv = map[string]string{
+	"USER_SERVICE_PORT": "users:8081",
+	"DB_PASSWORD":       "secret-password",
+}
+
+func TestLoadDefaults(t *testing.T) {
+	cfg, err := load(env(validEnv), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfg.DB_HOST != "localhost" || cfg.DB_PORT != "5432" ||
+		cfg.CONTENT_SERVICE_PORT != ":8080" || cfg.REDIS_POOL_SIZE != 10 ||
+		cfg.ADMIN_ADDR != "127.0.0.1:8081" {
+		t.Errorf("unexpected defaults: %s", cfg)
+	}
+}
+
+func TestLoadRequired(t *testing.T) {
+	_, err := load(env(map[string]string{"DB_HOST": ""}), nil)
+	if err == nil {
+		t.Fatal("expected missing values to fail")
+	}
+	for _, key := range []string{"USER_SERVICE_PORT", "DB_PASSWORD", "DB_HOST"} {
+		if !strings.Contains(err.Error(), key+" is required") {
+			t.Errorf("expected %s to be reported, got %s", key, err)
+		}
+	}
+
+	_, err = load(env(map[string]string{
+		"USER_SERVICE_FAKE": "true",
+		"DB_PASSWORD":       "secret-password",
+	}), nil)
+	if err != nil {
+		t.Errorf("expected the users service address not to be required with the fake, got %s", err)
+	}
+}
+
+func TestLoadInvalid(t *testing.T) {
+	values := map[string]string{
+		"DB_QUERY_TIMEOUT":     "soon",
+		"CACHE_BACKEND":        "memcached",
+		"REDIS_POOL_SIZE":      "many",
+		"USER_SERVICE_RETRIES": "9",
+		"PURGE_RETENTION":      "24h",
+	}
+	for key, val := range validEnv {
+		values[key] = val
+	}
+
+	_, err := load(env(values), nil)
+	if err == nil {
+		t.Fatal("expected invalid values to fail")
+	}
+	for key := range values {
+		if _, ok := validEnv[key]; !ok && !strings.Contains(err.Error(), key) {
+			t.Errorf("expected %s to be reported, got %s", key, err)
+		}
+	}
+}
+
+func TestLoadFile(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		"config.yaml": "db_host: postgres\ndb_query_timeout: 2s\nredis_pool_size: 20\n",
+		"config.toml": "DB_HOST = \"postgres\"\nDB_QUERY_TIMEOUT = \"2s\"\nREDIS_POOL_SIZE = 20\n",
+	}
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+		file, err := readFile(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		values := map[string]string{"REDIS_POOL_SIZE": "30"}
+		for key, val := range validEnv {
+			values[key] = val
+		}
+		cfg, err := load(env(values), file)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if cfg.DB_HOST != "postgres" || cfg.DB_QUERY_TIMEOUT.String() != "2s" {
+			t.Errorf("%s: expected values from the file, got %s", name, cfg)
+		}
+		if cfg.REDIS_POOL_SIZE != 30 {
+			t.Errorf("%s: expected the environment to override the file, got %d",
+				name, cfg.REDIS_POOL_SIZE)
+		}
+	}
+
+	if _, err := readFile(filepath.Join(dir, "config.json")); err == nil {
+		t.Error("expected unsupported file format to fail")
+	}
+}
+
+func TestSecretsRedacted(t *testing.T) {
+	values := map[string]string{"REDIS_PASSWORD": "redis-password"}
+	for key, val := range validEnv {
+		values[key] = val
+	}
+	cfg, err := load(env(values), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	out := cfg.String()
+	if strings.Contains(out, "secret-password") || strings.Contains(out, "redis-password") {
+		t.Errorf("expected secrets to be redacted, got %s", out)
+	}
+	if !strings.Contains(out, "DB_PASSWORD=[REDACTED]") || !strings.Contains(out, "DB_HOST=localhost") {
+		t.Errorf("unexpected config output: %s", out)
+	}
+}
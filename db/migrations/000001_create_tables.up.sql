@@ -1,4 +1,7 @@
-CREATE TABLE stories (
+-- the tables from before the migrations were tracked. IF NOT EXISTS lets a
+-- database created by hand with them adopt the migrations with a plain `up`;
+-- the later migrations bring it to the current schema.
+CREATE TABLE IF NOT EXISTS stories (
     id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
     title VARCHAR(200) NOT NULL,
     content TEXT NOT NULL,
@@ -12,13 +15,13 @@ CREATE TABLE stories (
     deleted_at TIMESTAMP WITH TIME ZONE
 );
 
-CREATE TABLE story_tags (
+CREATE TABLE IF NOT EXISTS story_tags (
     story_id UUID REFERENCES stories(id),
     tag VARCHAR(50) not null,
     PRIMARY KEY (story_id, tag)
 );
 
-CREATE TABLE comments (
+CREATE TABLE IF NOT EXISTS comments (
     id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
     content TEXT NOT NULL,
     author_id UUID,
@@ -28,14 +31,14 @@ CREATE TABLE comments (
     deleted_at TIMESTAMP WITH TIME ZONE
 );
 
-CREATE TABLE likes (
+CREATE TABLE IF NOT EXISTS likes (
     user_id UUID,
     story_id UUID REFERENCES stories(id),
     created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
     PRIMARY KEY (user_id, story_id)
 );
 
-CREATE TABLE itineraries (
+CREATE TABLE IF NOT EXISTS itineraries (
     id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
     title VARCHAR(200) NOT NULL,
     description TEXT,
@@ -49,7 +52,7 @@ CREATE TABLE itineraries (
     deleted_at TIMESTAMP WITH TIME ZONE
 );  
 
-CREATE TABLE itinerary_destinations (
+CREATE TABLE IF NOT EXISTS itinerary_destinations (
     id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
     itinerary_id UUID REFERENCES itineraries(id),
     name VARCHAR(100) NOT NULL,
@@ -60,7 +63,7 @@ CREATE TABLE itinerary_destinations (
     deleted_at TIMESTAMP WITH TIME ZONE
 );
 
-CREATE TABLE itinerary_activities (
+CREATE TABLE IF NOT EXISTS itinerary_activities (
     id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
     destination_id UUID REFERENCES itinerary_destinations(id),
     activity TEXT NOT NULL,
@@ -69,7 +72,7 @@ CREATE TABLE itinerary_activities (
     deleted_at TIMESTAMP WITH TIME ZONE 
 );
 
-CREATE TABLE destinations (
+CREATE TABLE IF NOT EXISTS destinations (
     id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
     name VARCHAR(100) NOT NULL,
     country VARCHAR(100) NOT NULL,
@@ -84,7 +87,7 @@ CREATE TABLE destinations (
     deleted_at TIMESTAMP WITH TIME ZONE 
 );
 
-CREATE TABLE messages (
+CREATE TABLE IF NOT EXISTS messages (
     id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
     sender_id UUID,
     recipient_id UUID,
@@ -94,7 +97,7 @@ CREATE TABLE messages (
     deleted_at TIMESTAMP WITH TIME ZONE 
 );
 
-CREATE TABLE commentsForItinerary (
+CREATE TABLE IF NOT EXISTS commentsForItinerary (
     id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
     content TEXT NOT NULL,
     author_id UUID,
@@ -104,7 +107,7 @@ CREATE TABLE commentsForItinerary (
     deleted_at TIMESTAMP WITH TIME ZONE
 );
 
-CREATE TABLE travel_tips (
+CREATE TABLE IF NOT EXISTS travel_tips (
     id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
     title VARCHAR(200) NOT NULL,
     content TEXT NOT NULL,
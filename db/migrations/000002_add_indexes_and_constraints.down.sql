@@ -0,0 +1,22 @@
+ALTER TABLE commentsForItinerary DROP CONSTRAINT IF EXISTS commentsForItinerary_author_id_check;
+ALTER TABLE comments DROP CONSTRAINT IF EXISTS comments_author_id_check;
+ALTER TABLE itinerary_destinations DROP CONSTRAINT IF EXISTS itinerary_destinations_dates_check;
+ALTER TABLE itineraries
+    DROP CONSTRAINT IF EXISTS itineraries_author_id_check,
+    DROP CONSTRAINT IF EXISTS itineraries_dates_check,
+    DROP CONSTRAINT IF EXISTS itineraries_comments_count_check,
+    DROP CONSTRAINT IF EXISTS itineraries_likes_count_check;
+ALTER TABLE stories
+    DROP CONSTRAINT IF EXISTS stories_author_id_check,
+    DROP CONSTRAINT IF EXISTS stories_comments_count_check,
+    DROP CONSTRAINT IF EXISTS stories_likes_count_check;
+
+DROP INDEX IF EXISTS destinations_popularity_score_idx;
+DROP INDEX IF EXISTS commentsForItinerary_itinerary_id_idx;
+DROP INDEX IF EXISTS itinerary_activities_destination_id_idx;
+DROP INDEX IF EXISTS itinerary_destinations_itinerary_id_idx;
+DROP INDEX IF EXISTS itineraries_author_id_idx;
+DROP INDEX IF EXISTS likes_story_id_idx;
+DROP INDEX IF EXISTS comments_story_id_idx;
+DROP INDEX IF EXISTS stories_created_at_idx;
+DROP INDEX IF EXISTS stories_author_id_idx;
@@ -0,0 +1,52 @@
+CREATE INDEX IF NOT EXISTS stories_author_id_idx
+    ON stories (author_id) WHERE deleted_at IS NULL;
+CREATE INDEX IF NOT EXISTS stories_created_at_idx
+    ON stories (created_at DESC) WHERE deleted_at IS NULL;
+CREATE INDEX IF NOT EXISTS comments_story_id_idx
+    ON comments (story_id) WHERE deleted_at IS NULL;
+CREATE INDEX IF NOT EXISTS likes_story_id_idx
+    ON likes (story_id);
+CREATE INDEX IF NOT EXISTS itineraries_author_id_idx
+    ON itineraries (author_id) WHERE deleted_at IS NULL;
+CREATE INDEX IF NOT EXISTS itinerary_destinations_itinerary_id_idx
+    ON itinerary_destinations (itinerary_id) WHERE deleted_at IS NULL;
+CREATE INDEX IF NOT EXISTS itinerary_activities_destination_id_idx
+    ON itinerary_activities (destination_id) WHERE deleted_at IS NULL;
+CREATE INDEX IF NOT EXISTS commentsForItinerary_itinerary_id_idx
+    ON commentsForItinerary (itinerary_id) WHERE deleted_at IS NULL;
+CREATE INDEX IF NOT EXISTS destinations_popularity_score_idx
+    ON destinations (popularity_score DESC);
+
+-- the whole file runs in one transaction, so neither the indexes nor the
+-- NOT VALID / VALIDATE split avoid locking the tables while existing rows are
+-- scanned; on a large database apply this in a maintenance window
+ALTER TABLE stories
+    ADD CONSTRAINT stories_likes_count_check CHECK (likes_count >= 0) NOT VALID,
+    ADD CONSTRAINT stories_comments_count_check CHECK (comments_count >= 0) NOT VALID,
+    ADD CONSTRAINT stories_author_id_check CHECK (author_id IS NOT NULL) NOT VALID;
+
+ALTER TABLE itineraries
+    ADD CONSTRAINT itineraries_likes_count_check CHECK (likes_count >= 0) NOT VALID,
+    ADD CONSTRAINT itineraries_comments_count_check CHECK (comments_count >= 0) NOT VALID,
+    ADD CONSTRAINT itineraries_dates_check CHECK (end_date >= start_date) NOT VALID,
+    ADD CONSTRAINT itineraries_author_id_check CHECK (author_id IS NOT NULL) NOT VALID;
+
+ALTER TABLE itinerary_destinations
+    ADD CONSTRAINT itinerary_destinations_dates_check CHECK (end_date >= start_date) NOT VALID;
+
+ALTER TABLE comments
+    ADD CONSTRAINT comments_author_id_check CHECK (author_id IS NOT NULL) NOT VALID;
+
+ALTER TABLE commentsForItinerary
+    ADD CONSTRAINT commentsForItinerary_author_id_check CHECK (author_id IS NOT NULL) NOT VALID;
+
+ALTER TABLE stories VALIDATE CONSTRAINT stories_likes_count_check;
+ALTER TABLE stories VALIDATE CONSTRAINT stories_comments_count_check;
+ALTER TABLE stories VALIDATE CONSTRAINT stories_author_id_check;
+ALTER TABLE itineraries VALIDATE CONSTRAINT itineraries_likes_count_check;
+ALTER TABLE itineraries VALIDATE CONSTRAINT itineraries_comments_count_check;
+ALTER TABLE itineraries VALIDATE CONSTRAINT itineraries_dates_check;
+ALTER TABLE itineraries VALIDATE CONSTRAINT itineraries_author_id_check;
+ALTER TABLE itinerary_destinations VALIDATE CONSTRAINT itinerary_destinations_dates_check;
+ALTER TABLE comments VALIDATE CONSTRAINT comments_author_id_check;
+ALTER TABLE commentsForItinerary VALIDATE CONSTRAINT commentsForItinerary_author_id_check;
@@ -0,0 +1 @@
+DROP TABLE IF EXISTS story_images;
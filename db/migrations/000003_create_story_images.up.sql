@@ -0,0 +1,16 @@
+CREATE TABLE IF NOT EXISTS story_images (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    story_id UUID NOT NULL REFERENCES stories(id),
+    key VARCHAR(255) NOT NULL UNIQUE,
+    url TEXT NOT NULL,
+    thumbnail_key VARCHAR(255) NOT NULL,
+    thumbnail_url TEXT NOT NULL,
+    content_type VARCHAR(50) NOT NULL,
+    width INTEGER NOT NULL,
+    height INTEGER NOT NULL,
+    position INTEGER NOT NULL DEFAULT 0,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS story_images_story_id_idx
+    ON story_images (story_id, position);
@@ -0,0 +1,7 @@
+DROP INDEX IF EXISTS stories_author_id_status_idx;
+DROP INDEX IF EXISTS stories_scheduled_idx;
+ALTER TABLE stories
+    DROP CONSTRAINT IF EXISTS stories_publish_at_check,
+    DROP CONSTRAINT IF EXISTS stories_status_check,
+    DROP COLUMN IF EXISTS publish_at,
+    DROP COLUMN IF EXISTS status;
@@ -0,0 +1,16 @@
+-- existing stories were visible as soon as they were created, so they start
+-- out published
+ALTER TABLE stories
+    ADD COLUMN IF NOT EXISTS status VARCHAR(20) NOT NULL DEFAULT 'published',
+    ADD COLUMN IF NOT EXISTS publish_at TIMESTAMP WITH TIME ZONE;
+
+ALTER TABLE stories
+    ADD CONSTRAINT stories_status_check
+        CHECK (status IN ('draft', 'scheduled', 'published', 'archived')),
+    ADD CONSTRAINT stories_publish_at_check
+        CHECK (status <> 'scheduled' OR publish_at IS NOT NULL);
+
+CREATE INDEX IF NOT EXISTS stories_scheduled_idx
+    ON stories (publish_at) WHERE status = 'scheduled' AND deleted_at IS NULL;
+CREATE INDEX IF NOT EXISTS stories_author_id_status_idx
+    ON stories (author_id, status) WHERE deleted_at IS NULL;
@@ -0,0 +1,13 @@
+-- a revision is the state of a story before an edit; images are not kept, as
+-- their blobs are deleted once removed from the story
+CREATE TABLE IF NOT EXISTS story_revisions (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    story_id UUID NOT NULL REFERENCES stories(id),
+    revision INTEGER NOT NULL,
+    title VARCHAR(200) NOT NULL,
+    content TEXT NOT NULL,
+    location VARCHAR(100),
+    tags VARCHAR[] NOT NULL DEFAULT '{}',
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (story_id, revision)
+);
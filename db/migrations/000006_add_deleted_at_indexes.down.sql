@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS commentsForItinerary_deleted_at_idx;
+DROP INDEX IF EXISTS comments_deleted_at_idx;
+DROP INDEX IF EXISTS itineraries_deleted_at_idx;
+DROP INDEX IF EXISTS stories_deleted_at_idx;
@@ -0,0 +1,10 @@
+-- the purge job and the admin listing look up the soft-deleted rows by when
+-- they were deleted
+CREATE INDEX IF NOT EXISTS stories_deleted_at_idx
+    ON stories (deleted_at) WHERE deleted_at IS NOT NULL;
+CREATE INDEX IF NOT EXISTS itineraries_deleted_at_idx
+    ON itineraries (deleted_at) WHERE deleted_at IS NOT NULL;
+CREATE INDEX IF NOT EXISTS comments_deleted_at_idx
+    ON comments (deleted_at) WHERE deleted_at IS NOT NULL;
+CREATE INDEX IF NOT EXISTS commentsForItinerary_deleted_at_idx
+    ON commentsForItinerary (deleted_at) WHERE deleted_at IS NOT NULL;
@@ -0,0 +1,4 @@
+ALTER TABLE itinerary_destinations
+    DROP CONSTRAINT IF EXISTS itinerary_destinations_coordinates_check,
+    DROP COLUMN IF EXISTS longitude,
+    DROP COLUMN IF EXISTS latitude;
@@ -0,0 +1,9 @@
+-- optional, for exporting itineraries as GPX waypoints
+ALTER TABLE itinerary_destinations
+    ADD COLUMN IF NOT EXISTS latitude DOUBLE PRECISION,
+    ADD COLUMN IF NOT EXISTS longitude DOUBLE PRECISION;
+
+ALTER TABLE itinerary_destinations
+    ADD CONSTRAINT itinerary_destinations_coordinates_check
+        CHECK ((latitude IS NULL) = (longitude IS NULL) AND
+            latitude BETWEEN -90 AND 90 AND longitude BETWEEN -180 AND 180);
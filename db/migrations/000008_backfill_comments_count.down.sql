@@ -0,0 +1,2 @@
+-- the counters stay correct, nothing to undo
+SELECT 1;
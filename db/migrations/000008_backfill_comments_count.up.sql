@@ -0,0 +1,11 @@
+-- comments_count was never kept up to date, so it starts from the comments
+-- there are; creating and deleting comments maintain it from now on
+UPDATE stories s
+SET comments_count = (
+    SELECT count(*) FROM comments c
+    WHERE c.story_id = s.id AND c.deleted_at IS NULL);
+
+UPDATE itineraries i
+SET comments_count = (
+    SELECT count(*) FROM commentsForItinerary c
+    WHERE c.itinerary_id = i.id AND c.deleted_at IS NULL);
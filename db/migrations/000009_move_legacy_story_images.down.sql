@@ -0,0 +1,2 @@
+-- stories.images still holds the urls, so the moved rows can just go
+DELETE FROM story_images WHERE key LIKE 'legacy/%';
@@ -0,0 +1,13 @@
+-- stories created before story_images kept the urls of their images in
+-- stories.images. They move over as images of unknown size that are their own
+-- thumbnails; their keys name no stored blob, so deleting them is a no-op in
+-- the media store.
+INSERT INTO story_images (
+    story_id, key, url, thumbnail_key, thumbnail_url, content_type,
+    width, height, position
+)
+SELECT s.id, 'legacy/' || s.id || '/' || i.position, i.url,
+    'legacy/' || s.id || '/' || i.position, i.url, '', 0, 0, i.position - 1
+FROM stories s, unnest(s.images) WITH ORDINALITY AS i(url, position)
+WHERE i.url IS NOT NULL AND i.url <> ''
+ON CONFLICT (key) DO NOTHING;
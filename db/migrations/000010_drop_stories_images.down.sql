@@ -0,0 +1,9 @@
+ALTER TABLE stories ADD COLUMN IF NOT EXISTS images VARCHAR[];
+
+UPDATE stories s
+SET images = (
+    SELECT array_agg(i.url ORDER BY i.position) FROM story_images i
+    WHERE i.story_id = s.id AND i.key LIKE 'legacy/%')
+WHERE EXISTS (
+    SELECT 1 FROM story_images i
+    WHERE i.story_id = s.id AND i.key LIKE 'legacy/%');
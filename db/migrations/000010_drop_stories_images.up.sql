@@ -0,0 +1,2 @@
+-- replaced by story_images, which holds the legacy urls since 000009
+ALTER TABLE stories DROP COLUMN IF EXISTS images;
@@ -0,0 +1,5 @@
+-- the detached images were no longer part of their stories
+DELETE FROM story_images WHERE removed_at IS NOT NULL;
+
+ALTER TABLE story_images DROP COLUMN IF EXISTS removed_at;
+ALTER TABLE story_revisions DROP COLUMN IF EXISTS image_ids;
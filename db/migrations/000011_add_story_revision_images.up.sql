@@ -0,0 +1,8 @@
+-- revisions keep the images of the story by id. An image removed from a story
+-- while a revision refers to it is only detached, with its blob kept, so
+-- restoring the revision can bring it back.
+ALTER TABLE story_revisions
+    ADD COLUMN IF NOT EXISTS image_ids UUID[] NOT NULL DEFAULT '{}';
+
+ALTER TABLE story_images
+    ADD COLUMN IF NOT EXISTS removed_at TIMESTAMP WITH TIME ZONE;
@@ -0,0 +1,151 @@
+// Package migrations embeds the schema migrations of this directory and
+// applies them with golang-migrate.
+package migrations
+
+import (
+	"context"
+	"database/sql"
+	"embed"
+	"errors"
+	"fmt"
+	"io/fs"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/golang-migrate/migrate/v4"
+	"github.com/golang-migrate/migrate/v4/database/postgres"
+	"github.com/golang-migrate/migrate/v4/source/iofs"
+)
+
+//go:embed *.sql
+var FS embed.FS
+
+// Migration is one version of the schema with its applied state. A dirty
+// migration failed halfway, so it is not applied.
+type Migration struct {
+	Version uint
+	Name    string
+	Applied bool
+	Dirty   bool
+}
+
+// Status is the state of the schema in the database.
+type Status struct {
+	Version    uint
+	Dirty      bool
+	Migrations []Migration
+}
+
+// newMigrate wraps a connection of db, released by closing the returned
+// Migrate. The postgres driver holds a postgres advisory lock while migrating,
+// so replicas migrating the same database don't race.
+func newMigrate(db *sql.DB) (*migrate.Migrate, error) {
+	source, err := iofs.New(FS, ".")
+	if err != nil {
+		return nil, err
+	}
+
+	ctx := context.Background()
+	conn, err := db.Conn(ctx)
+	if err != nil {
+		return nil, err
+	}
+	driver, err := postgres.WithConnection(ctx, conn, &postgres.Config{})
+	if err != nil {
+		conn.Close()
+		return nil, err
+	}
+	return migrate.NewWithInstance("iofs", source, "postgres", driver)
+}
+
+// Up applies all pending migrations.
+func Up(db *sql.DB) error {
+	m, err := newMigrate(db)
+	if err != nil {
+		return err
+	}
+	defer m.Close()
+	err = m.Up()
+	if errors.Is(err, migrate.ErrNoChange) {
+		return nil
+	}
+	return err
+}
+
+// Down rolls back the last steps applied migrations.
+func Down(db *sql.DB, steps int) error {
+	if steps <= 0 {
+		return fmt.Errorf("steps must be positive, got %d", steps)
+	}
+	m, err := newMigrate(db)
+	if err != nil {
+		return err
+	}
+	defer m.Close()
+	return m.Steps(-steps)
+}
+
+// Force sets the schema version without running any migration, to recover
+// from a dirty state after a failed migration was fixed by hand.
+func Force(db *sql.DB, version int) error {
+	m, err := newMigrate(db)
+	if err != nil {
+		return err
+	}
+	defer m.Close()
+	return m.Force(version)
+}
+
+// GetStatus returns the version of the schema and the state of each
+// migration.
+func GetStatus(db *sql.DB) (*Status, error) {
+	m, err := newMigrate(db)
+	if err != nil {
+		return nil, err
+	}
+	defer m.Close()
+
+	status := Status{}
+	status.Version, status.Dirty, err = m.Version()
+	if err != nil && !errors.Is(err, migrate.ErrNilVersion) {
+		return nil, err
+	}
+
+	status.Migrations, err = List()
+	if err != nil {
+		return nil, err
+	}
+	setApplied(&status)
+	return &status, nil
+}
+
+func setApplied(status *Status) {
+	for n, mig := range status.Migrations {
+		if mig.Version == status.Version && status.Dirty {
+			status.Migrations[n].Dirty = true
+		} else {
+			status.Migrations[n].Applied = mig.Version <= status.Version
+		}
+	}
+}
+
+// List returns the embedded migrations ordered by version.
+func List() ([]Migration, error) {
+	files, err := fs.Glob(FS, "*.up.sql")
+	if err != nil {
+		return nil, err
+	}
+
+	res := []Migration{}
+	for _, file := range files {
+		version, name, _ := strings.Cut(strings.TrimSuffix(file, ".up.sql"), "_")
+		num, err := strconv.ParseUint(version, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid migration file name: %s", file)
+		}
+		res = append(res, Migration{Version: uint(num), Name: name})
+	}
+	sort.Slice(res, func(i, j int) bool { return res[i].Version < res[j].Version })
+	return res, nil
+}
@@ -0,0 +1,50 @@
+package migrations
+
+import (
+	"io/fs"
+	"strings"
+	"testing"
+)
+
+func TestList(t *testing.T) {
+	list, err := List()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(list) == 0 || list[0].Version != 1 || list[0].Name != "create_tables" {
+		t.Fatalf("unexpected migrations: %v", list)
+	}
+	for n := 1; n < len(list); n++ {
+		if list[n].Version <= list[n-1].Version {
+			t.Errorf("migrations are not ordered: %v", list)
+		}
+	}
+}
+
+func TestEveryUpHasDown(t *testing.T) {
+	ups, _ := fs.Glob(FS, "*.up.sql")
+	for _, up := range ups {
+		down := strings.TrimSuffix(up, ".up.sql") + ".down.sql"
+		if _, err := fs.Stat(FS, down); err != nil {
+			t.Errorf("missing %s", down)
+		}
+	}
+}
+
+func TestSetApplied(t *testing.T) {
+	status := Status{Version: 2, Dirty: true, Migrations: []Migration{
+		{Version: 1}, {Version: 2}, {Version: 3},
+	}}
+	setApplied(&status)
+	first, second, third := status.Migrations[0], status.Migrations[1],
+		status.Migrations[2]
+	if !first.Applied || first.Dirty {
+		t.Errorf("expected the first migration to be applied, got %v", first)
+	}
+	if second.Applied || !second.Dirty {
+		t.Errorf("expected the failed migration to be dirty, got %v", second)
+	}
+	if third.Applied || third.Dirty {
+		t.Errorf("expected the last migration to be pending, got %v", third)
+	}
+}
@@ -0,0 +1,205 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.2.0
+// - protoc             v3.12.4
+// source: admin.proto
+
+package admin
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+// AdminClient is the client API for Admin service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type AdminClient interface {
+	ListDeleted(ctx context.Context, in *RequestListDeleted, opts ...grpc.CallOption) (*ResponseListDeleted, error)
+	PurgeUserContent(ctx context.Context, in *RequestPurgeUserContent, opts ...grpc.CallOption) (*ResponsePurgeUserContent, error)
+	ExportUserContent(ctx context.Context, in *RequestExportUserContent, opts ...grpc.CallOption) (Admin_ExportUserContentClient, error)
+}
+
+type adminClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewAdminClient(cc grpc.ClientConnInterface) AdminClient {
+	return &adminClient{cc}
+}
+
+func (c *adminClient) ListDeleted(ctx context.Context, in *RequestListDeleted, opts ...grpc.CallOption) (*ResponseListDeleted, error) {
+	out := new(ResponseListDeleted)
+	err := c.cc.Invoke(ctx, "/admin.Admin/ListDeleted", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminClient) PurgeUserContent(ctx context.Context, in *RequestPurgeUserContent, opts ...grpc.CallOption) (*ResponsePurgeUserContent, error) {
+	out := new(ResponsePurgeUserContent)
+	err := c.cc.Invoke(ctx, "/admin.Admin/PurgeUserContent", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminClient) ExportUserContent(ctx context.Context, in *RequestExportUserContent, opts ...grpc.CallOption) (Admin_ExportUserContentClient, error) {
+	stream, err := c.cc.NewStream(ctx, &Admin_ServiceDesc.Streams[0], "/admin.Admin/ExportUserContent", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &adminExportUserContentClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Admin_ExportUserContentClient interface {
+	Recv() (*ExportChunk, error)
+	grpc.ClientStream
+}
+
+type adminExportUserContentClient struct {
+	grpc.ClientStream
+}
+
+func (x *adminExportUserContentClient) Recv() (*ExportChunk, error) {
+	m := new(ExportChunk)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// AdminServer is the server API for Admin service.
+// All implementations must embed UnimplementedAdminServer
+// for forward compatibility
+type AdminServer interface {
+	ListDeleted(context.Context, *RequestListDeleted) (*ResponseListDeleted, error)
+	PurgeUserContent(context.Context, *RequestPurgeUserContent) (*ResponsePurgeUserContent, error)
+	ExportUserContent(*RequestExportUserContent, Admin_ExportUserContentServer) error
+	mustEmbedUnimplementedAdminServer()
+}
+
+// UnimplementedAdminServer must be embedded to have forward compatible implementations.
+type UnimplementedAdminServer struct {
+}
+
+func (UnimplementedAdminServer) ListDeleted(context.Context, *RequestListDeleted) (*ResponseListDeleted, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListDeleted not implemented")
+}
+func (UnimplementedAdminServer) PurgeUserContent(context.Context, *RequestPurgeUserContent) (*ResponsePurgeUserContent, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PurgeUserContent not implemented")
+}
+func (UnimplementedAdminServer) ExportUserContent(*RequestExportUserContent, Admin_ExportUserContentServer) error {
+	return status.Errorf(codes.Unimplemented, "method ExportUserContent not implemented")
+}
+func (UnimplementedAdminServer) mustEmbedUnimplementedAdminServer() {}
+
+// UnsafeAdminServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to AdminServer will
+// result in compilation errors.
+type UnsafeAdminServer interface {
+	mustEmbedUnimplementedAdminServer()
+}
+
+func RegisterAdminServer(s grpc.ServiceRegistrar, srv AdminServer) {
+	s.RegisterService(&Admin_ServiceDesc, srv)
+}
+
+func _Admin_ListDeleted_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RequestListDeleted)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServer).ListDeleted(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/admin.Admin/ListDeleted",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServer).ListDeleted(ctx, req.(*RequestListDeleted))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Admin_PurgeUserContent_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RequestPurgeUserContent)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServer).PurgeUserContent(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/admin.Admin/PurgeUserContent",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServer).PurgeUserContent(ctx, req.(*RequestPurgeUserContent))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Admin_ExportUserContent_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(RequestExportUserContent)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(AdminServer).ExportUserContent(m, &adminExportUserContentServer{stream})
+}
+
+type Admin_ExportUserContentServer interface {
+	Send(*ExportChunk) error
+	grpc.ServerStream
+}
+
+type adminExportUserContentServer struct {
+	grpc.ServerStream
+}
+
+func (x *adminExportUserContentServer) Send(m *ExportChunk) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+// Admin_ServiceDesc is the grpc.ServiceDesc for Admin service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var Admin_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "admin.Admin",
+	HandlerType: (*AdminServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "ListDeleted",
+			Handler:    _Admin_ListDeleted_Handler,
+		},
+		{
+			MethodName: "PurgeUserContent",
+			Handler:    _Admin_PurgeUserContent_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "ExportUserContent",
+			Handler:       _Admin_ExportUserContent_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "admin.proto",
+}
@@ -251,6 +251,9 @@ type Destination struct {
 	StartDate  string   `protobuf:"bytes,2,opt,name=start_date,json=startDate,proto3" json:"start_date,omitempty"`
 	EndDate    string   `protobuf:"bytes,3,opt,name=end_date,json=endDate,proto3" json:"end_date,omitempty"`
 	Activities []string `protobuf:"bytes,4,rep,name=activities,proto3" json:"activities,omitempty"`
+	// set both or neither
+	Latitude  *float64 `protobuf:"fixed64,5,opt,name=latitude,proto3,oneof" json:"latitude,omitempty"`
+	Longitude *float64 `protobuf:"fixed64,6,opt,name=longitude,proto3,oneof" json:"longitude,omitempty"`
 }
 
 func (x *Destination) Reset() {
@@ -313,6 +316,20 @@ func (x *Destination) GetActivities() []string {
 	return nil
 }
 
+func (x *Destination) GetLatitude() float64 {
+	if x != nil && x.Latitude != nil {
+		return *x.Latitude
+	}
+	return 0
+}
+
+func (x *Destination) GetLongitude() float64 {
+	if x != nil && x.Longitude != nil {
+		return *x.Longitude
+	}
+	return 0
+}
+
 type RequestCreateItineraries struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -560,6 +577,8 @@ type DestinationEdit struct {
 	StartDate  string      `protobuf:"bytes,3,opt,name=start_date,json=startDate,proto3" json:"start_date,omitempty"`
 	EndDate    string      `protobuf:"bytes,4,opt,name=end_date,json=endDate,proto3" json:"end_date,omitempty"`
 	Activities []*Activity `protobuf:"bytes,5,rep,name=activities,proto3" json:"activities,omitempty"`
+	Latitude   *float64    `protobuf:"fixed64,6,opt,name=latitude,proto3,oneof" json:"latitude,omitempty"`
+	Longitude  *float64    `protobuf:"fixed64,7,opt,name=longitude,proto3,oneof" json:"longitude,omitempty"`
 }
 
 func (x *DestinationEdit) Reset() {
@@ -629,6 +648,20 @@ func (x *DestinationEdit) GetActivities() []*Activity {
 	return nil
 }
 
+func (x *DestinationEdit) GetLatitude() float64 {
+	if x != nil && x.Latitude != nil {
+		return *x.Latitude
+	}
+	return 0
+}
+
+func (x *DestinationEdit) GetLongitude() float64 {
+	if x != nil && x.Longitude != nil {
+		return *x.Longitude
+	}
+	return 0
+}
+
 type RequestEditItineraries struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -906,11 +939,464 @@ func (*ResponseDeleteItineraries) Descriptor() ([]byte, []int) {
 	return file_itineraries_proto_rawDescGZIP(), []int{10}
 }
 
-func (x *ResponseDeleteItineraries) GetMessage() string {
+func (x *ResponseDeleteItineraries) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+type RequestRestoreItinerary struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id       string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	AuthorId string `protobuf:"bytes,2,opt,name=author_id,json=authorId,proto3" json:"author_id,omitempty"`
+}
+
+func (x *RequestRestoreItinerary) Reset() {
+	*x = RequestRestoreItinerary{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_itineraries_proto_msgTypes[11]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RequestRestoreItinerary) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RequestRestoreItinerary) ProtoMessage() {}
+
+func (x *RequestRestoreItinerary) ProtoReflect() protoreflect.Message {
+	mi := &file_itineraries_proto_msgTypes[11]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RequestRestoreItinerary.ProtoReflect.Descriptor instead.
+func (*RequestRestoreItinerary) Descriptor() ([]byte, []int) {
+	return file_itineraries_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *RequestRestoreItinerary) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *RequestRestoreItinerary) GetAuthorId() string {
+	if x != nil {
+		return x.AuthorId
+	}
+	return ""
+}
+
+type ResponseRestoreItinerary struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Message string `protobuf:"bytes,1,opt,name=message,proto3" json:"message,omitempty"`
+}
+
+func (x *ResponseRestoreItinerary) Reset() {
+	*x = ResponseRestoreItinerary{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_itineraries_proto_msgTypes[12]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ResponseRestoreItinerary) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResponseRestoreItinerary) ProtoMessage() {}
+
+func (x *ResponseRestoreItinerary) ProtoReflect() protoreflect.Message {
+	mi := &file_itineraries_proto_msgTypes[12]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ResponseRestoreItinerary.ProtoReflect.Descriptor instead.
+func (*ResponseRestoreItinerary) Descriptor() ([]byte, []int) {
+	return file_itineraries_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *ResponseRestoreItinerary) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+type RequestExportItinerary struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// "ics" (the default), "markdown", "html" or "gpx"; gpx needs destinations
+	// with coordinates
+	Format string `protobuf:"bytes,2,opt,name=format,proto3" json:"format,omitempty"`
+}
+
+func (x *RequestExportItinerary) Reset() {
+	*x = RequestExportItinerary{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_itineraries_proto_msgTypes[13]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RequestExportItinerary) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RequestExportItinerary) ProtoMessage() {}
+
+func (x *RequestExportItinerary) ProtoReflect() protoreflect.Message {
+	mi := &file_itineraries_proto_msgTypes[13]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RequestExportItinerary.ProtoReflect.Descriptor instead.
+func (*RequestExportItinerary) Descriptor() ([]byte, []int) {
+	return file_itineraries_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *RequestExportItinerary) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *RequestExportItinerary) GetFormat() string {
+	if x != nil {
+		return x.Format
+	}
+	return ""
+}
+
+type ResponseExportItinerary struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Filename    string `protobuf:"bytes,1,opt,name=filename,proto3" json:"filename,omitempty"`
+	ContentType string `protobuf:"bytes,2,opt,name=content_type,json=contentType,proto3" json:"content_type,omitempty"`
+	Data        []byte `protobuf:"bytes,3,opt,name=data,proto3" json:"data,omitempty"`
+}
+
+func (x *ResponseExportItinerary) Reset() {
+	*x = ResponseExportItinerary{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_itineraries_proto_msgTypes[14]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ResponseExportItinerary) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResponseExportItinerary) ProtoMessage() {}
+
+func (x *ResponseExportItinerary) ProtoReflect() protoreflect.Message {
+	mi := &file_itineraries_proto_msgTypes[14]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ResponseExportItinerary.ProtoReflect.Descriptor instead.
+func (*ResponseExportItinerary) Descriptor() ([]byte, []int) {
+	return file_itineraries_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *ResponseExportItinerary) GetFilename() string {
+	if x != nil {
+		return x.Filename
+	}
+	return ""
+}
+
+func (x *ResponseExportItinerary) GetContentType() string {
+	if x != nil {
+		return x.ContentType
+	}
+	return ""
+}
+
+func (x *ResponseExportItinerary) GetData() []byte {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+// requestImportItinerary creates an itinerary from an iCalendar file, whose
+// events become destinations and their descriptions activities, or from JSON
+// in the form of requestCreateItineraries with the field names as above, e.g.
+// {"title": "...", "start_date": "2024-07-16", "end_date": "2024-07-20",
+// "destinations": [{"name": "...", "start_date": "...", "end_date": "...",
+// "activities": ["..."], "latitude": 41.3, "longitude": 69.2}]}. The dates are
+// YYYY-MM-DD; the itinerary dates default to those of its destinations.
+type RequestImportItinerary struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	AuthorId string `protobuf:"bytes,1,opt,name=author_id,json=authorId,proto3" json:"author_id,omitempty"`
+	// "ics" or "json", detected from the file name or the data when empty
+	Format string `protobuf:"bytes,2,opt,name=format,proto3" json:"format,omitempty"`
+	// the title of a calendar without a name defaults to the file name
+	Filename string `protobuf:"bytes,3,opt,name=filename,proto3" json:"filename,omitempty"`
+	Data     []byte `protobuf:"bytes,4,opt,name=data,proto3" json:"data,omitempty"`
+}
+
+func (x *RequestImportItinerary) Reset() {
+	*x = RequestImportItinerary{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_itineraries_proto_msgTypes[15]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RequestImportItinerary) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RequestImportItinerary) ProtoMessage() {}
+
+func (x *RequestImportItinerary) ProtoReflect() protoreflect.Message {
+	mi := &file_itineraries_proto_msgTypes[15]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RequestImportItinerary.ProtoReflect.Descriptor instead.
+func (*RequestImportItinerary) Descriptor() ([]byte, []int) {
+	return file_itineraries_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *RequestImportItinerary) GetAuthorId() string {
+	if x != nil {
+		return x.AuthorId
+	}
+	return ""
+}
+
+func (x *RequestImportItinerary) GetFormat() string {
+	if x != nil {
+		return x.Format
+	}
+	return ""
+}
+
+func (x *RequestImportItinerary) GetFilename() string {
+	if x != nil {
+		return x.Filename
+	}
+	return ""
+}
+
+func (x *RequestImportItinerary) GetData() []byte {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+type ImportWarning struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// the event or destination skipped or changed, e.g. "destination 2"
+	Item    string `protobuf:"bytes,1,opt,name=item,proto3" json:"item,omitempty"`
+	Message string `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+}
+
+func (x *ImportWarning) Reset() {
+	*x = ImportWarning{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_itineraries_proto_msgTypes[16]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ImportWarning) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ImportWarning) ProtoMessage() {}
+
+func (x *ImportWarning) ProtoReflect() protoreflect.Message {
+	mi := &file_itineraries_proto_msgTypes[16]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ImportWarning.ProtoReflect.Descriptor instead.
+func (*ImportWarning) Descriptor() ([]byte, []int) {
+	return file_itineraries_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *ImportWarning) GetItem() string {
+	if x != nil {
+		return x.Item
+	}
+	return ""
+}
+
+func (x *ImportWarning) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+type ResponseImportItinerary struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id           string           `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Title        string           `protobuf:"bytes,2,opt,name=title,proto3" json:"title,omitempty"`
+	StartDate    string           `protobuf:"bytes,3,opt,name=start_date,json=startDate,proto3" json:"start_date,omitempty"`
+	EndDate      string           `protobuf:"bytes,4,opt,name=end_date,json=endDate,proto3" json:"end_date,omitempty"`
+	Destinations int64            `protobuf:"varint,5,opt,name=destinations,proto3" json:"destinations,omitempty"`
+	Activities   int64            `protobuf:"varint,6,opt,name=activities,proto3" json:"activities,omitempty"`
+	Warnings     []*ImportWarning `protobuf:"bytes,7,rep,name=warnings,proto3" json:"warnings,omitempty"`
+}
+
+func (x *ResponseImportItinerary) Reset() {
+	*x = ResponseImportItinerary{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_itineraries_proto_msgTypes[17]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ResponseImportItinerary) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResponseImportItinerary) ProtoMessage() {}
+
+func (x *ResponseImportItinerary) ProtoReflect() protoreflect.Message {
+	mi := &file_itineraries_proto_msgTypes[17]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ResponseImportItinerary.ProtoReflect.Descriptor instead.
+func (*ResponseImportItinerary) Descriptor() ([]byte, []int) {
+	return file_itineraries_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *ResponseImportItinerary) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *ResponseImportItinerary) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *ResponseImportItinerary) GetStartDate() string {
+	if x != nil {
+		return x.StartDate
+	}
+	return ""
+}
+
+func (x *ResponseImportItinerary) GetEndDate() string {
+	if x != nil {
+		return x.EndDate
+	}
+	return ""
+}
+
+func (x *ResponseImportItinerary) GetDestinations() int64 {
+	if x != nil {
+		return x.Destinations
+	}
+	return 0
+}
+
+func (x *ResponseImportItinerary) GetActivities() int64 {
+	if x != nil {
+		return x.Activities
+	}
+	return 0
+}
+
+func (x *ResponseImportItinerary) GetWarnings() []*ImportWarning {
 	if x != nil {
-		return x.Message
+		return x.Warnings
 	}
-	return ""
+	return nil
 }
 
 type RequestGetAllItineraries struct {
@@ -925,7 +1411,7 @@ type RequestGetAllItineraries struct {
 func (x *RequestGetAllItineraries) Reset() {
 	*x = RequestGetAllItineraries{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_itineraries_proto_msgTypes[11]
+		mi := &file_itineraries_proto_msgTypes[18]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -938,7 +1424,7 @@ func (x *RequestGetAllItineraries) String() string {
 func (*RequestGetAllItineraries) ProtoMessage() {}
 
 func (x *RequestGetAllItineraries) ProtoReflect() protoreflect.Message {
-	mi := &file_itineraries_proto_msgTypes[11]
+	mi := &file_itineraries_proto_msgTypes[18]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -951,7 +1437,7 @@ func (x *RequestGetAllItineraries) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use RequestGetAllItineraries.ProtoReflect.Descriptor instead.
 func (*RequestGetAllItineraries) Descriptor() ([]byte, []int) {
-	return file_itineraries_proto_rawDescGZIP(), []int{11}
+	return file_itineraries_proto_rawDescGZIP(), []int{18}
 }
 
 func (x *RequestGetAllItineraries) GetPage() int32 {
@@ -980,7 +1466,7 @@ type Author struct {
 func (x *Author) Reset() {
 	*x = Author{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_itineraries_proto_msgTypes[12]
+		mi := &file_itineraries_proto_msgTypes[19]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -993,7 +1479,7 @@ func (x *Author) String() string {
 func (*Author) ProtoMessage() {}
 
 func (x *Author) ProtoReflect() protoreflect.Message {
-	mi := &file_itineraries_proto_msgTypes[12]
+	mi := &file_itineraries_proto_msgTypes[19]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1006,7 +1492,7 @@ func (x *Author) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Author.ProtoReflect.Descriptor instead.
 func (*Author) Descriptor() ([]byte, []int) {
-	return file_itineraries_proto_rawDescGZIP(), []int{12}
+	return file_itineraries_proto_rawDescGZIP(), []int{19}
 }
 
 func (x *Author) GetId() string {
@@ -1041,7 +1527,7 @@ type Itinerary struct {
 func (x *Itinerary) Reset() {
 	*x = Itinerary{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_itineraries_proto_msgTypes[13]
+		mi := &file_itineraries_proto_msgTypes[20]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1054,7 +1540,7 @@ func (x *Itinerary) String() string {
 func (*Itinerary) ProtoMessage() {}
 
 func (x *Itinerary) ProtoReflect() protoreflect.Message {
-	mi := &file_itineraries_proto_msgTypes[13]
+	mi := &file_itineraries_proto_msgTypes[20]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1067,7 +1553,7 @@ func (x *Itinerary) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Itinerary.ProtoReflect.Descriptor instead.
 func (*Itinerary) Descriptor() ([]byte, []int) {
-	return file_itineraries_proto_rawDescGZIP(), []int{13}
+	return file_itineraries_proto_rawDescGZIP(), []int{20}
 }
 
 func (x *Itinerary) GetId() string {
@@ -1140,7 +1626,7 @@ type ResponseGetAllItineraries struct {
 func (x *ResponseGetAllItineraries) Reset() {
 	*x = ResponseGetAllItineraries{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_itineraries_proto_msgTypes[14]
+		mi := &file_itineraries_proto_msgTypes[21]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1153,7 +1639,7 @@ func (x *ResponseGetAllItineraries) String() string {
 func (*ResponseGetAllItineraries) ProtoMessage() {}
 
 func (x *ResponseGetAllItineraries) ProtoReflect() protoreflect.Message {
-	mi := &file_itineraries_proto_msgTypes[14]
+	mi := &file_itineraries_proto_msgTypes[21]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1166,7 +1652,7 @@ func (x *ResponseGetAllItineraries) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ResponseGetAllItineraries.ProtoReflect.Descriptor instead.
 func (*ResponseGetAllItineraries) Descriptor() ([]byte, []int) {
-	return file_itineraries_proto_rawDescGZIP(), []int{14}
+	return file_itineraries_proto_rawDescGZIP(), []int{21}
 }
 
 func (x *ResponseGetAllItineraries) GetItineraries() []*Itinerary {
@@ -1208,7 +1694,7 @@ type RequestGetItineraryFullInfo struct {
 func (x *RequestGetItineraryFullInfo) Reset() {
 	*x = RequestGetItineraryFullInfo{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_itineraries_proto_msgTypes[15]
+		mi := &file_itineraries_proto_msgTypes[22]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1221,7 +1707,7 @@ func (x *RequestGetItineraryFullInfo) String() string {
 func (*RequestGetItineraryFullInfo) ProtoMessage() {}
 
 func (x *RequestGetItineraryFullInfo) ProtoReflect() protoreflect.Message {
-	mi := &file_itineraries_proto_msgTypes[15]
+	mi := &file_itineraries_proto_msgTypes[22]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1234,7 +1720,7 @@ func (x *RequestGetItineraryFullInfo) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use RequestGetItineraryFullInfo.ProtoReflect.Descriptor instead.
 func (*RequestGetItineraryFullInfo) Descriptor() ([]byte, []int) {
-	return file_itineraries_proto_rawDescGZIP(), []int{15}
+	return file_itineraries_proto_rawDescGZIP(), []int{22}
 }
 
 func (x *RequestGetItineraryFullInfo) GetId() string {
@@ -1265,7 +1751,7 @@ type ResponseGetItineraryFullInfo struct {
 func (x *ResponseGetItineraryFullInfo) Reset() {
 	*x = ResponseGetItineraryFullInfo{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_itineraries_proto_msgTypes[16]
+		mi := &file_itineraries_proto_msgTypes[23]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1278,7 +1764,7 @@ func (x *ResponseGetItineraryFullInfo) String() string {
 func (*ResponseGetItineraryFullInfo) ProtoMessage() {}
 
 func (x *ResponseGetItineraryFullInfo) ProtoReflect() protoreflect.Message {
-	mi := &file_itineraries_proto_msgTypes[16]
+	mi := &file_itineraries_proto_msgTypes[23]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1291,7 +1777,7 @@ func (x *ResponseGetItineraryFullInfo) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ResponseGetItineraryFullInfo.ProtoReflect.Descriptor instead.
 func (*ResponseGetItineraryFullInfo) Descriptor() ([]byte, []int) {
-	return file_itineraries_proto_rawDescGZIP(), []int{16}
+	return file_itineraries_proto_rawDescGZIP(), []int{23}
 }
 
 func (x *ResponseGetItineraryFullInfo) GetId() string {
@@ -1384,7 +1870,7 @@ type RequestWriteCommentToItinerary struct {
 func (x *RequestWriteCommentToItinerary) Reset() {
 	*x = RequestWriteCommentToItinerary{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_itineraries_proto_msgTypes[17]
+		mi := &file_itineraries_proto_msgTypes[24]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1397,7 +1883,7 @@ func (x *RequestWriteCommentToItinerary) String() string {
 func (*RequestWriteCommentToItinerary) ProtoMessage() {}
 
 func (x *RequestWriteCommentToItinerary) ProtoReflect() protoreflect.Message {
-	mi := &file_itineraries_proto_msgTypes[17]
+	mi := &file_itineraries_proto_msgTypes[24]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1410,7 +1896,7 @@ func (x *RequestWriteCommentToItinerary) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use RequestWriteCommentToItinerary.ProtoReflect.Descriptor instead.
 func (*RequestWriteCommentToItinerary) Descriptor() ([]byte, []int) {
-	return file_itineraries_proto_rawDescGZIP(), []int{17}
+	return file_itineraries_proto_rawDescGZIP(), []int{24}
 }
 
 func (x *RequestWriteCommentToItinerary) GetAuthorId() string {
@@ -1449,7 +1935,7 @@ type ResponseWriteCommentToItinerary struct {
 func (x *ResponseWriteCommentToItinerary) Reset() {
 	*x = ResponseWriteCommentToItinerary{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_itineraries_proto_msgTypes[18]
+		mi := &file_itineraries_proto_msgTypes[25]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1462,7 +1948,7 @@ func (x *ResponseWriteCommentToItinerary) String() string {
 func (*ResponseWriteCommentToItinerary) ProtoMessage() {}
 
 func (x *ResponseWriteCommentToItinerary) ProtoReflect() protoreflect.Message {
-	mi := &file_itineraries_proto_msgTypes[18]
+	mi := &file_itineraries_proto_msgTypes[25]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1475,7 +1961,7 @@ func (x *ResponseWriteCommentToItinerary) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ResponseWriteCommentToItinerary.ProtoReflect.Descriptor instead.
 func (*ResponseWriteCommentToItinerary) Descriptor() ([]byte, []int) {
-	return file_itineraries_proto_rawDescGZIP(), []int{18}
+	return file_itineraries_proto_rawDescGZIP(), []int{25}
 }
 
 func (x *ResponseWriteCommentToItinerary) GetId() string {
@@ -1525,7 +2011,7 @@ type RequestGetDestinations struct {
 func (x *RequestGetDestinations) Reset() {
 	*x = RequestGetDestinations{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_itineraries_proto_msgTypes[19]
+		mi := &file_itineraries_proto_msgTypes[26]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1538,7 +2024,7 @@ func (x *RequestGetDestinations) String() string {
 func (*RequestGetDestinations) ProtoMessage() {}
 
 func (x *RequestGetDestinations) ProtoReflect() protoreflect.Message {
-	mi := &file_itineraries_proto_msgTypes[19]
+	mi := &file_itineraries_proto_msgTypes[26]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1551,7 +2037,7 @@ func (x *RequestGetDestinations) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use RequestGetDestinations.ProtoReflect.Descriptor instead.
 func (*RequestGetDestinations) Descriptor() ([]byte, []int) {
-	return file_itineraries_proto_rawDescGZIP(), []int{19}
+	return file_itineraries_proto_rawDescGZIP(), []int{26}
 }
 
 func (x *RequestGetDestinations) GetPage() int32 {
@@ -1582,7 +2068,7 @@ type DestionationInfo struct {
 func (x *DestionationInfo) Reset() {
 	*x = DestionationInfo{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_itineraries_proto_msgTypes[20]
+		mi := &file_itineraries_proto_msgTypes[27]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1595,7 +2081,7 @@ func (x *DestionationInfo) String() string {
 func (*DestionationInfo) ProtoMessage() {}
 
 func (x *DestionationInfo) ProtoReflect() protoreflect.Message {
-	mi := &file_itineraries_proto_msgTypes[20]
+	mi := &file_itineraries_proto_msgTypes[27]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1608,7 +2094,7 @@ func (x *DestionationInfo) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DestionationInfo.ProtoReflect.Descriptor instead.
 func (*DestionationInfo) Descriptor() ([]byte, []int) {
-	return file_itineraries_proto_rawDescGZIP(), []int{20}
+	return file_itineraries_proto_rawDescGZIP(), []int{27}
 }
 
 func (x *DestionationInfo) GetId() string {
@@ -1653,7 +2139,7 @@ type ResponseGetDestinations struct {
 func (x *ResponseGetDestinations) Reset() {
 	*x = ResponseGetDestinations{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_itineraries_proto_msgTypes[21]
+		mi := &file_itineraries_proto_msgTypes[28]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1666,7 +2152,7 @@ func (x *ResponseGetDestinations) String() string {
 func (*ResponseGetDestinations) ProtoMessage() {}
 
 func (x *ResponseGetDestinations) ProtoReflect() protoreflect.Message {
-	mi := &file_itineraries_proto_msgTypes[21]
+	mi := &file_itineraries_proto_msgTypes[28]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1679,7 +2165,7 @@ func (x *ResponseGetDestinations) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ResponseGetDestinations.ProtoReflect.Descriptor instead.
 func (*ResponseGetDestinations) Descriptor() ([]byte, []int) {
-	return file_itineraries_proto_rawDescGZIP(), []int{21}
+	return file_itineraries_proto_rawDescGZIP(), []int{28}
 }
 
 func (x *ResponseGetDestinations) GetDestinations() []*DestionationInfo {
@@ -1721,7 +2207,7 @@ type RequestGetDestinationsAllInfo struct {
 func (x *RequestGetDestinationsAllInfo) Reset() {
 	*x = RequestGetDestinationsAllInfo{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_itineraries_proto_msgTypes[22]
+		mi := &file_itineraries_proto_msgTypes[29]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1734,7 +2220,7 @@ func (x *RequestGetDestinationsAllInfo) String() string {
 func (*RequestGetDestinationsAllInfo) ProtoMessage() {}
 
 func (x *RequestGetDestinationsAllInfo) ProtoReflect() protoreflect.Message {
-	mi := &file_itineraries_proto_msgTypes[22]
+	mi := &file_itineraries_proto_msgTypes[29]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1747,7 +2233,7 @@ func (x *RequestGetDestinationsAllInfo) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use RequestGetDestinationsAllInfo.ProtoReflect.Descriptor instead.
 func (*RequestGetDestinationsAllInfo) Descriptor() ([]byte, []int) {
-	return file_itineraries_proto_rawDescGZIP(), []int{22}
+	return file_itineraries_proto_rawDescGZIP(), []int{29}
 }
 
 func (x *RequestGetDestinationsAllInfo) GetDestinationId() string {
@@ -1776,7 +2262,7 @@ type ResponseGetDestinationsAllInfo struct {
 func (x *ResponseGetDestinationsAllInfo) Reset() {
 	*x = ResponseGetDestinationsAllInfo{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_itineraries_proto_msgTypes[23]
+		mi := &file_itineraries_proto_msgTypes[30]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1789,7 +2275,7 @@ func (x *ResponseGetDestinationsAllInfo) String() string {
 func (*ResponseGetDestinationsAllInfo) ProtoMessage() {}
 
 func (x *ResponseGetDestinationsAllInfo) ProtoReflect() protoreflect.Message {
-	mi := &file_itineraries_proto_msgTypes[23]
+	mi := &file_itineraries_proto_msgTypes[30]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1802,7 +2288,7 @@ func (x *ResponseGetDestinationsAllInfo) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ResponseGetDestinationsAllInfo.ProtoReflect.Descriptor instead.
 func (*ResponseGetDestinationsAllInfo) Descriptor() ([]byte, []int) {
-	return file_itineraries_proto_rawDescGZIP(), []int{23}
+	return file_itineraries_proto_rawDescGZIP(), []int{30}
 }
 
 func (x *ResponseGetDestinationsAllInfo) GetId() string {
@@ -1881,7 +2367,7 @@ type RequestWriteMessages struct {
 func (x *RequestWriteMessages) Reset() {
 	*x = RequestWriteMessages{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_itineraries_proto_msgTypes[24]
+		mi := &file_itineraries_proto_msgTypes[31]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1894,7 +2380,7 @@ func (x *RequestWriteMessages) String() string {
 func (*RequestWriteMessages) ProtoMessage() {}
 
 func (x *RequestWriteMessages) ProtoReflect() protoreflect.Message {
-	mi := &file_itineraries_proto_msgTypes[24]
+	mi := &file_itineraries_proto_msgTypes[31]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1907,7 +2393,7 @@ func (x *RequestWriteMessages) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use RequestWriteMessages.ProtoReflect.Descriptor instead.
 func (*RequestWriteMessages) Descriptor() ([]byte, []int) {
-	return file_itineraries_proto_rawDescGZIP(), []int{24}
+	return file_itineraries_proto_rawDescGZIP(), []int{31}
 }
 
 func (x *RequestWriteMessages) GetSenderId() string {
@@ -1946,7 +2432,7 @@ type ResponseWriteMessages struct {
 func (x *ResponseWriteMessages) Reset() {
 	*x = ResponseWriteMessages{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_itineraries_proto_msgTypes[25]
+		mi := &file_itineraries_proto_msgTypes[32]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1959,7 +2445,7 @@ func (x *ResponseWriteMessages) String() string {
 func (*ResponseWriteMessages) ProtoMessage() {}
 
 func (x *ResponseWriteMessages) ProtoReflect() protoreflect.Message {
-	mi := &file_itineraries_proto_msgTypes[25]
+	mi := &file_itineraries_proto_msgTypes[32]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1972,7 +2458,7 @@ func (x *ResponseWriteMessages) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ResponseWriteMessages.ProtoReflect.Descriptor instead.
 func (*ResponseWriteMessages) Descriptor() ([]byte, []int) {
-	return file_itineraries_proto_rawDescGZIP(), []int{25}
+	return file_itineraries_proto_rawDescGZIP(), []int{32}
 }
 
 func (x *ResponseWriteMessages) GetId() string {
@@ -2025,7 +2511,7 @@ type Message struct {
 func (x *Message) Reset() {
 	*x = Message{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_itineraries_proto_msgTypes[26]
+		mi := &file_itineraries_proto_msgTypes[33]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -2038,7 +2524,7 @@ func (x *Message) String() string {
 func (*Message) ProtoMessage() {}
 
 func (x *Message) ProtoReflect() protoreflect.Message {
-	mi := &file_itineraries_proto_msgTypes[26]
+	mi := &file_itineraries_proto_msgTypes[33]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2051,7 +2537,7 @@ func (x *Message) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Message.ProtoReflect.Descriptor instead.
 func (*Message) Descriptor() ([]byte, []int) {
-	return file_itineraries_proto_rawDescGZIP(), []int{26}
+	return file_itineraries_proto_rawDescGZIP(), []int{33}
 }
 
 func (x *Message) GetId() string {
@@ -2101,7 +2587,7 @@ type RequestGetMessages struct {
 func (x *RequestGetMessages) Reset() {
 	*x = RequestGetMessages{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_itineraries_proto_msgTypes[27]
+		mi := &file_itineraries_proto_msgTypes[34]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -2114,7 +2600,7 @@ func (x *RequestGetMessages) String() string {
 func (*RequestGetMessages) ProtoMessage() {}
 
 func (x *RequestGetMessages) ProtoReflect() protoreflect.Message {
-	mi := &file_itineraries_proto_msgTypes[27]
+	mi := &file_itineraries_proto_msgTypes[34]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2127,7 +2613,7 @@ func (x *RequestGetMessages) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use RequestGetMessages.ProtoReflect.Descriptor instead.
 func (*RequestGetMessages) Descriptor() ([]byte, []int) {
-	return file_itineraries_proto_rawDescGZIP(), []int{27}
+	return file_itineraries_proto_rawDescGZIP(), []int{34}
 }
 
 func (x *RequestGetMessages) GetPage() int32 {
@@ -2158,7 +2644,7 @@ type ResponseGetMessages struct {
 func (x *ResponseGetMessages) Reset() {
 	*x = ResponseGetMessages{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_itineraries_proto_msgTypes[28]
+		mi := &file_itineraries_proto_msgTypes[35]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -2171,7 +2657,7 @@ func (x *ResponseGetMessages) String() string {
 func (*ResponseGetMessages) ProtoMessage() {}
 
 func (x *ResponseGetMessages) ProtoReflect() protoreflect.Message {
-	mi := &file_itineraries_proto_msgTypes[28]
+	mi := &file_itineraries_proto_msgTypes[35]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2184,7 +2670,7 @@ func (x *ResponseGetMessages) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ResponseGetMessages.ProtoReflect.Descriptor instead.
 func (*ResponseGetMessages) Descriptor() ([]byte, []int) {
-	return file_itineraries_proto_rawDescGZIP(), []int{28}
+	return file_itineraries_proto_rawDescGZIP(), []int{35}
 }
 
 func (x *ResponseGetMessages) GetMessages() []*Message {
@@ -2226,7 +2712,7 @@ type RequestGetUserStatistic struct {
 func (x *RequestGetUserStatistic) Reset() {
 	*x = RequestGetUserStatistic{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_itineraries_proto_msgTypes[29]
+		mi := &file_itineraries_proto_msgTypes[36]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -2239,7 +2725,7 @@ func (x *RequestGetUserStatistic) String() string {
 func (*RequestGetUserStatistic) ProtoMessage() {}
 
 func (x *RequestGetUserStatistic) ProtoReflect() protoreflect.Message {
-	mi := &file_itineraries_proto_msgTypes[29]
+	mi := &file_itineraries_proto_msgTypes[36]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2252,7 +2738,7 @@ func (x *RequestGetUserStatistic) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use RequestGetUserStatistic.ProtoReflect.Descriptor instead.
 func (*RequestGetUserStatistic) Descriptor() ([]byte, []int) {
-	return file_itineraries_proto_rawDescGZIP(), []int{29}
+	return file_itineraries_proto_rawDescGZIP(), []int{36}
 }
 
 func (x *RequestGetUserStatistic) GetUserId() string {
@@ -2275,7 +2761,7 @@ type PopularStoriy struct {
 func (x *PopularStoriy) Reset() {
 	*x = PopularStoriy{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_itineraries_proto_msgTypes[30]
+		mi := &file_itineraries_proto_msgTypes[37]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -2288,7 +2774,7 @@ func (x *PopularStoriy) String() string {
 func (*PopularStoriy) ProtoMessage() {}
 
 func (x *PopularStoriy) ProtoReflect() protoreflect.Message {
-	mi := &file_itineraries_proto_msgTypes[30]
+	mi := &file_itineraries_proto_msgTypes[37]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2301,7 +2787,7 @@ func (x *PopularStoriy) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use PopularStoriy.ProtoReflect.Descriptor instead.
 func (*PopularStoriy) Descriptor() ([]byte, []int) {
-	return file_itineraries_proto_rawDescGZIP(), []int{30}
+	return file_itineraries_proto_rawDescGZIP(), []int{37}
 }
 
 func (x *PopularStoriy) GetId() string {
@@ -2338,7 +2824,7 @@ type PopularItinerary struct {
 func (x *PopularItinerary) Reset() {
 	*x = PopularItinerary{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_itineraries_proto_msgTypes[31]
+		mi := &file_itineraries_proto_msgTypes[38]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -2351,7 +2837,7 @@ func (x *PopularItinerary) String() string {
 func (*PopularItinerary) ProtoMessage() {}
 
 func (x *PopularItinerary) ProtoReflect() protoreflect.Message {
-	mi := &file_itineraries_proto_msgTypes[31]
+	mi := &file_itineraries_proto_msgTypes[38]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2364,7 +2850,7 @@ func (x *PopularItinerary) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use PopularItinerary.ProtoReflect.Descriptor instead.
 func (*PopularItinerary) Descriptor() ([]byte, []int) {
-	return file_itineraries_proto_rawDescGZIP(), []int{31}
+	return file_itineraries_proto_rawDescGZIP(), []int{38}
 }
 
 func (x *PopularItinerary) GetId() string {
@@ -2406,7 +2892,7 @@ type ResponseGetUserStatistic struct {
 func (x *ResponseGetUserStatistic) Reset() {
 	*x = ResponseGetUserStatistic{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_itineraries_proto_msgTypes[32]
+		mi := &file_itineraries_proto_msgTypes[39]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -2419,7 +2905,7 @@ func (x *ResponseGetUserStatistic) String() string {
 func (*ResponseGetUserStatistic) ProtoMessage() {}
 
 func (x *ResponseGetUserStatistic) ProtoReflect() protoreflect.Message {
-	mi := &file_itineraries_proto_msgTypes[32]
+	mi := &file_itineraries_proto_msgTypes[39]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2432,7 +2918,7 @@ func (x *ResponseGetUserStatistic) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ResponseGetUserStatistic.ProtoReflect.Descriptor instead.
 func (*ResponseGetUserStatistic) Descriptor() ([]byte, []int) {
-	return file_itineraries_proto_rawDescGZIP(), []int{32}
+	return file_itineraries_proto_rawDescGZIP(), []int{39}
 }
 
 func (x *ResponseGetUserStatistic) GetUserId() string {
@@ -2536,15 +3022,21 @@ var file_itineraries_proto_rawDesc = []byte{
 	0x5f, 0x73, 0x63, 0x6f, 0x72, 0x65, 0x18, 0x09, 0x20, 0x01, 0x28, 0x02, 0x52, 0x0f, 0x70, 0x6f,
 	0x70, 0x75, 0x6c, 0x61, 0x72, 0x69, 0x74, 0x79, 0x53, 0x63, 0x6f, 0x72, 0x65, 0x12, 0x1d, 0x0a,
 	0x0a, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x5f, 0x61, 0x74, 0x18, 0x0a, 0x20, 0x01, 0x28,
-	0x09, 0x52, 0x09, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x41, 0x74, 0x22, 0x7b, 0x0a, 0x0b,
-	0x64, 0x65, 0x73, 0x74, 0x69, 0x6e, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x12, 0x0a, 0x04, 0x6e,
-	0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12,
-	0x1d, 0x0a, 0x0a, 0x73, 0x74, 0x61, 0x72, 0x74, 0x5f, 0x64, 0x61, 0x74, 0x65, 0x18, 0x02, 0x20,
-	0x01, 0x28, 0x09, 0x52, 0x09, 0x73, 0x74, 0x61, 0x72, 0x74, 0x44, 0x61, 0x74, 0x65, 0x12, 0x19,
-	0x0a, 0x08, 0x65, 0x6e, 0x64, 0x5f, 0x64, 0x61, 0x74, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09,
-	0x52, 0x07, 0x65, 0x6e, 0x64, 0x44, 0x61, 0x74, 0x65, 0x12, 0x1e, 0x0a, 0x0a, 0x61, 0x63, 0x74,
-	0x69, 0x76, 0x69, 0x74, 0x69, 0x65, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0a, 0x61,
-	0x63, 0x74, 0x69, 0x76, 0x69, 0x74, 0x69, 0x65, 0x73, 0x22, 0xe7, 0x01, 0x0a, 0x18, 0x72, 0x65,
+	0x09, 0x52, 0x09, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x41, 0x74, 0x22, 0xda, 0x01, 0x0a,
+	0x0b, 0x64, 0x65, 0x73, 0x74, 0x69, 0x6e, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x12, 0x0a, 0x04,
+	0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65,
+	0x12, 0x1d, 0x0a, 0x0a, 0x73, 0x74, 0x61, 0x72, 0x74, 0x5f, 0x64, 0x61, 0x74, 0x65, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x73, 0x74, 0x61, 0x72, 0x74, 0x44, 0x61, 0x74, 0x65, 0x12,
+	0x19, 0x0a, 0x08, 0x65, 0x6e, 0x64, 0x5f, 0x64, 0x61, 0x74, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x07, 0x65, 0x6e, 0x64, 0x44, 0x61, 0x74, 0x65, 0x12, 0x1e, 0x0a, 0x0a, 0x61, 0x63,
+	0x74, 0x69, 0x76, 0x69, 0x74, 0x69, 0x65, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0a,
+	0x61, 0x63, 0x74, 0x69, 0x76, 0x69, 0x74, 0x69, 0x65, 0x73, 0x12, 0x1f, 0x0a, 0x08, 0x6c, 0x61,
+	0x74, 0x69, 0x74, 0x75, 0x64, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x01, 0x48, 0x00, 0x52, 0x08,
+	0x6c, 0x61, 0x74, 0x69, 0x74, 0x75, 0x64, 0x65, 0x88, 0x01, 0x01, 0x12, 0x21, 0x0a, 0x09, 0x6c,
+	0x6f, 0x6e, 0x67, 0x69, 0x74, 0x75, 0x64, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x01, 0x48, 0x01,
+	0x52, 0x09, 0x6c, 0x6f, 0x6e, 0x67, 0x69, 0x74, 0x75, 0x64, 0x65, 0x88, 0x01, 0x01, 0x42, 0x0b,
+	0x0a, 0x09, 0x5f, 0x6c, 0x61, 0x74, 0x69, 0x74, 0x75, 0x64, 0x65, 0x42, 0x0c, 0x0a, 0x0a, 0x5f,
+	0x6c, 0x6f, 0x6e, 0x67, 0x69, 0x74, 0x75, 0x64, 0x65, 0x22, 0xe7, 0x01, 0x0a, 0x18, 0x72, 0x65,
 	0x71, 0x75, 0x65, 0x73, 0x74, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x49, 0x74, 0x69, 0x6e, 0x65,
 	0x72, 0x61, 0x72, 0x69, 0x65, 0x73, 0x12, 0x1b, 0x0a, 0x09, 0x61, 0x75, 0x74, 0x68, 0x65, 0x72,
 	0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x61, 0x75, 0x74, 0x68, 0x65,
@@ -2576,7 +3068,7 @@ var file_itineraries_proto_rawDesc = []byte{
 	0x36, 0x0a, 0x08, 0x61, 0x63, 0x74, 0x69, 0x76, 0x69, 0x74, 0x79, 0x12, 0x0e, 0x0a, 0x02, 0x69,
 	0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x1a, 0x0a, 0x08, 0x61,
 	0x63, 0x74, 0x69, 0x76, 0x69, 0x74, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x61,
-	0x63, 0x74, 0x69, 0x76, 0x69, 0x74, 0x79, 0x22, 0xa6, 0x01, 0x0a, 0x0f, 0x64, 0x65, 0x73, 0x74,
+	0x63, 0x74, 0x69, 0x76, 0x69, 0x74, 0x79, 0x22, 0x85, 0x02, 0x0a, 0x0f, 0x64, 0x65, 0x73, 0x74,
 	0x69, 0x6e, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x45, 0x64, 0x69, 0x74, 0x12, 0x0e, 0x0a, 0x02, 0x69,
 	0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x6e,
 	0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12,
@@ -2587,272 +3079,342 @@ var file_itineraries_proto_rawDesc = []byte{
 	0x69, 0x76, 0x69, 0x74, 0x69, 0x65, 0x73, 0x18, 0x05, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x15, 0x2e,
 	0x69, 0x74, 0x69, 0x6e, 0x65, 0x72, 0x61, 0x72, 0x69, 0x65, 0x73, 0x2e, 0x61, 0x63, 0x74, 0x69,
 	0x76, 0x69, 0x74, 0x79, 0x52, 0x0a, 0x61, 0x63, 0x74, 0x69, 0x76, 0x69, 0x74, 0x69, 0x65, 0x73,
-	0x22, 0xf9, 0x01, 0x0a, 0x16, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x45, 0x64, 0x69, 0x74,
-	0x49, 0x74, 0x69, 0x6e, 0x65, 0x72, 0x61, 0x72, 0x69, 0x65, 0x73, 0x12, 0x0e, 0x0a, 0x02, 0x69,
-	0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x74,
-	0x69, 0x74, 0x6c, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x74, 0x69, 0x74, 0x6c,
-	0x65, 0x12, 0x20, 0x0a, 0x0b, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e,
-	0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74,
-	0x69, 0x6f, 0x6e, 0x12, 0x1d, 0x0a, 0x0a, 0x73, 0x74, 0x61, 0x72, 0x74, 0x5f, 0x64, 0x61, 0x74,
-	0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x73, 0x74, 0x61, 0x72, 0x74, 0x44, 0x61,
-	0x74, 0x65, 0x12, 0x19, 0x0a, 0x08, 0x65, 0x6e, 0x64, 0x5f, 0x64, 0x61, 0x74, 0x65, 0x18, 0x05,
-	0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x65, 0x6e, 0x64, 0x44, 0x61, 0x74, 0x65, 0x12, 0x1b, 0x0a,
-	0x09, 0x61, 0x75, 0x74, 0x68, 0x6f, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09,
-	0x52, 0x08, 0x61, 0x75, 0x74, 0x68, 0x6f, 0x72, 0x49, 0x64, 0x12, 0x40, 0x0a, 0x0c, 0x64, 0x65,
-	0x73, 0x74, 0x69, 0x6e, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x07, 0x20, 0x03, 0x28, 0x0b,
-	0x32, 0x1c, 0x2e, 0x69, 0x74, 0x69, 0x6e, 0x65, 0x72, 0x61, 0x72, 0x69, 0x65, 0x73, 0x2e, 0x64,
-	0x65, 0x73, 0x74, 0x69, 0x6e, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x45, 0x64, 0x69, 0x74, 0x52, 0x0c,
-	0x64, 0x65, 0x73, 0x74, 0x69, 0x6e, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x22, 0xd7, 0x01, 0x0a,
-	0x17, 0x72, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x45, 0x64, 0x69, 0x74, 0x49, 0x74, 0x69,
-	0x6e, 0x65, 0x72, 0x61, 0x72, 0x69, 0x65, 0x73, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01,
-	0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x74, 0x69, 0x74, 0x6c,
-	0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x74, 0x69, 0x74, 0x6c, 0x65, 0x12, 0x20,
-	0x0a, 0x0b, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x03, 0x20,
-	0x01, 0x28, 0x09, 0x52, 0x0b, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e,
-	0x12, 0x1d, 0x0a, 0x0a, 0x73, 0x74, 0x61, 0x72, 0x74, 0x5f, 0x64, 0x61, 0x74, 0x65, 0x18, 0x04,
-	0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x73, 0x74, 0x61, 0x72, 0x74, 0x44, 0x61, 0x74, 0x65, 0x12,
-	0x19, 0x0a, 0x08, 0x65, 0x6e, 0x64, 0x5f, 0x64, 0x61, 0x74, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28,
-	0x09, 0x52, 0x07, 0x65, 0x6e, 0x64, 0x44, 0x61, 0x74, 0x65, 0x12, 0x1b, 0x0a, 0x09, 0x61, 0x75,
-	0x74, 0x68, 0x6f, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x61,
-	0x75, 0x74, 0x68, 0x6f, 0x72, 0x49, 0x64, 0x12, 0x1d, 0x0a, 0x0a, 0x75, 0x70, 0x64, 0x61, 0x74,
-	0x65, 0x64, 0x5f, 0x61, 0x74, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x75, 0x70, 0x64,
-	0x61, 0x74, 0x65, 0x64, 0x41, 0x74, 0x22, 0x2a, 0x0a, 0x18, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73,
-	0x74, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x49, 0x74, 0x69, 0x6e, 0x65, 0x72, 0x61, 0x72, 0x69,
-	0x65, 0x73, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02,
-	0x69, 0x64, 0x22, 0x35, 0x0a, 0x19, 0x72, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x44, 0x65,
-	0x6c, 0x65, 0x74, 0x65, 0x49, 0x74, 0x69, 0x6e, 0x65, 0x72, 0x61, 0x72, 0x69, 0x65, 0x73, 0x12,
-	0x18, 0x0a, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
-	0x52, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x22, 0x44, 0x0a, 0x18, 0x72, 0x65, 0x71,
-	0x75, 0x65, 0x73, 0x74, 0x47, 0x65, 0x74, 0x41, 0x6c, 0x6c, 0x49, 0x74, 0x69, 0x6e, 0x65, 0x72,
-	0x61, 0x72, 0x69, 0x65, 0x73, 0x12, 0x12, 0x0a, 0x04, 0x70, 0x61, 0x67, 0x65, 0x18, 0x01, 0x20,
-	0x01, 0x28, 0x05, 0x52, 0x04, 0x70, 0x61, 0x67, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x6c, 0x69, 0x6d,
-	0x69, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x22,
-	0x34, 0x0a, 0x06, 0x61, 0x75, 0x74, 0x68, 0x6f, 0x72, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18,
-	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x1a, 0x0a, 0x08, 0x75, 0x73, 0x65,
-	0x72, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x75, 0x73, 0x65,
-	0x72, 0x6e, 0x61, 0x6d, 0x65, 0x22, 0xff, 0x01, 0x0a, 0x09, 0x69, 0x74, 0x69, 0x6e, 0x65, 0x72,
+	0x12, 0x1f, 0x0a, 0x08, 0x6c, 0x61, 0x74, 0x69, 0x74, 0x75, 0x64, 0x65, 0x18, 0x06, 0x20, 0x01,
+	0x28, 0x01, 0x48, 0x00, 0x52, 0x08, 0x6c, 0x61, 0x74, 0x69, 0x74, 0x75, 0x64, 0x65, 0x88, 0x01,
+	0x01, 0x12, 0x21, 0x0a, 0x09, 0x6c, 0x6f, 0x6e, 0x67, 0x69, 0x74, 0x75, 0x64, 0x65, 0x18, 0x07,
+	0x20, 0x01, 0x28, 0x01, 0x48, 0x01, 0x52, 0x09, 0x6c, 0x6f, 0x6e, 0x67, 0x69, 0x74, 0x75, 0x64,
+	0x65, 0x88, 0x01, 0x01, 0x42, 0x0b, 0x0a, 0x09, 0x5f, 0x6c, 0x61, 0x74, 0x69, 0x74, 0x75, 0x64,
+	0x65, 0x42, 0x0c, 0x0a, 0x0a, 0x5f, 0x6c, 0x6f, 0x6e, 0x67, 0x69, 0x74, 0x75, 0x64, 0x65, 0x22,
+	0xf9, 0x01, 0x0a, 0x16, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x45, 0x64, 0x69, 0x74, 0x49,
+	0x74, 0x69, 0x6e, 0x65, 0x72, 0x61, 0x72, 0x69, 0x65, 0x73, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x74, 0x69,
+	0x74, 0x6c, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x74, 0x69, 0x74, 0x6c, 0x65,
+	0x12, 0x20, 0x0a, 0x0b, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69,
+	0x6f, 0x6e, 0x12, 0x1d, 0x0a, 0x0a, 0x73, 0x74, 0x61, 0x72, 0x74, 0x5f, 0x64, 0x61, 0x74, 0x65,
+	0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x73, 0x74, 0x61, 0x72, 0x74, 0x44, 0x61, 0x74,
+	0x65, 0x12, 0x19, 0x0a, 0x08, 0x65, 0x6e, 0x64, 0x5f, 0x64, 0x61, 0x74, 0x65, 0x18, 0x05, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x07, 0x65, 0x6e, 0x64, 0x44, 0x61, 0x74, 0x65, 0x12, 0x1b, 0x0a, 0x09,
+	0x61, 0x75, 0x74, 0x68, 0x6f, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x08, 0x61, 0x75, 0x74, 0x68, 0x6f, 0x72, 0x49, 0x64, 0x12, 0x40, 0x0a, 0x0c, 0x64, 0x65, 0x73,
+	0x74, 0x69, 0x6e, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x07, 0x20, 0x03, 0x28, 0x0b, 0x32,
+	0x1c, 0x2e, 0x69, 0x74, 0x69, 0x6e, 0x65, 0x72, 0x61, 0x72, 0x69, 0x65, 0x73, 0x2e, 0x64, 0x65,
+	0x73, 0x74, 0x69, 0x6e, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x45, 0x64, 0x69, 0x74, 0x52, 0x0c, 0x64,
+	0x65, 0x73, 0x74, 0x69, 0x6e, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x22, 0xd7, 0x01, 0x0a, 0x17,
+	0x72, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x45, 0x64, 0x69, 0x74, 0x49, 0x74, 0x69, 0x6e,
+	0x65, 0x72, 0x61, 0x72, 0x69, 0x65, 0x73, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x74, 0x69, 0x74, 0x6c, 0x65,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x74, 0x69, 0x74, 0x6c, 0x65, 0x12, 0x20, 0x0a,
+	0x0b, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x0b, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x12,
+	0x1d, 0x0a, 0x0a, 0x73, 0x74, 0x61, 0x72, 0x74, 0x5f, 0x64, 0x61, 0x74, 0x65, 0x18, 0x04, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x09, 0x73, 0x74, 0x61, 0x72, 0x74, 0x44, 0x61, 0x74, 0x65, 0x12, 0x19,
+	0x0a, 0x08, 0x65, 0x6e, 0x64, 0x5f, 0x64, 0x61, 0x74, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x07, 0x65, 0x6e, 0x64, 0x44, 0x61, 0x74, 0x65, 0x12, 0x1b, 0x0a, 0x09, 0x61, 0x75, 0x74,
+	0x68, 0x6f, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x61, 0x75,
+	0x74, 0x68, 0x6f, 0x72, 0x49, 0x64, 0x12, 0x1d, 0x0a, 0x0a, 0x75, 0x70, 0x64, 0x61, 0x74, 0x65,
+	0x64, 0x5f, 0x61, 0x74, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x75, 0x70, 0x64, 0x61,
+	0x74, 0x65, 0x64, 0x41, 0x74, 0x22, 0x2a, 0x0a, 0x18, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x49, 0x74, 0x69, 0x6e, 0x65, 0x72, 0x61, 0x72, 0x69, 0x65,
+	0x73, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69,
+	0x64, 0x22, 0x35, 0x0a, 0x19, 0x72, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x44, 0x65, 0x6c,
+	0x65, 0x74, 0x65, 0x49, 0x74, 0x69, 0x6e, 0x65, 0x72, 0x61, 0x72, 0x69, 0x65, 0x73, 0x12, 0x18,
+	0x0a, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x22, 0x46, 0x0a, 0x17, 0x72, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x52, 0x65, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x49, 0x74, 0x69, 0x6e, 0x65, 0x72,
 	0x61, 0x72, 0x79, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
-	0x02, 0x69, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x74, 0x69, 0x74, 0x6c, 0x65, 0x18, 0x02, 0x20, 0x01,
-	0x28, 0x09, 0x52, 0x05, 0x74, 0x69, 0x74, 0x6c, 0x65, 0x12, 0x2b, 0x0a, 0x06, 0x61, 0x75, 0x74,
-	0x68, 0x65, 0x72, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x69, 0x74, 0x69, 0x6e,
-	0x65, 0x72, 0x61, 0x72, 0x69, 0x65, 0x73, 0x2e, 0x61, 0x75, 0x74, 0x68, 0x6f, 0x72, 0x52, 0x06,
-	0x61, 0x75, 0x74, 0x68, 0x65, 0x72, 0x12, 0x1d, 0x0a, 0x0a, 0x73, 0x74, 0x61, 0x72, 0x74, 0x5f,
-	0x64, 0x61, 0x74, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x73, 0x74, 0x61, 0x72,
+	0x02, 0x69, 0x64, 0x12, 0x1b, 0x0a, 0x09, 0x61, 0x75, 0x74, 0x68, 0x6f, 0x72, 0x5f, 0x69, 0x64,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x61, 0x75, 0x74, 0x68, 0x6f, 0x72, 0x49, 0x64,
+	0x22, 0x34, 0x0a, 0x18, 0x72, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x52, 0x65, 0x73, 0x74,
+	0x6f, 0x72, 0x65, 0x49, 0x74, 0x69, 0x6e, 0x65, 0x72, 0x61, 0x72, 0x79, 0x12, 0x18, 0x0a, 0x07,
+	0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6d,
+	0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x22, 0x40, 0x0a, 0x16, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x45, 0x78, 0x70, 0x6f, 0x72, 0x74, 0x49, 0x74, 0x69, 0x6e, 0x65, 0x72, 0x61, 0x72, 0x79,
+	0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64,
+	0x12, 0x16, 0x0a, 0x06, 0x66, 0x6f, 0x72, 0x6d, 0x61, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x06, 0x66, 0x6f, 0x72, 0x6d, 0x61, 0x74, 0x22, 0x6c, 0x0a, 0x17, 0x72, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x45, 0x78, 0x70, 0x6f, 0x72, 0x74, 0x49, 0x74, 0x69, 0x6e, 0x65, 0x72,
+	0x61, 0x72, 0x79, 0x12, 0x1a, 0x0a, 0x08, 0x66, 0x69, 0x6c, 0x65, 0x6e, 0x61, 0x6d, 0x65, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x66, 0x69, 0x6c, 0x65, 0x6e, 0x61, 0x6d, 0x65, 0x12,
+	0x21, 0x0a, 0x0c, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x5f, 0x74, 0x79, 0x70, 0x65, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x54, 0x79,
+	0x70, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x64, 0x61, 0x74, 0x61, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0c,
+	0x52, 0x04, 0x64, 0x61, 0x74, 0x61, 0x22, 0x7d, 0x0a, 0x16, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x49, 0x6d, 0x70, 0x6f, 0x72, 0x74, 0x49, 0x74, 0x69, 0x6e, 0x65, 0x72, 0x61, 0x72, 0x79,
+	0x12, 0x1b, 0x0a, 0x09, 0x61, 0x75, 0x74, 0x68, 0x6f, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x08, 0x61, 0x75, 0x74, 0x68, 0x6f, 0x72, 0x49, 0x64, 0x12, 0x16, 0x0a,
+	0x06, 0x66, 0x6f, 0x72, 0x6d, 0x61, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x66,
+	0x6f, 0x72, 0x6d, 0x61, 0x74, 0x12, 0x1a, 0x0a, 0x08, 0x66, 0x69, 0x6c, 0x65, 0x6e, 0x61, 0x6d,
+	0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x66, 0x69, 0x6c, 0x65, 0x6e, 0x61, 0x6d,
+	0x65, 0x12, 0x12, 0x0a, 0x04, 0x64, 0x61, 0x74, 0x61, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0c, 0x52,
+	0x04, 0x64, 0x61, 0x74, 0x61, 0x22, 0x3d, 0x0a, 0x0d, 0x69, 0x6d, 0x70, 0x6f, 0x72, 0x74, 0x57,
+	0x61, 0x72, 0x6e, 0x69, 0x6e, 0x67, 0x12, 0x12, 0x0a, 0x04, 0x69, 0x74, 0x65, 0x6d, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x69, 0x74, 0x65, 0x6d, 0x12, 0x18, 0x0a, 0x07, 0x6d, 0x65,
+	0x73, 0x73, 0x61, 0x67, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6d, 0x65, 0x73,
+	0x73, 0x61, 0x67, 0x65, 0x22, 0xf5, 0x01, 0x0a, 0x17, 0x72, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x49, 0x6d, 0x70, 0x6f, 0x72, 0x74, 0x49, 0x74, 0x69, 0x6e, 0x65, 0x72, 0x61, 0x72, 0x79,
+	0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64,
+	0x12, 0x14, 0x0a, 0x05, 0x74, 0x69, 0x74, 0x6c, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x05, 0x74, 0x69, 0x74, 0x6c, 0x65, 0x12, 0x1d, 0x0a, 0x0a, 0x73, 0x74, 0x61, 0x72, 0x74, 0x5f,
+	0x64, 0x61, 0x74, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x73, 0x74, 0x61, 0x72,
 	0x74, 0x44, 0x61, 0x74, 0x65, 0x12, 0x19, 0x0a, 0x08, 0x65, 0x6e, 0x64, 0x5f, 0x64, 0x61, 0x74,
-	0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x65, 0x6e, 0x64, 0x44, 0x61, 0x74, 0x65,
-	0x12, 0x1f, 0x0a, 0x0b, 0x6c, 0x69, 0x6b, 0x65, 0x73, 0x5f, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18,
-	0x06, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0a, 0x6c, 0x69, 0x6b, 0x65, 0x73, 0x43, 0x6f, 0x75, 0x6e,
-	0x74, 0x12, 0x25, 0x0a, 0x0e, 0x63, 0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x5f, 0x63, 0x6f,
-	0x75, 0x6e, 0x74, 0x18, 0x07, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0d, 0x63, 0x6f, 0x6d, 0x6d, 0x65,
-	0x6e, 0x74, 0x73, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x1d, 0x0a, 0x0a, 0x63, 0x72, 0x65, 0x61,
-	0x74, 0x65, 0x64, 0x5f, 0x61, 0x74, 0x18, 0x08, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x63, 0x72,
-	0x65, 0x61, 0x74, 0x65, 0x64, 0x41, 0x74, 0x22, 0x95, 0x01, 0x0a, 0x19, 0x72, 0x65, 0x73, 0x70,
-	0x6f, 0x6e, 0x73, 0x65, 0x47, 0x65, 0x74, 0x41, 0x6c, 0x6c, 0x49, 0x74, 0x69, 0x6e, 0x65, 0x72,
-	0x61, 0x72, 0x69, 0x65, 0x73, 0x12, 0x38, 0x0a, 0x0b, 0x69, 0x74, 0x69, 0x6e, 0x65, 0x72, 0x61,
-	0x72, 0x69, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x69, 0x74, 0x69,
-	0x6e, 0x65, 0x72, 0x61, 0x72, 0x69, 0x65, 0x73, 0x2e, 0x69, 0x74, 0x69, 0x6e, 0x65, 0x72, 0x61,
-	0x72, 0x79, 0x52, 0x0b, 0x69, 0x74, 0x69, 0x6e, 0x65, 0x72, 0x61, 0x72, 0x69, 0x65, 0x73, 0x12,
-	0x14, 0x0a, 0x05, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x05,
-	0x74, 0x6f, 0x74, 0x61, 0x6c, 0x12, 0x12, 0x0a, 0x04, 0x70, 0x61, 0x67, 0x65, 0x18, 0x03, 0x20,
-	0x01, 0x28, 0x05, 0x52, 0x04, 0x70, 0x61, 0x67, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x6c, 0x69, 0x6d,
-	0x69, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x22,
-	0x2d, 0x0a, 0x1b, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x47, 0x65, 0x74, 0x49, 0x74, 0x69,
-	0x6e, 0x65, 0x72, 0x61, 0x72, 0x79, 0x46, 0x75, 0x6c, 0x6c, 0x49, 0x6e, 0x66, 0x6f, 0x12, 0x0e,
-	0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x22, 0x95,
-	0x03, 0x0a, 0x1c, 0x72, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x47, 0x65, 0x74, 0x49, 0x74,
-	0x69, 0x6e, 0x65, 0x72, 0x61, 0x72, 0x79, 0x46, 0x75, 0x6c, 0x6c, 0x49, 0x6e, 0x66, 0x6f, 0x12,
-	0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12,
-	0x14, 0x0a, 0x05, 0x74, 0x69, 0x74, 0x6c, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05,
-	0x74, 0x69, 0x74, 0x6c, 0x65, 0x12, 0x20, 0x0a, 0x0b, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70,
-	0x74, 0x69, 0x6f, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x64, 0x65, 0x73, 0x63,
-	0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x1d, 0x0a, 0x0a, 0x73, 0x74, 0x61, 0x72, 0x74,
-	0x5f, 0x64, 0x61, 0x74, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x73, 0x74, 0x61,
-	0x72, 0x74, 0x44, 0x61, 0x74, 0x65, 0x12, 0x19, 0x0a, 0x08, 0x65, 0x6e, 0x64, 0x5f, 0x64, 0x61,
-	0x74, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x65, 0x6e, 0x64, 0x44, 0x61, 0x74,
-	0x65, 0x12, 0x2b, 0x0a, 0x06, 0x61, 0x75, 0x74, 0x68, 0x6f, 0x72, 0x18, 0x06, 0x20, 0x01, 0x28,
-	0x0b, 0x32, 0x13, 0x2e, 0x69, 0x74, 0x69, 0x6e, 0x65, 0x72, 0x61, 0x72, 0x69, 0x65, 0x73, 0x2e,
-	0x61, 0x75, 0x74, 0x68, 0x6f, 0x72, 0x52, 0x06, 0x61, 0x75, 0x74, 0x68, 0x6f, 0x72, 0x12, 0x40,
-	0x0a, 0x0c, 0x64, 0x65, 0x73, 0x74, 0x69, 0x6e, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x07,
-	0x20, 0x03, 0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x69, 0x74, 0x69, 0x6e, 0x65, 0x72, 0x61, 0x72, 0x69,
-	0x65, 0x73, 0x2e, 0x64, 0x65, 0x73, 0x74, 0x69, 0x6e, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x45, 0x64,
-	0x69, 0x74, 0x52, 0x0c, 0x64, 0x65, 0x73, 0x74, 0x69, 0x6e, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73,
-	0x12, 0x1f, 0x0a, 0x0b, 0x6c, 0x69, 0x6b, 0x65, 0x73, 0x5f, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18,
-	0x08, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0a, 0x6c, 0x69, 0x6b, 0x65, 0x73, 0x43, 0x6f, 0x75, 0x6e,
-	0x74, 0x12, 0x25, 0x0a, 0x0e, 0x63, 0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x5f, 0x63, 0x6f,
-	0x75, 0x6e, 0x74, 0x18, 0x09, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0d, 0x63, 0x6f, 0x6d, 0x6d, 0x65,
-	0x6e, 0x74, 0x73, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x1d, 0x0a, 0x0a, 0x63, 0x72, 0x65, 0x61,
-	0x74, 0x65, 0x64, 0x5f, 0x61, 0x74, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x63, 0x72,
-	0x65, 0x61, 0x74, 0x65, 0x64, 0x41, 0x74, 0x12, 0x1d, 0x0a, 0x0a, 0x75, 0x70, 0x64, 0x61, 0x74,
-	0x65, 0x64, 0x5f, 0x61, 0x74, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x75, 0x70, 0x64,
-	0x61, 0x74, 0x65, 0x64, 0x41, 0x74, 0x22, 0x7a, 0x0a, 0x1e, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73,
-	0x74, 0x57, 0x72, 0x69, 0x74, 0x65, 0x43, 0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x74, 0x54, 0x6f, 0x49,
-	0x74, 0x69, 0x6e, 0x65, 0x72, 0x61, 0x72, 0x79, 0x12, 0x1b, 0x0a, 0x09, 0x61, 0x75, 0x74, 0x68,
-	0x6f, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x61, 0x75, 0x74,
-	0x68, 0x6f, 0x72, 0x49, 0x64, 0x12, 0x21, 0x0a, 0x0c, 0x69, 0x74, 0x69, 0x6e, 0x65, 0x72, 0x61,
-	0x72, 0x79, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x69, 0x74, 0x69,
-	0x6e, 0x65, 0x72, 0x61, 0x72, 0x79, 0x49, 0x64, 0x12, 0x18, 0x0a, 0x07, 0x63, 0x6f, 0x6e, 0x74,
-	0x65, 0x6e, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x63, 0x6f, 0x6e, 0x74, 0x65,
-	0x6e, 0x74, 0x22, 0xaa, 0x01, 0x0a, 0x1f, 0x72, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x57,
-	0x72, 0x69, 0x74, 0x65, 0x43, 0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x74, 0x54, 0x6f, 0x49, 0x74, 0x69,
+	0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x65, 0x6e, 0x64, 0x44, 0x61, 0x74, 0x65,
+	0x12, 0x22, 0x0a, 0x0c, 0x64, 0x65, 0x73, 0x74, 0x69, 0x6e, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73,
+	0x18, 0x05, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0c, 0x64, 0x65, 0x73, 0x74, 0x69, 0x6e, 0x61, 0x74,
+	0x69, 0x6f, 0x6e, 0x73, 0x12, 0x1e, 0x0a, 0x0a, 0x61, 0x63, 0x74, 0x69, 0x76, 0x69, 0x74, 0x69,
+	0x65, 0x73, 0x18, 0x06, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0a, 0x61, 0x63, 0x74, 0x69, 0x76, 0x69,
+	0x74, 0x69, 0x65, 0x73, 0x12, 0x36, 0x0a, 0x08, 0x77, 0x61, 0x72, 0x6e, 0x69, 0x6e, 0x67, 0x73,
+	0x18, 0x07, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x69, 0x74, 0x69, 0x6e, 0x65, 0x72, 0x61,
+	0x72, 0x69, 0x65, 0x73, 0x2e, 0x69, 0x6d, 0x70, 0x6f, 0x72, 0x74, 0x57, 0x61, 0x72, 0x6e, 0x69,
+	0x6e, 0x67, 0x52, 0x08, 0x77, 0x61, 0x72, 0x6e, 0x69, 0x6e, 0x67, 0x73, 0x22, 0x44, 0x0a, 0x18,
+	0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x47, 0x65, 0x74, 0x41, 0x6c, 0x6c, 0x49, 0x74, 0x69,
+	0x6e, 0x65, 0x72, 0x61, 0x72, 0x69, 0x65, 0x73, 0x12, 0x12, 0x0a, 0x04, 0x70, 0x61, 0x67, 0x65,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x04, 0x70, 0x61, 0x67, 0x65, 0x12, 0x14, 0x0a, 0x05,
+	0x6c, 0x69, 0x6d, 0x69, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x6c, 0x69, 0x6d,
+	0x69, 0x74, 0x22, 0x34, 0x0a, 0x06, 0x61, 0x75, 0x74, 0x68, 0x6f, 0x72, 0x12, 0x0e, 0x0a, 0x02,
+	0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x1a, 0x0a, 0x08,
+	0x75, 0x73, 0x65, 0x72, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08,
+	0x75, 0x73, 0x65, 0x72, 0x6e, 0x61, 0x6d, 0x65, 0x22, 0xff, 0x01, 0x0a, 0x09, 0x69, 0x74, 0x69,
 	0x6e, 0x65, 0x72, 0x61, 0x72, 0x79, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01,
-	0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x18, 0x0a, 0x07, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x6e,
-	0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74,
-	0x12, 0x1b, 0x0a, 0x09, 0x61, 0x75, 0x74, 0x68, 0x6f, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x03, 0x20,
-	0x01, 0x28, 0x09, 0x52, 0x08, 0x61, 0x75, 0x74, 0x68, 0x6f, 0x72, 0x49, 0x64, 0x12, 0x21, 0x0a,
-	0x0c, 0x69, 0x74, 0x69, 0x6e, 0x65, 0x72, 0x61, 0x72, 0x79, 0x5f, 0x69, 0x64, 0x18, 0x04, 0x20,
-	0x01, 0x28, 0x09, 0x52, 0x0b, 0x69, 0x74, 0x69, 0x6e, 0x65, 0x72, 0x61, 0x72, 0x79, 0x49, 0x64,
-	0x12, 0x1d, 0x0a, 0x0a, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x5f, 0x61, 0x74, 0x18, 0x05,
-	0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x41, 0x74, 0x22,
-	0x42, 0x0a, 0x16, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x47, 0x65, 0x74, 0x44, 0x65, 0x73,
-	0x74, 0x69, 0x6e, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x12, 0x0a, 0x04, 0x70, 0x61, 0x67,
+	0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x74, 0x69, 0x74, 0x6c, 0x65, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x74, 0x69, 0x74, 0x6c, 0x65, 0x12, 0x2b, 0x0a, 0x06,
+	0x61, 0x75, 0x74, 0x68, 0x65, 0x72, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x69,
+	0x74, 0x69, 0x6e, 0x65, 0x72, 0x61, 0x72, 0x69, 0x65, 0x73, 0x2e, 0x61, 0x75, 0x74, 0x68, 0x6f,
+	0x72, 0x52, 0x06, 0x61, 0x75, 0x74, 0x68, 0x65, 0x72, 0x12, 0x1d, 0x0a, 0x0a, 0x73, 0x74, 0x61,
+	0x72, 0x74, 0x5f, 0x64, 0x61, 0x74, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x73,
+	0x74, 0x61, 0x72, 0x74, 0x44, 0x61, 0x74, 0x65, 0x12, 0x19, 0x0a, 0x08, 0x65, 0x6e, 0x64, 0x5f,
+	0x64, 0x61, 0x74, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x65, 0x6e, 0x64, 0x44,
+	0x61, 0x74, 0x65, 0x12, 0x1f, 0x0a, 0x0b, 0x6c, 0x69, 0x6b, 0x65, 0x73, 0x5f, 0x63, 0x6f, 0x75,
+	0x6e, 0x74, 0x18, 0x06, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0a, 0x6c, 0x69, 0x6b, 0x65, 0x73, 0x43,
+	0x6f, 0x75, 0x6e, 0x74, 0x12, 0x25, 0x0a, 0x0e, 0x63, 0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x74, 0x73,
+	0x5f, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x07, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0d, 0x63, 0x6f,
+	0x6d, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x1d, 0x0a, 0x0a, 0x63,
+	0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x5f, 0x61, 0x74, 0x18, 0x08, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x09, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x41, 0x74, 0x22, 0x95, 0x01, 0x0a, 0x19, 0x72,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x47, 0x65, 0x74, 0x41, 0x6c, 0x6c, 0x49, 0x74, 0x69,
+	0x6e, 0x65, 0x72, 0x61, 0x72, 0x69, 0x65, 0x73, 0x12, 0x38, 0x0a, 0x0b, 0x69, 0x74, 0x69, 0x6e,
+	0x65, 0x72, 0x61, 0x72, 0x69, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x16, 0x2e,
+	0x69, 0x74, 0x69, 0x6e, 0x65, 0x72, 0x61, 0x72, 0x69, 0x65, 0x73, 0x2e, 0x69, 0x74, 0x69, 0x6e,
+	0x65, 0x72, 0x61, 0x72, 0x79, 0x52, 0x0b, 0x69, 0x74, 0x69, 0x6e, 0x65, 0x72, 0x61, 0x72, 0x69,
+	0x65, 0x73, 0x12, 0x14, 0x0a, 0x05, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x03, 0x52, 0x05, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x12, 0x12, 0x0a, 0x04, 0x70, 0x61, 0x67, 0x65,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x04, 0x70, 0x61, 0x67, 0x65, 0x12, 0x14, 0x0a, 0x05,
+	0x6c, 0x69, 0x6d, 0x69, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x6c, 0x69, 0x6d,
+	0x69, 0x74, 0x22, 0x2d, 0x0a, 0x1b, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x47, 0x65, 0x74,
+	0x49, 0x74, 0x69, 0x6e, 0x65, 0x72, 0x61, 0x72, 0x79, 0x46, 0x75, 0x6c, 0x6c, 0x49, 0x6e, 0x66,
+	0x6f, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69,
+	0x64, 0x22, 0x95, 0x03, 0x0a, 0x1c, 0x72, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x47, 0x65,
+	0x74, 0x49, 0x74, 0x69, 0x6e, 0x65, 0x72, 0x61, 0x72, 0x79, 0x46, 0x75, 0x6c, 0x6c, 0x49, 0x6e,
+	0x66, 0x6f, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02,
+	0x69, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x74, 0x69, 0x74, 0x6c, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x05, 0x74, 0x69, 0x74, 0x6c, 0x65, 0x12, 0x20, 0x0a, 0x0b, 0x64, 0x65, 0x73, 0x63,
+	0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x64,
+	0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x1d, 0x0a, 0x0a, 0x73, 0x74,
+	0x61, 0x72, 0x74, 0x5f, 0x64, 0x61, 0x74, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09,
+	0x73, 0x74, 0x61, 0x72, 0x74, 0x44, 0x61, 0x74, 0x65, 0x12, 0x19, 0x0a, 0x08, 0x65, 0x6e, 0x64,
+	0x5f, 0x64, 0x61, 0x74, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x65, 0x6e, 0x64,
+	0x44, 0x61, 0x74, 0x65, 0x12, 0x2b, 0x0a, 0x06, 0x61, 0x75, 0x74, 0x68, 0x6f, 0x72, 0x18, 0x06,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x69, 0x74, 0x69, 0x6e, 0x65, 0x72, 0x61, 0x72, 0x69,
+	0x65, 0x73, 0x2e, 0x61, 0x75, 0x74, 0x68, 0x6f, 0x72, 0x52, 0x06, 0x61, 0x75, 0x74, 0x68, 0x6f,
+	0x72, 0x12, 0x40, 0x0a, 0x0c, 0x64, 0x65, 0x73, 0x74, 0x69, 0x6e, 0x61, 0x74, 0x69, 0x6f, 0x6e,
+	0x73, 0x18, 0x07, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x69, 0x74, 0x69, 0x6e, 0x65, 0x72,
+	0x61, 0x72, 0x69, 0x65, 0x73, 0x2e, 0x64, 0x65, 0x73, 0x74, 0x69, 0x6e, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x45, 0x64, 0x69, 0x74, 0x52, 0x0c, 0x64, 0x65, 0x73, 0x74, 0x69, 0x6e, 0x61, 0x74, 0x69,
+	0x6f, 0x6e, 0x73, 0x12, 0x1f, 0x0a, 0x0b, 0x6c, 0x69, 0x6b, 0x65, 0x73, 0x5f, 0x63, 0x6f, 0x75,
+	0x6e, 0x74, 0x18, 0x08, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0a, 0x6c, 0x69, 0x6b, 0x65, 0x73, 0x43,
+	0x6f, 0x75, 0x6e, 0x74, 0x12, 0x25, 0x0a, 0x0e, 0x63, 0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x74, 0x73,
+	0x5f, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x09, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0d, 0x63, 0x6f,
+	0x6d, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x1d, 0x0a, 0x0a, 0x63,
+	0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x5f, 0x61, 0x74, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x09, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x41, 0x74, 0x12, 0x1d, 0x0a, 0x0a, 0x75, 0x70,
+	0x64, 0x61, 0x74, 0x65, 0x64, 0x5f, 0x61, 0x74, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09,
+	0x75, 0x70, 0x64, 0x61, 0x74, 0x65, 0x64, 0x41, 0x74, 0x22, 0x7a, 0x0a, 0x1e, 0x72, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x57, 0x72, 0x69, 0x74, 0x65, 0x43, 0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x74,
+	0x54, 0x6f, 0x49, 0x74, 0x69, 0x6e, 0x65, 0x72, 0x61, 0x72, 0x79, 0x12, 0x1b, 0x0a, 0x09, 0x61,
+	0x75, 0x74, 0x68, 0x6f, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08,
+	0x61, 0x75, 0x74, 0x68, 0x6f, 0x72, 0x49, 0x64, 0x12, 0x21, 0x0a, 0x0c, 0x69, 0x74, 0x69, 0x6e,
+	0x65, 0x72, 0x61, 0x72, 0x79, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b,
+	0x69, 0x74, 0x69, 0x6e, 0x65, 0x72, 0x61, 0x72, 0x79, 0x49, 0x64, 0x12, 0x18, 0x0a, 0x07, 0x63,
+	0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x63, 0x6f,
+	0x6e, 0x74, 0x65, 0x6e, 0x74, 0x22, 0xaa, 0x01, 0x0a, 0x1f, 0x72, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x57, 0x72, 0x69, 0x74, 0x65, 0x43, 0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x74, 0x54, 0x6f,
+	0x49, 0x74, 0x69, 0x6e, 0x65, 0x72, 0x61, 0x72, 0x79, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x18, 0x0a, 0x07, 0x63, 0x6f, 0x6e,
+	0x74, 0x65, 0x6e, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x63, 0x6f, 0x6e, 0x74,
+	0x65, 0x6e, 0x74, 0x12, 0x1b, 0x0a, 0x09, 0x61, 0x75, 0x74, 0x68, 0x6f, 0x72, 0x5f, 0x69, 0x64,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x61, 0x75, 0x74, 0x68, 0x6f, 0x72, 0x49, 0x64,
+	0x12, 0x21, 0x0a, 0x0c, 0x69, 0x74, 0x69, 0x6e, 0x65, 0x72, 0x61, 0x72, 0x79, 0x5f, 0x69, 0x64,
+	0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x69, 0x74, 0x69, 0x6e, 0x65, 0x72, 0x61, 0x72,
+	0x79, 0x49, 0x64, 0x12, 0x1d, 0x0a, 0x0a, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x5f, 0x61,
+	0x74, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64,
+	0x41, 0x74, 0x22, 0x42, 0x0a, 0x16, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x47, 0x65, 0x74,
+	0x44, 0x65, 0x73, 0x74, 0x69, 0x6e, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x12, 0x0a, 0x04,
+	0x70, 0x61, 0x67, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x04, 0x70, 0x61, 0x67, 0x65,
+	0x12, 0x14, 0x0a, 0x05, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52,
+	0x05, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x22, 0x72, 0x0a, 0x10, 0x64, 0x65, 0x73, 0x74, 0x69, 0x6f,
+	0x6e, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x49, 0x6e, 0x66, 0x6f, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61,
+	0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x18,
+	0x0a, 0x07, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x72, 0x79, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x07, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x20, 0x0a, 0x0b, 0x64, 0x65, 0x73, 0x63,
+	0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x64,
+	0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x22, 0x9c, 0x01, 0x0a, 0x17, 0x72,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x47, 0x65, 0x74, 0x44, 0x65, 0x73, 0x74, 0x69, 0x6e,
+	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x41, 0x0a, 0x0c, 0x64, 0x65, 0x73, 0x74, 0x69, 0x6e,
+	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1d, 0x2e, 0x69,
+	0x74, 0x69, 0x6e, 0x65, 0x72, 0x61, 0x72, 0x69, 0x65, 0x73, 0x2e, 0x64, 0x65, 0x73, 0x74, 0x69,
+	0x6f, 0x6e, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x0c, 0x64, 0x65, 0x73,
+	0x74, 0x69, 0x6e, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x14, 0x0a, 0x05, 0x74, 0x6f, 0x74,
+	0x61, 0x6c, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x05, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x12,
+	0x12, 0x0a, 0x04, 0x70, 0x61, 0x67, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x04, 0x70,
+	0x61, 0x67, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x18, 0x04, 0x20, 0x01,
+	0x28, 0x05, 0x52, 0x05, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x22, 0x46, 0x0a, 0x1d, 0x72, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x47, 0x65, 0x74, 0x44, 0x65, 0x73, 0x74, 0x69, 0x6e, 0x61, 0x74, 0x69,
+	0x6f, 0x6e, 0x73, 0x41, 0x6c, 0x6c, 0x49, 0x6e, 0x66, 0x6f, 0x12, 0x25, 0x0a, 0x0e, 0x64, 0x65,
+	0x73, 0x74, 0x69, 0x6e, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x0d, 0x64, 0x65, 0x73, 0x74, 0x69, 0x6e, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x49,
+	0x64, 0x22, 0xc5, 0x02, 0x0a, 0x1e, 0x72, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x47, 0x65,
+	0x74, 0x44, 0x65, 0x73, 0x74, 0x69, 0x6e, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x41, 0x6c, 0x6c,
+	0x49, 0x6e, 0x66, 0x6f, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x02, 0x69, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x63, 0x6f, 0x75, 0x6e,
+	0x74, 0x72, 0x79, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x63, 0x6f, 0x75, 0x6e, 0x74,
+	0x72, 0x79, 0x12, 0x20, 0x0a, 0x0b, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f,
+	0x6e, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70,
+	0x74, 0x69, 0x6f, 0x6e, 0x12, 0x2d, 0x0a, 0x12, 0x70, 0x6f, 0x70, 0x75, 0x6c, 0x61, 0x72, 0x5f,
+	0x61, 0x63, 0x74, 0x69, 0x76, 0x69, 0x74, 0x69, 0x65, 0x73, 0x18, 0x05, 0x20, 0x03, 0x28, 0x09,
+	0x52, 0x11, 0x70, 0x6f, 0x70, 0x75, 0x6c, 0x61, 0x72, 0x41, 0x63, 0x74, 0x69, 0x76, 0x69, 0x74,
+	0x69, 0x65, 0x73, 0x12, 0x2b, 0x0a, 0x12, 0x62, 0x65, 0x73, 0x74, 0x5f, 0x74, 0x69, 0x6d, 0x65,
+	0x5f, 0x74, 0x6f, 0x5f, 0x76, 0x69, 0x73, 0x69, 0x74, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x0f, 0x62, 0x65, 0x73, 0x74, 0x54, 0x69, 0x6d, 0x65, 0x54, 0x6f, 0x56, 0x69, 0x73, 0x69, 0x74,
+	0x12, 0x2f, 0x0a, 0x14, 0x61, 0x76, 0x65, 0x72, 0x61, 0x67, 0x65, 0x5f, 0x63, 0x6f, 0x73, 0x74,
+	0x5f, 0x70, 0x65, 0x72, 0x5f, 0x64, 0x61, 0x79, 0x18, 0x07, 0x20, 0x01, 0x28, 0x05, 0x52, 0x11,
+	0x61, 0x76, 0x65, 0x72, 0x61, 0x67, 0x65, 0x43, 0x6f, 0x73, 0x74, 0x50, 0x65, 0x72, 0x44, 0x61,
+	0x79, 0x12, 0x1a, 0x0a, 0x08, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x63, 0x79, 0x18, 0x08, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x08, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x63, 0x79, 0x12, 0x1a, 0x0a,
+	0x08, 0x6c, 0x61, 0x6e, 0x67, 0x75, 0x61, 0x67, 0x65, 0x18, 0x09, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x08, 0x6c, 0x61, 0x6e, 0x67, 0x75, 0x61, 0x67, 0x65, 0x22, 0x70, 0x0a, 0x14, 0x72, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x57, 0x72, 0x69, 0x74, 0x65, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65,
+	0x73, 0x12, 0x1b, 0x0a, 0x09, 0x73, 0x65, 0x6e, 0x64, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x73, 0x65, 0x6e, 0x64, 0x65, 0x72, 0x49, 0x64, 0x12, 0x21,
+	0x0a, 0x0c, 0x72, 0x65, 0x63, 0x69, 0x70, 0x69, 0x65, 0x6e, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x72, 0x65, 0x63, 0x69, 0x70, 0x69, 0x65, 0x6e, 0x74, 0x49,
+	0x64, 0x12, 0x18, 0x0a, 0x07, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x07, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x22, 0xa0, 0x01, 0x0a, 0x15,
+	0x72, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x57, 0x72, 0x69, 0x74, 0x65, 0x4d, 0x65, 0x73,
+	0x73, 0x61, 0x67, 0x65, 0x73, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x1b, 0x0a, 0x09, 0x73, 0x65, 0x6e, 0x64, 0x65, 0x72, 0x5f,
+	0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x73, 0x65, 0x6e, 0x64, 0x65, 0x72,
+	0x49, 0x64, 0x12, 0x21, 0x0a, 0x0c, 0x72, 0x65, 0x63, 0x69, 0x70, 0x69, 0x65, 0x6e, 0x74, 0x5f,
+	0x69, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x72, 0x65, 0x63, 0x69, 0x70, 0x69,
+	0x65, 0x6e, 0x74, 0x49, 0x64, 0x12, 0x18, 0x0a, 0x07, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74,
+	0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x12,
+	0x1d, 0x0a, 0x0a, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x5f, 0x61, 0x74, 0x18, 0x05, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x09, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x41, 0x74, 0x22, 0xb2,
+	0x01, 0x0a, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x2b, 0x0a, 0x06, 0x73, 0x65,
+	0x6e, 0x64, 0x65, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x69, 0x74, 0x69,
+	0x6e, 0x65, 0x72, 0x61, 0x72, 0x69, 0x65, 0x73, 0x2e, 0x61, 0x75, 0x74, 0x68, 0x6f, 0x72, 0x52,
+	0x06, 0x73, 0x65, 0x6e, 0x64, 0x65, 0x72, 0x12, 0x31, 0x0a, 0x09, 0x72, 0x65, 0x63, 0x69, 0x70,
+	0x69, 0x65, 0x6e, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x69, 0x74, 0x69,
+	0x6e, 0x65, 0x72, 0x61, 0x72, 0x69, 0x65, 0x73, 0x2e, 0x61, 0x75, 0x74, 0x68, 0x6f, 0x72, 0x52,
+	0x09, 0x72, 0x65, 0x63, 0x69, 0x70, 0x69, 0x65, 0x6e, 0x74, 0x12, 0x18, 0x0a, 0x07, 0x63, 0x6f,
+	0x6e, 0x74, 0x65, 0x6e, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x63, 0x6f, 0x6e,
+	0x74, 0x65, 0x6e, 0x74, 0x12, 0x1d, 0x0a, 0x0a, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x5f,
+	0x61, 0x74, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65,
+	0x64, 0x41, 0x74, 0x22, 0x3e, 0x0a, 0x12, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x47, 0x65,
+	0x74, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x73, 0x12, 0x12, 0x0a, 0x04, 0x70, 0x61, 0x67,
 	0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x04, 0x70, 0x61, 0x67, 0x65, 0x12, 0x14, 0x0a,
 	0x05, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x6c, 0x69,
-	0x6d, 0x69, 0x74, 0x22, 0x72, 0x0a, 0x10, 0x64, 0x65, 0x73, 0x74, 0x69, 0x6f, 0x6e, 0x61, 0x74,
-	0x69, 0x6f, 0x6e, 0x49, 0x6e, 0x66, 0x6f, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20,
-	0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18,
-	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x63,
-	0x6f, 0x75, 0x6e, 0x74, 0x72, 0x79, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x63, 0x6f,
-	0x75, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x20, 0x0a, 0x0b, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70,
-	0x74, 0x69, 0x6f, 0x6e, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x64, 0x65, 0x73, 0x63,
-	0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x22, 0x9c, 0x01, 0x0a, 0x17, 0x72, 0x65, 0x73, 0x70,
-	0x6f, 0x6e, 0x73, 0x65, 0x47, 0x65, 0x74, 0x44, 0x65, 0x73, 0x74, 0x69, 0x6e, 0x61, 0x74, 0x69,
-	0x6f, 0x6e, 0x73, 0x12, 0x41, 0x0a, 0x0c, 0x64, 0x65, 0x73, 0x74, 0x69, 0x6e, 0x61, 0x74, 0x69,
-	0x6f, 0x6e, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1d, 0x2e, 0x69, 0x74, 0x69, 0x6e,
-	0x65, 0x72, 0x61, 0x72, 0x69, 0x65, 0x73, 0x2e, 0x64, 0x65, 0x73, 0x74, 0x69, 0x6f, 0x6e, 0x61,
-	0x74, 0x69, 0x6f, 0x6e, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x0c, 0x64, 0x65, 0x73, 0x74, 0x69, 0x6e,
-	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x14, 0x0a, 0x05, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x18,
-	0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x05, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x12, 0x12, 0x0a, 0x04,
-	0x70, 0x61, 0x67, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x04, 0x70, 0x61, 0x67, 0x65,
-	0x12, 0x14, 0x0a, 0x05, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x05, 0x52,
-	0x05, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x22, 0x46, 0x0a, 0x1d, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73,
-	0x74, 0x47, 0x65, 0x74, 0x44, 0x65, 0x73, 0x74, 0x69, 0x6e, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73,
-	0x41, 0x6c, 0x6c, 0x49, 0x6e, 0x66, 0x6f, 0x12, 0x25, 0x0a, 0x0e, 0x64, 0x65, 0x73, 0x74, 0x69,
-	0x6e, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
-	0x0d, 0x64, 0x65, 0x73, 0x74, 0x69, 0x6e, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x49, 0x64, 0x22, 0xc5,
-	0x02, 0x0a, 0x1e, 0x72, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x47, 0x65, 0x74, 0x44, 0x65,
-	0x73, 0x74, 0x69, 0x6e, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x41, 0x6c, 0x6c, 0x49, 0x6e, 0x66,
-	0x6f, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69,
-	0x64, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52,
-	0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x72, 0x79,
-	0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x72, 0x79, 0x12,
-	0x20, 0x0a, 0x0b, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x04,
-	0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f,
-	0x6e, 0x12, 0x2d, 0x0a, 0x12, 0x70, 0x6f, 0x70, 0x75, 0x6c, 0x61, 0x72, 0x5f, 0x61, 0x63, 0x74,
-	0x69, 0x76, 0x69, 0x74, 0x69, 0x65, 0x73, 0x18, 0x05, 0x20, 0x03, 0x28, 0x09, 0x52, 0x11, 0x70,
-	0x6f, 0x70, 0x75, 0x6c, 0x61, 0x72, 0x41, 0x63, 0x74, 0x69, 0x76, 0x69, 0x74, 0x69, 0x65, 0x73,
-	0x12, 0x2b, 0x0a, 0x12, 0x62, 0x65, 0x73, 0x74, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x5f, 0x74, 0x6f,
-	0x5f, 0x76, 0x69, 0x73, 0x69, 0x74, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0f, 0x62, 0x65,
-	0x73, 0x74, 0x54, 0x69, 0x6d, 0x65, 0x54, 0x6f, 0x56, 0x69, 0x73, 0x69, 0x74, 0x12, 0x2f, 0x0a,
-	0x14, 0x61, 0x76, 0x65, 0x72, 0x61, 0x67, 0x65, 0x5f, 0x63, 0x6f, 0x73, 0x74, 0x5f, 0x70, 0x65,
-	0x72, 0x5f, 0x64, 0x61, 0x79, 0x18, 0x07, 0x20, 0x01, 0x28, 0x05, 0x52, 0x11, 0x61, 0x76, 0x65,
-	0x72, 0x61, 0x67, 0x65, 0x43, 0x6f, 0x73, 0x74, 0x50, 0x65, 0x72, 0x44, 0x61, 0x79, 0x12, 0x1a,
-	0x0a, 0x08, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x63, 0x79, 0x18, 0x08, 0x20, 0x01, 0x28, 0x09,
-	0x52, 0x08, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x63, 0x79, 0x12, 0x1a, 0x0a, 0x08, 0x6c, 0x61,
-	0x6e, 0x67, 0x75, 0x61, 0x67, 0x65, 0x18, 0x09, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x6c, 0x61,
-	0x6e, 0x67, 0x75, 0x61, 0x67, 0x65, 0x22, 0x70, 0x0a, 0x14, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73,
-	0x74, 0x57, 0x72, 0x69, 0x74, 0x65, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x73, 0x12, 0x1b,
-	0x0a, 0x09, 0x73, 0x65, 0x6e, 0x64, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28,
-	0x09, 0x52, 0x08, 0x73, 0x65, 0x6e, 0x64, 0x65, 0x72, 0x49, 0x64, 0x12, 0x21, 0x0a, 0x0c, 0x72,
-	0x65, 0x63, 0x69, 0x70, 0x69, 0x65, 0x6e, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28,
-	0x09, 0x52, 0x0b, 0x72, 0x65, 0x63, 0x69, 0x70, 0x69, 0x65, 0x6e, 0x74, 0x49, 0x64, 0x12, 0x18,
-	0x0a, 0x07, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52,
-	0x07, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x22, 0xa0, 0x01, 0x0a, 0x15, 0x72, 0x65, 0x73,
-	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x57, 0x72, 0x69, 0x74, 0x65, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67,
-	0x65, 0x73, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02,
-	0x69, 0x64, 0x12, 0x1b, 0x0a, 0x09, 0x73, 0x65, 0x6e, 0x64, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18,
-	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x73, 0x65, 0x6e, 0x64, 0x65, 0x72, 0x49, 0x64, 0x12,
-	0x21, 0x0a, 0x0c, 0x72, 0x65, 0x63, 0x69, 0x70, 0x69, 0x65, 0x6e, 0x74, 0x5f, 0x69, 0x64, 0x18,
-	0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x72, 0x65, 0x63, 0x69, 0x70, 0x69, 0x65, 0x6e, 0x74,
-	0x49, 0x64, 0x12, 0x18, 0x0a, 0x07, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x18, 0x04, 0x20,
-	0x01, 0x28, 0x09, 0x52, 0x07, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x12, 0x1d, 0x0a, 0x0a,
-	0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x5f, 0x61, 0x74, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09,
-	0x52, 0x09, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x41, 0x74, 0x22, 0xb2, 0x01, 0x0a, 0x07,
-	0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20,
-	0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x2b, 0x0a, 0x06, 0x73, 0x65, 0x6e, 0x64, 0x65,
-	0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x69, 0x74, 0x69, 0x6e, 0x65, 0x72,
-	0x61, 0x72, 0x69, 0x65, 0x73, 0x2e, 0x61, 0x75, 0x74, 0x68, 0x6f, 0x72, 0x52, 0x06, 0x73, 0x65,
-	0x6e, 0x64, 0x65, 0x72, 0x12, 0x31, 0x0a, 0x09, 0x72, 0x65, 0x63, 0x69, 0x70, 0x69, 0x65, 0x6e,
-	0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x69, 0x74, 0x69, 0x6e, 0x65, 0x72,
-	0x61, 0x72, 0x69, 0x65, 0x73, 0x2e, 0x61, 0x75, 0x74, 0x68, 0x6f, 0x72, 0x52, 0x09, 0x72, 0x65,
-	0x63, 0x69, 0x70, 0x69, 0x65, 0x6e, 0x74, 0x12, 0x18, 0x0a, 0x07, 0x63, 0x6f, 0x6e, 0x74, 0x65,
-	0x6e, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x6e,
-	0x74, 0x12, 0x1d, 0x0a, 0x0a, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x5f, 0x61, 0x74, 0x18,
-	0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x41, 0x74,
-	0x22, 0x3e, 0x0a, 0x12, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x47, 0x65, 0x74, 0x4d, 0x65,
-	0x73, 0x73, 0x61, 0x67, 0x65, 0x73, 0x12, 0x12, 0x0a, 0x04, 0x70, 0x61, 0x67, 0x65, 0x18, 0x01,
-	0x20, 0x01, 0x28, 0x05, 0x52, 0x04, 0x70, 0x61, 0x67, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x6c, 0x69,
-	0x6d, 0x69, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x6c, 0x69, 0x6d, 0x69, 0x74,
-	0x22, 0x87, 0x01, 0x0a, 0x13, 0x72, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x47, 0x65, 0x74,
-	0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x73, 0x12, 0x30, 0x0a, 0x08, 0x6d, 0x65, 0x73, 0x73,
-	0x61, 0x67, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x69, 0x74, 0x69,
-	0x6e, 0x65, 0x72, 0x61, 0x72, 0x69, 0x65, 0x73, 0x2e, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65,
-	0x52, 0x08, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x73, 0x12, 0x14, 0x0a, 0x05, 0x74, 0x6f,
-	0x74, 0x61, 0x6c, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x05, 0x74, 0x6f, 0x74, 0x61, 0x6c,
-	0x12, 0x12, 0x0a, 0x04, 0x70, 0x61, 0x67, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x04,
-	0x70, 0x61, 0x67, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x18, 0x04, 0x20,
-	0x01, 0x28, 0x05, 0x52, 0x05, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x22, 0x32, 0x0a, 0x17, 0x72, 0x65,
-	0x71, 0x75, 0x65, 0x73, 0x74, 0x47, 0x65, 0x74, 0x55, 0x73, 0x65, 0x72, 0x53, 0x74, 0x61, 0x74,
-	0x69, 0x73, 0x74, 0x69, 0x63, 0x12, 0x17, 0x0a, 0x07, 0x75, 0x73, 0x65, 0x72, 0x5f, 0x69, 0x64,
-	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x75, 0x73, 0x65, 0x72, 0x49, 0x64, 0x22, 0x56,
-	0x0a, 0x0d, 0x70, 0x6f, 0x70, 0x75, 0x6c, 0x61, 0x72, 0x53, 0x74, 0x6f, 0x72, 0x69, 0x79, 0x12,
-	0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12,
-	0x14, 0x0a, 0x05, 0x74, 0x69, 0x74, 0x6c, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05,
-	0x74, 0x69, 0x74, 0x6c, 0x65, 0x12, 0x1f, 0x0a, 0x0b, 0x6c, 0x69, 0x6b, 0x65, 0x73, 0x5f, 0x63,
-	0x6f, 0x75, 0x6e, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0a, 0x6c, 0x69, 0x6b, 0x65,
-	0x73, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x22, 0x59, 0x0a, 0x10, 0x70, 0x6f, 0x70, 0x75, 0x6c, 0x61,
-	0x72, 0x49, 0x74, 0x69, 0x6e, 0x65, 0x72, 0x61, 0x72, 0x79, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64,
-	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x74, 0x69,
-	0x74, 0x6c, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x74, 0x69, 0x74, 0x6c, 0x65,
-	0x12, 0x1f, 0x0a, 0x0b, 0x6c, 0x69, 0x6b, 0x65, 0x73, 0x5f, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18,
-	0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0a, 0x6c, 0x69, 0x6b, 0x65, 0x73, 0x43, 0x6f, 0x75, 0x6e,
-	0x74, 0x22, 0xc6, 0x03, 0x0a, 0x18, 0x72, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x47, 0x65,
-	0x74, 0x55, 0x73, 0x65, 0x72, 0x53, 0x74, 0x61, 0x74, 0x69, 0x73, 0x74, 0x69, 0x63, 0x12, 0x17,
-	0x0a, 0x07, 0x75, 0x73, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
-	0x06, 0x75, 0x73, 0x65, 0x72, 0x49, 0x64, 0x12, 0x23, 0x0a, 0x0d, 0x74, 0x6f, 0x74, 0x61, 0x6c,
-	0x5f, 0x73, 0x74, 0x6f, 0x72, 0x69, 0x65, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0c,
-	0x74, 0x6f, 0x74, 0x61, 0x6c, 0x53, 0x74, 0x6f, 0x72, 0x69, 0x65, 0x73, 0x12, 0x2b, 0x0a, 0x11,
-	0x74, 0x6f, 0x74, 0x61, 0x6c, 0x5f, 0x69, 0x74, 0x69, 0x6e, 0x65, 0x72, 0x61, 0x72, 0x69, 0x65,
-	0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x10, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x49, 0x74,
-	0x69, 0x6e, 0x65, 0x72, 0x61, 0x72, 0x69, 0x65, 0x73, 0x12, 0x36, 0x0a, 0x17, 0x74, 0x6f, 0x74,
-	0x61, 0x6c, 0x5f, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x72, 0x69, 0x65, 0x73, 0x5f, 0x76, 0x69, 0x73,
-	0x69, 0x74, 0x65, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x15, 0x74, 0x6f, 0x74, 0x61,
-	0x6c, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x72, 0x69, 0x65, 0x73, 0x56, 0x69, 0x73, 0x69, 0x74, 0x65,
-	0x64, 0x12, 0x30, 0x0a, 0x14, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x5f, 0x6c, 0x69, 0x6b, 0x65, 0x73,
-	0x5f, 0x72, 0x65, 0x63, 0x65, 0x69, 0x76, 0x65, 0x64, 0x18, 0x05, 0x20, 0x01, 0x28, 0x03, 0x52,
-	0x12, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x4c, 0x69, 0x6b, 0x65, 0x73, 0x52, 0x65, 0x63, 0x65, 0x69,
-	0x76, 0x65, 0x64, 0x12, 0x36, 0x0a, 0x17, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x5f, 0x63, 0x6f, 0x6d,
-	0x6d, 0x65, 0x6e, 0x74, 0x73, 0x5f, 0x72, 0x65, 0x63, 0x65, 0x69, 0x76, 0x65, 0x64, 0x18, 0x06,
-	0x20, 0x01, 0x28, 0x03, 0x52, 0x15, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x43, 0x6f, 0x6d, 0x6d, 0x65,
-	0x6e, 0x74, 0x73, 0x52, 0x65, 0x63, 0x65, 0x69, 0x76, 0x65, 0x64, 0x12, 0x48, 0x0a, 0x12, 0x6d,
-	0x6f, 0x73, 0x74, 0x5f, 0x70, 0x6f, 0x70, 0x75, 0x6c, 0x61, 0x72, 0x5f, 0x73, 0x74, 0x6f, 0x72,
-	0x79, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x69, 0x74, 0x69, 0x6e, 0x65, 0x72,
-	0x61, 0x72, 0x69, 0x65, 0x73, 0x2e, 0x70, 0x6f, 0x70, 0x75, 0x6c, 0x61, 0x72, 0x53, 0x74, 0x6f,
-	0x72, 0x69, 0x79, 0x52, 0x10, 0x6d, 0x6f, 0x73, 0x74, 0x50, 0x6f, 0x70, 0x75, 0x6c, 0x61, 0x72,
-	0x53, 0x74, 0x6f, 0x72, 0x79, 0x12, 0x53, 0x0a, 0x16, 0x6d, 0x6f, 0x73, 0x74, 0x5f, 0x70, 0x6f,
-	0x70, 0x75, 0x6c, 0x61, 0x72, 0x5f, 0x69, 0x74, 0x69, 0x6e, 0x65, 0x72, 0x61, 0x72, 0x79, 0x18,
-	0x08, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1d, 0x2e, 0x69, 0x74, 0x69, 0x6e, 0x65, 0x72, 0x61, 0x72,
-	0x69, 0x65, 0x73, 0x2e, 0x70, 0x6f, 0x70, 0x75, 0x6c, 0x61, 0x72, 0x49, 0x74, 0x69, 0x6e, 0x65,
-	0x72, 0x61, 0x72, 0x79, 0x52, 0x14, 0x6d, 0x6f, 0x73, 0x74, 0x50, 0x6f, 0x70, 0x75, 0x6c, 0x61,
-	0x72, 0x49, 0x74, 0x69, 0x6e, 0x65, 0x72, 0x61, 0x72, 0x79, 0x32, 0xba, 0x09, 0x0a, 0x0b, 0x69,
-	0x74, 0x69, 0x6e, 0x65, 0x72, 0x61, 0x72, 0x69, 0x65, 0x73, 0x12, 0x62, 0x0a, 0x11, 0x43, 0x72,
-	0x65, 0x61, 0x74, 0x65, 0x49, 0x74, 0x69, 0x6e, 0x65, 0x72, 0x61, 0x72, 0x69, 0x65, 0x73, 0x12,
-	0x25, 0x2e, 0x69, 0x74, 0x69, 0x6e, 0x65, 0x72, 0x61, 0x72, 0x69, 0x65, 0x73, 0x2e, 0x72, 0x65,
-	0x71, 0x75, 0x65, 0x73, 0x74, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x49, 0x74, 0x69, 0x6e, 0x65,
-	0x72, 0x61, 0x72, 0x69, 0x65, 0x73, 0x1a, 0x26, 0x2e, 0x69, 0x74, 0x69, 0x6e, 0x65, 0x72, 0x61,
-	0x72, 0x69, 0x65, 0x73, 0x2e, 0x72, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x43, 0x72, 0x65,
-	0x61, 0x74, 0x65, 0x49, 0x74, 0x69, 0x6e, 0x65, 0x72, 0x61, 0x72, 0x69, 0x65, 0x73, 0x12, 0x5c,
-	0x0a, 0x0f, 0x45, 0x64, 0x69, 0x74, 0x49, 0x74, 0x69, 0x6e, 0x65, 0x72, 0x61, 0x72, 0x69, 0x65,
-	0x73, 0x12, 0x23, 0x2e, 0x69, 0x74, 0x69, 0x6e, 0x65, 0x72, 0x61, 0x72, 0x69, 0x65, 0x73, 0x2e,
-	0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x45, 0x64, 0x69, 0x74, 0x49, 0x74, 0x69, 0x6e, 0x65,
-	0x72, 0x61, 0x72, 0x69, 0x65, 0x73, 0x1a, 0x24, 0x2e, 0x69, 0x74, 0x69, 0x6e, 0x65, 0x72, 0x61,
-	0x72, 0x69, 0x65, 0x73, 0x2e, 0x72, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x45, 0x64, 0x69,
-	0x74, 0x49, 0x74, 0x69, 0x6e, 0x65, 0x72, 0x61, 0x72, 0x69, 0x65, 0x73, 0x12, 0x62, 0x0a, 0x11,
-	0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x49, 0x74, 0x69, 0x6e, 0x65, 0x72, 0x61, 0x72, 0x69, 0x65,
-	0x73, 0x12, 0x25, 0x2e, 0x69, 0x74, 0x69, 0x6e, 0x65, 0x72, 0x61, 0x72, 0x69, 0x65, 0x73, 0x2e,
-	0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x49, 0x74, 0x69,
-	0x6e, 0x65, 0x72, 0x61, 0x72, 0x69, 0x65, 0x73, 0x1a, 0x26, 0x2e, 0x69, 0x74, 0x69, 0x6e, 0x65,
-	0x72, 0x61, 0x72, 0x69, 0x65, 0x73, 0x2e, 0x72, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x44,
-	0x65, 0x6c, 0x65, 0x74, 0x65, 0x49, 0x74, 0x69, 0x6e, 0x65, 0x72, 0x61, 0x72, 0x69, 0x65, 0x73,
+	0x6d, 0x69, 0x74, 0x22, 0x87, 0x01, 0x0a, 0x13, 0x72, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x47, 0x65, 0x74, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x73, 0x12, 0x30, 0x0a, 0x08, 0x6d,
+	0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x14, 0x2e,
+	0x69, 0x74, 0x69, 0x6e, 0x65, 0x72, 0x61, 0x72, 0x69, 0x65, 0x73, 0x2e, 0x6d, 0x65, 0x73, 0x73,
+	0x61, 0x67, 0x65, 0x52, 0x08, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x73, 0x12, 0x14, 0x0a,
+	0x05, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x05, 0x74, 0x6f,
+	0x74, 0x61, 0x6c, 0x12, 0x12, 0x0a, 0x04, 0x70, 0x61, 0x67, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x05, 0x52, 0x04, 0x70, 0x61, 0x67, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x6c, 0x69, 0x6d, 0x69, 0x74,
+	0x18, 0x04, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x22, 0x32, 0x0a,
+	0x17, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x47, 0x65, 0x74, 0x55, 0x73, 0x65, 0x72, 0x53,
+	0x74, 0x61, 0x74, 0x69, 0x73, 0x74, 0x69, 0x63, 0x12, 0x17, 0x0a, 0x07, 0x75, 0x73, 0x65, 0x72,
+	0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x75, 0x73, 0x65, 0x72, 0x49,
+	0x64, 0x22, 0x56, 0x0a, 0x0d, 0x70, 0x6f, 0x70, 0x75, 0x6c, 0x61, 0x72, 0x53, 0x74, 0x6f, 0x72,
+	0x69, 0x79, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02,
+	0x69, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x74, 0x69, 0x74, 0x6c, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x05, 0x74, 0x69, 0x74, 0x6c, 0x65, 0x12, 0x1f, 0x0a, 0x0b, 0x6c, 0x69, 0x6b, 0x65,
+	0x73, 0x5f, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0a, 0x6c,
+	0x69, 0x6b, 0x65, 0x73, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x22, 0x59, 0x0a, 0x10, 0x70, 0x6f, 0x70,
+	0x75, 0x6c, 0x61, 0x72, 0x49, 0x74, 0x69, 0x6e, 0x65, 0x72, 0x61, 0x72, 0x79, 0x12, 0x0e, 0x0a,
+	0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x14, 0x0a,
+	0x05, 0x74, 0x69, 0x74, 0x6c, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x74, 0x69,
+	0x74, 0x6c, 0x65, 0x12, 0x1f, 0x0a, 0x0b, 0x6c, 0x69, 0x6b, 0x65, 0x73, 0x5f, 0x63, 0x6f, 0x75,
+	0x6e, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0a, 0x6c, 0x69, 0x6b, 0x65, 0x73, 0x43,
+	0x6f, 0x75, 0x6e, 0x74, 0x22, 0xc6, 0x03, 0x0a, 0x18, 0x72, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x47, 0x65, 0x74, 0x55, 0x73, 0x65, 0x72, 0x53, 0x74, 0x61, 0x74, 0x69, 0x73, 0x74, 0x69,
+	0x63, 0x12, 0x17, 0x0a, 0x07, 0x75, 0x73, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x06, 0x75, 0x73, 0x65, 0x72, 0x49, 0x64, 0x12, 0x23, 0x0a, 0x0d, 0x74, 0x6f,
+	0x74, 0x61, 0x6c, 0x5f, 0x73, 0x74, 0x6f, 0x72, 0x69, 0x65, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x03, 0x52, 0x0c, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x53, 0x74, 0x6f, 0x72, 0x69, 0x65, 0x73, 0x12,
+	0x2b, 0x0a, 0x11, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x5f, 0x69, 0x74, 0x69, 0x6e, 0x65, 0x72, 0x61,
+	0x72, 0x69, 0x65, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x10, 0x74, 0x6f, 0x74, 0x61,
+	0x6c, 0x49, 0x74, 0x69, 0x6e, 0x65, 0x72, 0x61, 0x72, 0x69, 0x65, 0x73, 0x12, 0x36, 0x0a, 0x17,
+	0x74, 0x6f, 0x74, 0x61, 0x6c, 0x5f, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x72, 0x69, 0x65, 0x73, 0x5f,
+	0x76, 0x69, 0x73, 0x69, 0x74, 0x65, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x15, 0x74,
+	0x6f, 0x74, 0x61, 0x6c, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x72, 0x69, 0x65, 0x73, 0x56, 0x69, 0x73,
+	0x69, 0x74, 0x65, 0x64, 0x12, 0x30, 0x0a, 0x14, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x5f, 0x6c, 0x69,
+	0x6b, 0x65, 0x73, 0x5f, 0x72, 0x65, 0x63, 0x65, 0x69, 0x76, 0x65, 0x64, 0x18, 0x05, 0x20, 0x01,
+	0x28, 0x03, 0x52, 0x12, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x4c, 0x69, 0x6b, 0x65, 0x73, 0x52, 0x65,
+	0x63, 0x65, 0x69, 0x76, 0x65, 0x64, 0x12, 0x36, 0x0a, 0x17, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x5f,
+	0x63, 0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x5f, 0x72, 0x65, 0x63, 0x65, 0x69, 0x76, 0x65,
+	0x64, 0x18, 0x06, 0x20, 0x01, 0x28, 0x03, 0x52, 0x15, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x43, 0x6f,
+	0x6d, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x52, 0x65, 0x63, 0x65, 0x69, 0x76, 0x65, 0x64, 0x12, 0x48,
+	0x0a, 0x12, 0x6d, 0x6f, 0x73, 0x74, 0x5f, 0x70, 0x6f, 0x70, 0x75, 0x6c, 0x61, 0x72, 0x5f, 0x73,
+	0x74, 0x6f, 0x72, 0x79, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x69, 0x74, 0x69,
+	0x6e, 0x65, 0x72, 0x61, 0x72, 0x69, 0x65, 0x73, 0x2e, 0x70, 0x6f, 0x70, 0x75, 0x6c, 0x61, 0x72,
+	0x53, 0x74, 0x6f, 0x72, 0x69, 0x79, 0x52, 0x10, 0x6d, 0x6f, 0x73, 0x74, 0x50, 0x6f, 0x70, 0x75,
+	0x6c, 0x61, 0x72, 0x53, 0x74, 0x6f, 0x72, 0x79, 0x12, 0x53, 0x0a, 0x16, 0x6d, 0x6f, 0x73, 0x74,
+	0x5f, 0x70, 0x6f, 0x70, 0x75, 0x6c, 0x61, 0x72, 0x5f, 0x69, 0x74, 0x69, 0x6e, 0x65, 0x72, 0x61,
+	0x72, 0x79, 0x18, 0x08, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1d, 0x2e, 0x69, 0x74, 0x69, 0x6e, 0x65,
+	0x72, 0x61, 0x72, 0x69, 0x65, 0x73, 0x2e, 0x70, 0x6f, 0x70, 0x75, 0x6c, 0x61, 0x72, 0x49, 0x74,
+	0x69, 0x6e, 0x65, 0x72, 0x61, 0x72, 0x79, 0x52, 0x14, 0x6d, 0x6f, 0x73, 0x74, 0x50, 0x6f, 0x70,
+	0x75, 0x6c, 0x61, 0x72, 0x49, 0x74, 0x69, 0x6e, 0x65, 0x72, 0x61, 0x72, 0x79, 0x32, 0xd7, 0x0b,
+	0x0a, 0x0b, 0x69, 0x74, 0x69, 0x6e, 0x65, 0x72, 0x61, 0x72, 0x69, 0x65, 0x73, 0x12, 0x62, 0x0a,
+	0x11, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x49, 0x74, 0x69, 0x6e, 0x65, 0x72, 0x61, 0x72, 0x69,
+	0x65, 0x73, 0x12, 0x25, 0x2e, 0x69, 0x74, 0x69, 0x6e, 0x65, 0x72, 0x61, 0x72, 0x69, 0x65, 0x73,
+	0x2e, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x49, 0x74,
+	0x69, 0x6e, 0x65, 0x72, 0x61, 0x72, 0x69, 0x65, 0x73, 0x1a, 0x26, 0x2e, 0x69, 0x74, 0x69, 0x6e,
+	0x65, 0x72, 0x61, 0x72, 0x69, 0x65, 0x73, 0x2e, 0x72, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x49, 0x74, 0x69, 0x6e, 0x65, 0x72, 0x61, 0x72, 0x69, 0x65,
+	0x73, 0x12, 0x5c, 0x0a, 0x0f, 0x45, 0x64, 0x69, 0x74, 0x49, 0x74, 0x69, 0x6e, 0x65, 0x72, 0x61,
+	0x72, 0x69, 0x65, 0x73, 0x12, 0x23, 0x2e, 0x69, 0x74, 0x69, 0x6e, 0x65, 0x72, 0x61, 0x72, 0x69,
+	0x65, 0x73, 0x2e, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x45, 0x64, 0x69, 0x74, 0x49, 0x74,
+	0x69, 0x6e, 0x65, 0x72, 0x61, 0x72, 0x69, 0x65, 0x73, 0x1a, 0x24, 0x2e, 0x69, 0x74, 0x69, 0x6e,
+	0x65, 0x72, 0x61, 0x72, 0x69, 0x65, 0x73, 0x2e, 0x72, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x45, 0x64, 0x69, 0x74, 0x49, 0x74, 0x69, 0x6e, 0x65, 0x72, 0x61, 0x72, 0x69, 0x65, 0x73, 0x12,
+	0x62, 0x0a, 0x11, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x49, 0x74, 0x69, 0x6e, 0x65, 0x72, 0x61,
+	0x72, 0x69, 0x65, 0x73, 0x12, 0x25, 0x2e, 0x69, 0x74, 0x69, 0x6e, 0x65, 0x72, 0x61, 0x72, 0x69,
+	0x65, 0x73, 0x2e, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65,
+	0x49, 0x74, 0x69, 0x6e, 0x65, 0x72, 0x61, 0x72, 0x69, 0x65, 0x73, 0x1a, 0x26, 0x2e, 0x69, 0x74,
+	0x69, 0x6e, 0x65, 0x72, 0x61, 0x72, 0x69, 0x65, 0x73, 0x2e, 0x72, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x49, 0x74, 0x69, 0x6e, 0x65, 0x72, 0x61, 0x72,
+	0x69, 0x65, 0x73, 0x12, 0x5f, 0x0a, 0x10, 0x52, 0x65, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x49, 0x74,
+	0x69, 0x6e, 0x65, 0x72, 0x61, 0x72, 0x79, 0x12, 0x24, 0x2e, 0x69, 0x74, 0x69, 0x6e, 0x65, 0x72,
+	0x61, 0x72, 0x69, 0x65, 0x73, 0x2e, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x52, 0x65, 0x73,
+	0x74, 0x6f, 0x72, 0x65, 0x49, 0x74, 0x69, 0x6e, 0x65, 0x72, 0x61, 0x72, 0x79, 0x1a, 0x25, 0x2e,
+	0x69, 0x74, 0x69, 0x6e, 0x65, 0x72, 0x61, 0x72, 0x69, 0x65, 0x73, 0x2e, 0x72, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x52, 0x65, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x49, 0x74, 0x69, 0x6e, 0x65,
+	0x72, 0x61, 0x72, 0x79, 0x12, 0x5c, 0x0a, 0x0f, 0x45, 0x78, 0x70, 0x6f, 0x72, 0x74, 0x49, 0x74,
+	0x69, 0x6e, 0x65, 0x72, 0x61, 0x72, 0x79, 0x12, 0x23, 0x2e, 0x69, 0x74, 0x69, 0x6e, 0x65, 0x72,
+	0x61, 0x72, 0x69, 0x65, 0x73, 0x2e, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x45, 0x78, 0x70,
+	0x6f, 0x72, 0x74, 0x49, 0x74, 0x69, 0x6e, 0x65, 0x72, 0x61, 0x72, 0x79, 0x1a, 0x24, 0x2e, 0x69,
+	0x74, 0x69, 0x6e, 0x65, 0x72, 0x61, 0x72, 0x69, 0x65, 0x73, 0x2e, 0x72, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x45, 0x78, 0x70, 0x6f, 0x72, 0x74, 0x49, 0x74, 0x69, 0x6e, 0x65, 0x72, 0x61,
+	0x72, 0x79, 0x12, 0x5c, 0x0a, 0x0f, 0x49, 0x6d, 0x70, 0x6f, 0x72, 0x74, 0x49, 0x74, 0x69, 0x6e,
+	0x65, 0x72, 0x61, 0x72, 0x79, 0x12, 0x23, 0x2e, 0x69, 0x74, 0x69, 0x6e, 0x65, 0x72, 0x61, 0x72,
+	0x69, 0x65, 0x73, 0x2e, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x49, 0x6d, 0x70, 0x6f, 0x72,
+	0x74, 0x49, 0x74, 0x69, 0x6e, 0x65, 0x72, 0x61, 0x72, 0x79, 0x1a, 0x24, 0x2e, 0x69, 0x74, 0x69,
+	0x6e, 0x65, 0x72, 0x61, 0x72, 0x69, 0x65, 0x73, 0x2e, 0x72, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x49, 0x6d, 0x70, 0x6f, 0x72, 0x74, 0x49, 0x74, 0x69, 0x6e, 0x65, 0x72, 0x61, 0x72, 0x79,
 	0x12, 0x62, 0x0a, 0x11, 0x47, 0x65, 0x74, 0x41, 0x6c, 0x6c, 0x49, 0x74, 0x69, 0x6e, 0x65, 0x72,
 	0x61, 0x72, 0x69, 0x65, 0x73, 0x12, 0x25, 0x2e, 0x69, 0x74, 0x69, 0x6e, 0x65, 0x72, 0x61, 0x72,
 	0x69, 0x65, 0x73, 0x2e, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x47, 0x65, 0x74, 0x41, 0x6c,
@@ -2926,7 +3488,7 @@ func file_itineraries_proto_rawDescGZIP() []byte {
 	return file_itineraries_proto_rawDescData
 }
 
-var file_itineraries_proto_msgTypes = make([]protoimpl.MessageInfo, 33)
+var file_itineraries_proto_msgTypes = make([]protoimpl.MessageInfo, 40)
 var file_itineraries_proto_goTypes = []interface{}{
 	(*RequestCreateDestination)(nil),        // 0: itineraries.requestCreateDestination
 	(*ResponseCreateDestination)(nil),       // 1: itineraries.responseCreateDestination
@@ -2939,72 +3501,86 @@ var file_itineraries_proto_goTypes = []interface{}{
 	(*ResponseEditItineraries)(nil),         // 8: itineraries.responseEditItineraries
 	(*RequestDeleteItineraries)(nil),        // 9: itineraries.requestDeleteItineraries
 	(*ResponseDeleteItineraries)(nil),       // 10: itineraries.responseDeleteItineraries
-	(*RequestGetAllItineraries)(nil),        // 11: itineraries.requestGetAllItineraries
-	(*Author)(nil),                          // 12: itineraries.author
-	(*Itinerary)(nil),                       // 13: itineraries.itinerary
-	(*ResponseGetAllItineraries)(nil),       // 14: itineraries.responseGetAllItineraries
-	(*RequestGetItineraryFullInfo)(nil),     // 15: itineraries.requestGetItineraryFullInfo
-	(*ResponseGetItineraryFullInfo)(nil),    // 16: itineraries.responseGetItineraryFullInfo
-	(*RequestWriteCommentToItinerary)(nil),  // 17: itineraries.requestWriteCommentToItinerary
-	(*ResponseWriteCommentToItinerary)(nil), // 18: itineraries.responseWriteCommentToItinerary
-	(*RequestGetDestinations)(nil),          // 19: itineraries.requestGetDestinations
-	(*DestionationInfo)(nil),                // 20: itineraries.destionationInfo
-	(*ResponseGetDestinations)(nil),         // 21: itineraries.responseGetDestinations
-	(*RequestGetDestinationsAllInfo)(nil),   // 22: itineraries.requestGetDestinationsAllInfo
-	(*ResponseGetDestinationsAllInfo)(nil),  // 23: itineraries.responseGetDestinationsAllInfo
-	(*RequestWriteMessages)(nil),            // 24: itineraries.requestWriteMessages
-	(*ResponseWriteMessages)(nil),           // 25: itineraries.responseWriteMessages
-	(*Message)(nil),                         // 26: itineraries.message
-	(*RequestGetMessages)(nil),              // 27: itineraries.requestGetMessages
-	(*ResponseGetMessages)(nil),             // 28: itineraries.responseGetMessages
-	(*RequestGetUserStatistic)(nil),         // 29: itineraries.requestGetUserStatistic
-	(*PopularStoriy)(nil),                   // 30: itineraries.popularStoriy
-	(*PopularItinerary)(nil),                // 31: itineraries.popularItinerary
-	(*ResponseGetUserStatistic)(nil),        // 32: itineraries.responseGetUserStatistic
+	(*RequestRestoreItinerary)(nil),         // 11: itineraries.requestRestoreItinerary
+	(*ResponseRestoreItinerary)(nil),        // 12: itineraries.responseRestoreItinerary
+	(*RequestExportItinerary)(nil),          // 13: itineraries.requestExportItinerary
+	(*ResponseExportItinerary)(nil),         // 14: itineraries.responseExportItinerary
+	(*RequestImportItinerary)(nil),          // 15: itineraries.requestImportItinerary
+	(*ImportWarning)(nil),                   // 16: itineraries.importWarning
+	(*ResponseImportItinerary)(nil),         // 17: itineraries.responseImportItinerary
+	(*RequestGetAllItineraries)(nil),        // 18: itineraries.requestGetAllItineraries
+	(*Author)(nil),                          // 19: itineraries.author
+	(*Itinerary)(nil),                       // 20: itineraries.itinerary
+	(*ResponseGetAllItineraries)(nil),       // 21: itineraries.responseGetAllItineraries
+	(*RequestGetItineraryFullInfo)(nil),     // 22: itineraries.requestGetItineraryFullInfo
+	(*ResponseGetItineraryFullInfo)(nil),    // 23: itineraries.responseGetItineraryFullInfo
+	(*RequestWriteCommentToItinerary)(nil),  // 24: itineraries.requestWriteCommentToItinerary
+	(*ResponseWriteCommentToItinerary)(nil), // 25: itineraries.responseWriteCommentToItinerary
+	(*RequestGetDestinations)(nil),          // 26: itineraries.requestGetDestinations
+	(*DestionationInfo)(nil),                // 27: itineraries.destionationInfo
+	(*ResponseGetDestinations)(nil),         // 28: itineraries.responseGetDestinations
+	(*RequestGetDestinationsAllInfo)(nil),   // 29: itineraries.requestGetDestinationsAllInfo
+	(*ResponseGetDestinationsAllInfo)(nil),  // 30: itineraries.responseGetDestinationsAllInfo
+	(*RequestWriteMessages)(nil),            // 31: itineraries.requestWriteMessages
+	(*ResponseWriteMessages)(nil),           // 32: itineraries.responseWriteMessages
+	(*Message)(nil),                         // 33: itineraries.message
+	(*RequestGetMessages)(nil),              // 34: itineraries.requestGetMessages
+	(*ResponseGetMessages)(nil),             // 35: itineraries.responseGetMessages
+	(*RequestGetUserStatistic)(nil),         // 36: itineraries.requestGetUserStatistic
+	(*PopularStoriy)(nil),                   // 37: itineraries.popularStoriy
+	(*PopularItinerary)(nil),                // 38: itineraries.popularItinerary
+	(*ResponseGetUserStatistic)(nil),        // 39: itineraries.responseGetUserStatistic
 }
 var file_itineraries_proto_depIdxs = []int32{
 	2,  // 0: itineraries.requestCreateItineraries.destinations:type_name -> itineraries.destination
 	5,  // 1: itineraries.destinationEdit.activities:type_name -> itineraries.activity
 	6,  // 2: itineraries.requestEditItineraries.destinations:type_name -> itineraries.destinationEdit
-	12, // 3: itineraries.itinerary.auther:type_name -> itineraries.author
-	13, // 4: itineraries.responseGetAllItineraries.itineraries:type_name -> itineraries.itinerary
-	12, // 5: itineraries.responseGetItineraryFullInfo.author:type_name -> itineraries.author
-	6,  // 6: itineraries.responseGetItineraryFullInfo.destinations:type_name -> itineraries.destinationEdit
-	20, // 7: itineraries.responseGetDestinations.destinations:type_name -> itineraries.destionationInfo
-	12, // 8: itineraries.message.sender:type_name -> itineraries.author
-	12, // 9: itineraries.message.recipient:type_name -> itineraries.author
-	26, // 10: itineraries.responseGetMessages.messages:type_name -> itineraries.message
-	30, // 11: itineraries.responseGetUserStatistic.most_popular_story:type_name -> itineraries.popularStoriy
-	31, // 12: itineraries.responseGetUserStatistic.most_popular_itinerary:type_name -> itineraries.popularItinerary
-	3,  // 13: itineraries.itineraries.CreateItineraries:input_type -> itineraries.requestCreateItineraries
-	7,  // 14: itineraries.itineraries.EditItineraries:input_type -> itineraries.requestEditItineraries
-	9,  // 15: itineraries.itineraries.DeleteItineraries:input_type -> itineraries.requestDeleteItineraries
-	11, // 16: itineraries.itineraries.GetAllItineraries:input_type -> itineraries.requestGetAllItineraries
-	15, // 17: itineraries.itineraries.GetItineraryFullInfo:input_type -> itineraries.requestGetItineraryFullInfo
-	17, // 18: itineraries.itineraries.WriteCommentToItinerary:input_type -> itineraries.requestWriteCommentToItinerary
-	19, // 19: itineraries.itineraries.GetDestinations:input_type -> itineraries.requestGetDestinations
-	22, // 20: itineraries.itineraries.GetDestinationsAllInfo:input_type -> itineraries.requestGetDestinationsAllInfo
-	24, // 21: itineraries.itineraries.WriteMessages:input_type -> itineraries.requestWriteMessages
-	27, // 22: itineraries.itineraries.GetMessages:input_type -> itineraries.requestGetMessages
-	29, // 23: itineraries.itineraries.GetUserStatistic:input_type -> itineraries.requestGetUserStatistic
-	0,  // 24: itineraries.itineraries.CreateDestination:input_type -> itineraries.requestCreateDestination
-	4,  // 25: itineraries.itineraries.CreateItineraries:output_type -> itineraries.responseCreateItineraries
-	8,  // 26: itineraries.itineraries.EditItineraries:output_type -> itineraries.responseEditItineraries
-	10, // 27: itineraries.itineraries.DeleteItineraries:output_type -> itineraries.responseDeleteItineraries
-	14, // 28: itineraries.itineraries.GetAllItineraries:output_type -> itineraries.responseGetAllItineraries
-	16, // 29: itineraries.itineraries.GetItineraryFullInfo:output_type -> itineraries.responseGetItineraryFullInfo
-	18, // 30: itineraries.itineraries.WriteCommentToItinerary:output_type -> itineraries.responseWriteCommentToItinerary
-	21, // 31: itineraries.itineraries.GetDestinations:output_type -> itineraries.responseGetDestinations
-	23, // 32: itineraries.itineraries.GetDestinationsAllInfo:output_type -> itineraries.responseGetDestinationsAllInfo
-	25, // 33: itineraries.itineraries.WriteMessages:output_type -> itineraries.responseWriteMessages
-	28, // 34: itineraries.itineraries.GetMessages:output_type -> itineraries.responseGetMessages
-	32, // 35: itineraries.itineraries.GetUserStatistic:output_type -> itineraries.responseGetUserStatistic
-	1,  // 36: itineraries.itineraries.CreateDestination:output_type -> itineraries.responseCreateDestination
-	25, // [25:37] is the sub-list for method output_type
-	13, // [13:25] is the sub-list for method input_type
-	13, // [13:13] is the sub-list for extension type_name
-	13, // [13:13] is the sub-list for extension extendee
-	0,  // [0:13] is the sub-list for field type_name
+	16, // 3: itineraries.responseImportItinerary.warnings:type_name -> itineraries.importWarning
+	19, // 4: itineraries.itinerary.auther:type_name -> itineraries.author
+	20, // 5: itineraries.responseGetAllItineraries.itineraries:type_name -> itineraries.itinerary
+	19, // 6: itineraries.responseGetItineraryFullInfo.author:type_name -> itineraries.author
+	6,  // 7: itineraries.responseGetItineraryFullInfo.destinations:type_name -> itineraries.destinationEdit
+	27, // 8: itineraries.responseGetDestinations.destinations:type_name -> itineraries.destionationInfo
+	19, // 9: itineraries.message.sender:type_name -> itineraries.author
+	19, // 10: itineraries.message.recipient:type_name -> itineraries.author
+	33, // 11: itineraries.responseGetMessages.messages:type_name -> itineraries.message
+	37, // 12: itineraries.responseGetUserStatistic.most_popular_story:type_name -> itineraries.popularStoriy
+	38, // 13: itineraries.responseGetUserStatistic.most_popular_itinerary:type_name -> itineraries.popularItinerary
+	3,  // 14: itineraries.itineraries.CreateItineraries:input_type -> itineraries.requestCreateItineraries
+	7,  // 15: itineraries.itineraries.EditItineraries:input_type -> itineraries.requestEditItineraries
+	9,  // 16: itineraries.itineraries.DeleteItineraries:input_type -> itineraries.requestDeleteItineraries
+	11, // 17: itineraries.itineraries.RestoreItinerary:input_type -> itineraries.requestRestoreItinerary
+	13, // 18: itineraries.itineraries.ExportItinerary:input_type -> itineraries.requestExportItinerary
+	15, // 19: itineraries.itineraries.ImportItinerary:input_type -> itineraries.requestImportItinerary
+	18, // 20: itineraries.itineraries.GetAllItineraries:input_type -> itineraries.requestGetAllItineraries
+	22, // 21: itineraries.itineraries.GetItineraryFullInfo:input_type -> itineraries.requestGetItineraryFullInfo
+	24, // 22: itineraries.itineraries.WriteCommentToItinerary:input_type -> itineraries.requestWriteCommentToItinerary
+	26, // 23: itineraries.itineraries.GetDestinations:input_type -> itineraries.requestGetDestinations
+	29, // 24: itineraries.itineraries.GetDestinationsAllInfo:input_type -> itineraries.requestGetDestinationsAllInfo
+	31, // 25: itineraries.itineraries.WriteMessages:input_type -> itineraries.requestWriteMessages
+	34, // 26: itineraries.itineraries.GetMessages:input_type -> itineraries.requestGetMessages
+	36, // 27: itineraries.itineraries.GetUserStatistic:input_type -> itineraries.requestGetUserStatistic
+	0,  // 28: itineraries.itineraries.CreateDestination:input_type -> itineraries.requestCreateDestination
+	4,  // 29: itineraries.itineraries.CreateItineraries:output_type -> itineraries.responseCreateItineraries
+	8,  // 30: itineraries.itineraries.EditItineraries:output_type -> itineraries.responseEditItineraries
+	10, // 31: itineraries.itineraries.DeleteItineraries:output_type -> itineraries.responseDeleteItineraries
+	12, // 32: itineraries.itineraries.RestoreItinerary:output_type -> itineraries.responseRestoreItinerary
+	14, // 33: itineraries.itineraries.ExportItinerary:output_type -> itineraries.responseExportItinerary
+	17, // 34: itineraries.itineraries.ImportItinerary:output_type -> itineraries.responseImportItinerary
+	21, // 35: itineraries.itineraries.GetAllItineraries:output_type -> itineraries.responseGetAllItineraries
+	23, // 36: itineraries.itineraries.GetItineraryFullInfo:output_type -> itineraries.responseGetItineraryFullInfo
+	25, // 37: itineraries.itineraries.WriteCommentToItinerary:output_type -> itineraries.responseWriteCommentToItinerary
+	28, // 38: itineraries.itineraries.GetDestinations:output_type -> itineraries.responseGetDestinations
+	30, // 39: itineraries.itineraries.GetDestinationsAllInfo:output_type -> itineraries.responseGetDestinationsAllInfo
+	32, // 40: itineraries.itineraries.WriteMessages:output_type -> itineraries.responseWriteMessages
+	35, // 41: itineraries.itineraries.GetMessages:output_type -> itineraries.responseGetMessages
+	39, // 42: itineraries.itineraries.GetUserStatistic:output_type -> itineraries.responseGetUserStatistic
+	1,  // 43: itineraries.itineraries.CreateDestination:output_type -> itineraries.responseCreateDestination
+	29, // [29:44] is the sub-list for method output_type
+	14, // [14:29] is the sub-list for method input_type
+	14, // [14:14] is the sub-list for extension type_name
+	14, // [14:14] is the sub-list for extension extendee
+	0,  // [0:14] is the sub-list for field type_name
 }
 
 func init() { file_itineraries_proto_init() }
@@ -3146,7 +3722,7 @@ func file_itineraries_proto_init() {
 			}
 		}
 		file_itineraries_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*RequestGetAllItineraries); i {
+			switch v := v.(*RequestRestoreItinerary); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -3158,7 +3734,7 @@ func file_itineraries_proto_init() {
 			}
 		}
 		file_itineraries_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Author); i {
+			switch v := v.(*ResponseRestoreItinerary); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -3170,7 +3746,7 @@ func file_itineraries_proto_init() {
 			}
 		}
 		file_itineraries_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Itinerary); i {
+			switch v := v.(*RequestExportItinerary); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -3182,7 +3758,7 @@ func file_itineraries_proto_init() {
 			}
 		}
 		file_itineraries_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ResponseGetAllItineraries); i {
+			switch v := v.(*ResponseExportItinerary); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -3194,7 +3770,7 @@ func file_itineraries_proto_init() {
 			}
 		}
 		file_itineraries_proto_msgTypes[15].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*RequestGetItineraryFullInfo); i {
+			switch v := v.(*RequestImportItinerary); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -3206,7 +3782,7 @@ func file_itineraries_proto_init() {
 			}
 		}
 		file_itineraries_proto_msgTypes[16].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ResponseGetItineraryFullInfo); i {
+			switch v := v.(*ImportWarning); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -3218,7 +3794,7 @@ func file_itineraries_proto_init() {
 			}
 		}
 		file_itineraries_proto_msgTypes[17].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*RequestWriteCommentToItinerary); i {
+			switch v := v.(*ResponseImportItinerary); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -3230,7 +3806,7 @@ func file_itineraries_proto_init() {
 			}
 		}
 		file_itineraries_proto_msgTypes[18].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ResponseWriteCommentToItinerary); i {
+			switch v := v.(*RequestGetAllItineraries); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -3242,7 +3818,7 @@ func file_itineraries_proto_init() {
 			}
 		}
 		file_itineraries_proto_msgTypes[19].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*RequestGetDestinations); i {
+			switch v := v.(*Author); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -3254,7 +3830,7 @@ func file_itineraries_proto_init() {
 			}
 		}
 		file_itineraries_proto_msgTypes[20].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*DestionationInfo); i {
+			switch v := v.(*Itinerary); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -3266,7 +3842,7 @@ func file_itineraries_proto_init() {
 			}
 		}
 		file_itineraries_proto_msgTypes[21].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ResponseGetDestinations); i {
+			switch v := v.(*ResponseGetAllItineraries); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -3278,7 +3854,7 @@ func file_itineraries_proto_init() {
 			}
 		}
 		file_itineraries_proto_msgTypes[22].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*RequestGetDestinationsAllInfo); i {
+			switch v := v.(*RequestGetItineraryFullInfo); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -3290,7 +3866,7 @@ func file_itineraries_proto_init() {
 			}
 		}
 		file_itineraries_proto_msgTypes[23].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ResponseGetDestinationsAllInfo); i {
+			switch v := v.(*ResponseGetItineraryFullInfo); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -3302,7 +3878,7 @@ func file_itineraries_proto_init() {
 			}
 		}
 		file_itineraries_proto_msgTypes[24].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*RequestWriteMessages); i {
+			switch v := v.(*RequestWriteCommentToItinerary); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -3314,7 +3890,7 @@ func file_itineraries_proto_init() {
 			}
 		}
 		file_itineraries_proto_msgTypes[25].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ResponseWriteMessages); i {
+			switch v := v.(*ResponseWriteCommentToItinerary); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -3326,7 +3902,7 @@ func file_itineraries_proto_init() {
 			}
 		}
 		file_itineraries_proto_msgTypes[26].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Message); i {
+			switch v := v.(*RequestGetDestinations); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -3338,7 +3914,7 @@ func file_itineraries_proto_init() {
 			}
 		}
 		file_itineraries_proto_msgTypes[27].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*RequestGetMessages); i {
+			switch v := v.(*DestionationInfo); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -3350,7 +3926,7 @@ func file_itineraries_proto_init() {
 			}
 		}
 		file_itineraries_proto_msgTypes[28].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ResponseGetMessages); i {
+			switch v := v.(*ResponseGetDestinations); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -3362,7 +3938,7 @@ func file_itineraries_proto_init() {
 			}
 		}
 		file_itineraries_proto_msgTypes[29].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*RequestGetUserStatistic); i {
+			switch v := v.(*RequestGetDestinationsAllInfo); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -3374,7 +3950,7 @@ func file_itineraries_proto_init() {
 			}
 		}
 		file_itineraries_proto_msgTypes[30].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*PopularStoriy); i {
+			switch v := v.(*ResponseGetDestinationsAllInfo); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -3386,7 +3962,7 @@ func file_itineraries_proto_init() {
 			}
 		}
 		file_itineraries_proto_msgTypes[31].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*PopularItinerary); i {
+			switch v := v.(*RequestWriteMessages); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -3398,6 +3974,90 @@ func file_itineraries_proto_init() {
 			}
 		}
 		file_itineraries_proto_msgTypes[32].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ResponseWriteMessages); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_itineraries_proto_msgTypes[33].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Message); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_itineraries_proto_msgTypes[34].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RequestGetMessages); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_itineraries_proto_msgTypes[35].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ResponseGetMessages); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_itineraries_proto_msgTypes[36].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RequestGetUserStatistic); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_itineraries_proto_msgTypes[37].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PopularStoriy); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_itineraries_proto_msgTypes[38].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PopularItinerary); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_itineraries_proto_msgTypes[39].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*ResponseGetUserStatistic); i {
 			case 0:
 				return &v.state
@@ -3410,13 +4070,15 @@ func file_itineraries_proto_init() {
 			}
 		}
 	}
+	file_itineraries_proto_msgTypes[2].OneofWrappers = []interface{}{}
+	file_itineraries_proto_msgTypes[6].OneofWrappers = []interface{}{}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_itineraries_proto_rawDesc,
 			NumEnums:      0,
-			NumMessages:   33,
+			NumMessages:   40,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -25,6 +25,9 @@ type ItinerariesClient interface {
 	CreateItineraries(ctx context.Context, in *RequestCreateItineraries, opts ...grpc.CallOption) (*ResponseCreateItineraries, error)
 	EditItineraries(ctx context.Context, in *RequestEditItineraries, opts ...grpc.CallOption) (*ResponseEditItineraries, error)
 	DeleteItineraries(ctx context.Context, in *RequestDeleteItineraries, opts ...grpc.CallOption) (*ResponseDeleteItineraries, error)
+	RestoreItinerary(ctx context.Context, in *RequestRestoreItinerary, opts ...grpc.CallOption) (*ResponseRestoreItinerary, error)
+	ExportItinerary(ctx context.Context, in *RequestExportItinerary, opts ...grpc.CallOption) (*ResponseExportItinerary, error)
+	ImportItinerary(ctx context.Context, in *RequestImportItinerary, opts ...grpc.CallOption) (*ResponseImportItinerary, error)
 	GetAllItineraries(ctx context.Context, in *RequestGetAllItineraries, opts ...grpc.CallOption) (*ResponseGetAllItineraries, error)
 	GetItineraryFullInfo(ctx context.Context, in *RequestGetItineraryFullInfo, opts ...grpc.CallOption) (*ResponseGetItineraryFullInfo, error)
 	WriteCommentToItinerary(ctx context.Context, in *RequestWriteCommentToItinerary, opts ...grpc.CallOption) (*ResponseWriteCommentToItinerary, error)
@@ -71,6 +74,33 @@ func (c *itinerariesClient) DeleteItineraries(ctx context.Context, in *RequestDe
 	return out, nil
 }
 
+func (c *itinerariesClient) RestoreItinerary(ctx context.Context, in *RequestRestoreItinerary, opts ...grpc.CallOption) (*ResponseRestoreItinerary, error) {
+	out := new(ResponseRestoreItinerary)
+	err := c.cc.Invoke(ctx, "/itineraries.itineraries/RestoreItinerary", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *itinerariesClient) ExportItinerary(ctx context.Context, in *RequestExportItinerary, opts ...grpc.CallOption) (*ResponseExportItinerary, error) {
+	out := new(ResponseExportItinerary)
+	err := c.cc.Invoke(ctx, "/itineraries.itineraries/ExportItinerary", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *itinerariesClient) ImportItinerary(ctx context.Context, in *RequestImportItinerary, opts ...grpc.CallOption) (*ResponseImportItinerary, error) {
+	out := new(ResponseImportItinerary)
+	err := c.cc.Invoke(ctx, "/itineraries.itineraries/ImportItinerary", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *itinerariesClient) GetAllItineraries(ctx context.Context, in *RequestGetAllItineraries, opts ...grpc.CallOption) (*ResponseGetAllItineraries, error) {
 	out := new(ResponseGetAllItineraries)
 	err := c.cc.Invoke(ctx, "/itineraries.itineraries/GetAllItineraries", in, out, opts...)
@@ -159,6 +189,9 @@ type ItinerariesServer interface {
 	CreateItineraries(context.Context, *RequestCreateItineraries) (*ResponseCreateItineraries, error)
 	EditItineraries(context.Context, *RequestEditItineraries) (*ResponseEditItineraries, error)
 	DeleteItineraries(context.Context, *RequestDeleteItineraries) (*ResponseDeleteItineraries, error)
+	RestoreItinerary(context.Context, *RequestRestoreItinerary) (*ResponseRestoreItinerary, error)
+	ExportItinerary(context.Context, *RequestExportItinerary) (*ResponseExportItinerary, error)
+	ImportItinerary(context.Context, *RequestImportItinerary) (*ResponseImportItinerary, error)
 	GetAllItineraries(context.Context, *RequestGetAllItineraries) (*ResponseGetAllItineraries, error)
 	GetItineraryFullInfo(context.Context, *RequestGetItineraryFullInfo) (*ResponseGetItineraryFullInfo, error)
 	WriteCommentToItinerary(context.Context, *RequestWriteCommentToItinerary) (*ResponseWriteCommentToItinerary, error)
@@ -184,6 +217,15 @@ func (UnimplementedItinerariesServer) EditItineraries(context.Context, *RequestE
 func (UnimplementedItinerariesServer) DeleteItineraries(context.Context, *RequestDeleteItineraries) (*ResponseDeleteItineraries, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method DeleteItineraries not implemented")
 }
+func (UnimplementedItinerariesServer) RestoreItinerary(context.Context, *RequestRestoreItinerary) (*ResponseRestoreItinerary, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RestoreItinerary not implemented")
+}
+func (UnimplementedItinerariesServer) ExportItinerary(context.Context, *RequestExportItinerary) (*ResponseExportItinerary, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ExportItinerary not implemented")
+}
+func (UnimplementedItinerariesServer) ImportItinerary(context.Context, *RequestImportItinerary) (*ResponseImportItinerary, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ImportItinerary not implemented")
+}
 func (UnimplementedItinerariesServer) GetAllItineraries(context.Context, *RequestGetAllItineraries) (*ResponseGetAllItineraries, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetAllItineraries not implemented")
 }
@@ -278,6 +320,60 @@ func _Itineraries_DeleteItineraries_Handler(srv interface{}, ctx context.Context
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Itineraries_RestoreItinerary_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RequestRestoreItinerary)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ItinerariesServer).RestoreItinerary(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/itineraries.itineraries/RestoreItinerary",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ItinerariesServer).RestoreItinerary(ctx, req.(*RequestRestoreItinerary))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Itineraries_ExportItinerary_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RequestExportItinerary)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ItinerariesServer).ExportItinerary(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/itineraries.itineraries/ExportItinerary",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ItinerariesServer).ExportItinerary(ctx, req.(*RequestExportItinerary))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Itineraries_ImportItinerary_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RequestImportItinerary)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ItinerariesServer).ImportItinerary(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/itineraries.itineraries/ImportItinerary",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ItinerariesServer).ImportItinerary(ctx, req.(*RequestImportItinerary))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _Itineraries_GetAllItineraries_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(RequestGetAllItineraries)
 	if err := dec(in); err != nil {
@@ -459,6 +555,18 @@ var Itineraries_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "DeleteItineraries",
 			Handler:    _Itineraries_DeleteItineraries_Handler,
 		},
+		{
+			MethodName: "RestoreItinerary",
+			Handler:    _Itineraries_RestoreItinerary_Handler,
+		},
+		{
+			MethodName: "ExportItinerary",
+			Handler:    _Itineraries_ExportItinerary_Handler,
+		},
+		{
+			MethodName: "ImportItinerary",
+			Handler:    _Itineraries_ImportItinerary_Handler,
+		},
 		{
 			MethodName: "GetAllItineraries",
 			Handler:    _Itineraries_GetAllItineraries_Handler,
@@ -25,8 +25,17 @@ type StoriesClient interface {
 	CreateStory(ctx context.Context, in *RequestCreateStory, opts ...grpc.CallOption) (*ResponseCreateStory, error)
 	EditStory(ctx context.Context, in *RequestEditStory, opts ...grpc.CallOption) (*ResponseEditStory, error)
 	DeleteStory(ctx context.Context, in *RequestDeleteStory, opts ...grpc.CallOption) (*ResponseDeleteStory, error)
+	RestoreStory(ctx context.Context, in *RequestRestoreStory, opts ...grpc.CallOption) (*ResponseRestoreStory, error)
 	GetStories(ctx context.Context, in *RequestGetStories, opts ...grpc.CallOption) (*ResponseGetStories, error)
 	GetStoryFullInfo(ctx context.Context, in *RequestGetStoryFullInfo, opts ...grpc.CallOption) (*ResponseGetStoryFullInfo, error)
+	SaveDraft(ctx context.Context, in *RequestSaveDraft, opts ...grpc.CallOption) (*ResponseSaveDraft, error)
+	ListDrafts(ctx context.Context, in *RequestListDrafts, opts ...grpc.CallOption) (*ResponseListDrafts, error)
+	PublishStory(ctx context.Context, in *RequestPublishStory, opts ...grpc.CallOption) (*ResponsePublishStory, error)
+	ArchiveStory(ctx context.Context, in *RequestArchiveStory, opts ...grpc.CallOption) (*ResponseArchiveStory, error)
+	ListStoryRevisions(ctx context.Context, in *RequestListStoryRevisions, opts ...grpc.CallOption) (*ResponseListStoryRevisions, error)
+	GetStoryRevision(ctx context.Context, in *RequestGetStoryRevision, opts ...grpc.CallOption) (*ResponseGetStoryRevision, error)
+	RestoreStoryRevision(ctx context.Context, in *RequestRestoreStoryRevision, opts ...grpc.CallOption) (*ResponseRestoreStoryRevision, error)
+	DiffStoryRevisions(ctx context.Context, in *RequestDiffStoryRevisions, opts ...grpc.CallOption) (*ResponseDiffStoryRevisions, error)
 }
 
 type storiesClient struct {
@@ -64,6 +73,15 @@ func (c *storiesClient) DeleteStory(ctx context.Context, in *RequestDeleteStory,
 	return out, nil
 }
 
+func (c *storiesClient) RestoreStory(ctx context.Context, in *RequestRestoreStory, opts ...grpc.CallOption) (*ResponseRestoreStory, error) {
+	out := new(ResponseRestoreStory)
+	err := c.cc.Invoke(ctx, "/stories.Stories/RestoreStory", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *storiesClient) GetStories(ctx context.Context, in *RequestGetStories, opts ...grpc.CallOption) (*ResponseGetStories, error) {
 	out := new(ResponseGetStories)
 	err := c.cc.Invoke(ctx, "/stories.Stories/GetStories", in, out, opts...)
@@ -82,6 +100,78 @@ func (c *storiesClient) GetStoryFullInfo(ctx context.Context, in *RequestGetStor
 	return out, nil
 }
 
+func (c *storiesClient) SaveDraft(ctx context.Context, in *RequestSaveDraft, opts ...grpc.CallOption) (*ResponseSaveDraft, error) {
+	out := new(ResponseSaveDraft)
+	err := c.cc.Invoke(ctx, "/stories.Stories/SaveDraft", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *storiesClient) ListDrafts(ctx context.Context, in *RequestListDrafts, opts ...grpc.CallOption) (*ResponseListDrafts, error) {
+	out := new(ResponseListDrafts)
+	err := c.cc.Invoke(ctx, "/stories.Stories/ListDrafts", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *storiesClient) PublishStory(ctx context.Context, in *RequestPublishStory, opts ...grpc.CallOption) (*ResponsePublishStory, error) {
+	out := new(ResponsePublishStory)
+	err := c.cc.Invoke(ctx, "/stories.Stories/PublishStory", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *storiesClient) ArchiveStory(ctx context.Context, in *RequestArchiveStory, opts ...grpc.CallOption) (*ResponseArchiveStory, error) {
+	out := new(ResponseArchiveStory)
+	err := c.cc.Invoke(ctx, "/stories.Stories/ArchiveStory", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *storiesClient) ListStoryRevisions(ctx context.Context, in *RequestListStoryRevisions, opts ...grpc.CallOption) (*ResponseListStoryRevisions, error) {
+	out := new(ResponseListStoryRevisions)
+	err := c.cc.Invoke(ctx, "/stories.Stories/ListStoryRevisions", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *storiesClient) GetStoryRevision(ctx context.Context, in *RequestGetStoryRevision, opts ...grpc.CallOption) (*ResponseGetStoryRevision, error) {
+	out := new(ResponseGetStoryRevision)
+	err := c.cc.Invoke(ctx, "/stories.Stories/GetStoryRevision", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *storiesClient) RestoreStoryRevision(ctx context.Context, in *RequestRestoreStoryRevision, opts ...grpc.CallOption) (*ResponseRestoreStoryRevision, error) {
+	out := new(ResponseRestoreStoryRevision)
+	err := c.cc.Invoke(ctx, "/stories.Stories/RestoreStoryRevision", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *storiesClient) DiffStoryRevisions(ctx context.Context, in *RequestDiffStoryRevisions, opts ...grpc.CallOption) (*ResponseDiffStoryRevisions, error) {
+	out := new(ResponseDiffStoryRevisions)
+	err := c.cc.Invoke(ctx, "/stories.Stories/DiffStoryRevisions", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // StoriesServer is the server API for Stories service.
 // All implementations must embed UnimplementedStoriesServer
 // for forward compatibility
@@ -89,8 +179,17 @@ type StoriesServer interface {
 	CreateStory(context.Context, *RequestCreateStory) (*ResponseCreateStory, error)
 	EditStory(context.Context, *RequestEditStory) (*ResponseEditStory, error)
 	DeleteStory(context.Context, *RequestDeleteStory) (*ResponseDeleteStory, error)
+	RestoreStory(context.Context, *RequestRestoreStory) (*ResponseRestoreStory, error)
 	GetStories(context.Context, *RequestGetStories) (*ResponseGetStories, error)
 	GetStoryFullInfo(context.Context, *RequestGetStoryFullInfo) (*ResponseGetStoryFullInfo, error)
+	SaveDraft(context.Context, *RequestSaveDraft) (*ResponseSaveDraft, error)
+	ListDrafts(context.Context, *RequestListDrafts) (*ResponseListDrafts, error)
+	PublishStory(context.Context, *RequestPublishStory) (*ResponsePublishStory, error)
+	ArchiveStory(context.Context, *RequestArchiveStory) (*ResponseArchiveStory, error)
+	ListStoryRevisions(context.Context, *RequestListStoryRevisions) (*ResponseListStoryRevisions, error)
+	GetStoryRevision(context.Context, *RequestGetStoryRevision) (*ResponseGetStoryRevision, error)
+	RestoreStoryRevision(context.Context, *RequestRestoreStoryRevision) (*ResponseRestoreStoryRevision, error)
+	DiffStoryRevisions(context.Context, *RequestDiffStoryRevisions) (*ResponseDiffStoryRevisions, error)
 	mustEmbedUnimplementedStoriesServer()
 }
 
@@ -107,12 +206,39 @@ func (UnimplementedStoriesServer) EditStory(context.Context, *RequestEditStory)
 func (UnimplementedStoriesServer) DeleteStory(context.Context, *RequestDeleteStory) (*ResponseDeleteStory, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method DeleteStory not implemented")
 }
+func (UnimplementedStoriesServer) RestoreStory(context.Context, *RequestRestoreStory) (*ResponseRestoreStory, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RestoreStory not implemented")
+}
 func (UnimplementedStoriesServer) GetStories(context.Context, *RequestGetStories) (*ResponseGetStories, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetStories not implemented")
 }
 func (UnimplementedStoriesServer) GetStoryFullInfo(context.Context, *RequestGetStoryFullInfo) (*ResponseGetStoryFullInfo, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetStoryFullInfo not implemented")
 }
+func (UnimplementedStoriesServer) SaveDraft(context.Context, *RequestSaveDraft) (*ResponseSaveDraft, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SaveDraft not implemented")
+}
+func (UnimplementedStoriesServer) ListDrafts(context.Context, *RequestListDrafts) (*ResponseListDrafts, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListDrafts not implemented")
+}
+func (UnimplementedStoriesServer) PublishStory(context.Context, *RequestPublishStory) (*ResponsePublishStory, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PublishStory not implemented")
+}
+func (UnimplementedStoriesServer) ArchiveStory(context.Context, *RequestArchiveStory) (*ResponseArchiveStory, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ArchiveStory not implemented")
+}
+func (UnimplementedStoriesServer) ListStoryRevisions(context.Context, *RequestListStoryRevisions) (*ResponseListStoryRevisions, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListStoryRevisions not implemented")
+}
+func (UnimplementedStoriesServer) GetStoryRevision(context.Context, *RequestGetStoryRevision) (*ResponseGetStoryRevision, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetStoryRevision not implemented")
+}
+func (UnimplementedStoriesServer) RestoreStoryRevision(context.Context, *RequestRestoreStoryRevision) (*ResponseRestoreStoryRevision, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RestoreStoryRevision not implemented")
+}
+func (UnimplementedStoriesServer) DiffStoryRevisions(context.Context, *RequestDiffStoryRevisions) (*ResponseDiffStoryRevisions, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DiffStoryRevisions not implemented")
+}
 func (UnimplementedStoriesServer) mustEmbedUnimplementedStoriesServer() {}
 
 // UnsafeStoriesServer may be embedded to opt out of forward compatibility for this service.
@@ -180,6 +306,24 @@ func _Stories_DeleteStory_Handler(srv interface{}, ctx context.Context, dec func
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Stories_RestoreStory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RequestRestoreStory)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StoriesServer).RestoreStory(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/stories.Stories/RestoreStory",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StoriesServer).RestoreStory(ctx, req.(*RequestRestoreStory))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _Stories_GetStories_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(RequestGetStories)
 	if err := dec(in); err != nil {
@@ -216,6 +360,150 @@ func _Stories_GetStoryFullInfo_Handler(srv interface{}, ctx context.Context, dec
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Stories_SaveDraft_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RequestSaveDraft)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StoriesServer).SaveDraft(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/stories.Stories/SaveDraft",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StoriesServer).SaveDraft(ctx, req.(*RequestSaveDraft))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Stories_ListDrafts_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RequestListDrafts)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StoriesServer).ListDrafts(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/stories.Stories/ListDrafts",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StoriesServer).ListDrafts(ctx, req.(*RequestListDrafts))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Stories_PublishStory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RequestPublishStory)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StoriesServer).PublishStory(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/stories.Stories/PublishStory",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StoriesServer).PublishStory(ctx, req.(*RequestPublishStory))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Stories_ArchiveStory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RequestArchiveStory)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StoriesServer).ArchiveStory(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/stories.Stories/ArchiveStory",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StoriesServer).ArchiveStory(ctx, req.(*RequestArchiveStory))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Stories_ListStoryRevisions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RequestListStoryRevisions)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StoriesServer).ListStoryRevisions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/stories.Stories/ListStoryRevisions",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StoriesServer).ListStoryRevisions(ctx, req.(*RequestListStoryRevisions))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Stories_GetStoryRevision_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RequestGetStoryRevision)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StoriesServer).GetStoryRevision(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/stories.Stories/GetStoryRevision",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StoriesServer).GetStoryRevision(ctx, req.(*RequestGetStoryRevision))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Stories_RestoreStoryRevision_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RequestRestoreStoryRevision)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StoriesServer).RestoreStoryRevision(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/stories.Stories/RestoreStoryRevision",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StoriesServer).RestoreStoryRevision(ctx, req.(*RequestRestoreStoryRevision))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Stories_DiffStoryRevisions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RequestDiffStoryRevisions)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StoriesServer).DiffStoryRevisions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/stories.Stories/DiffStoryRevisions",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StoriesServer).DiffStoryRevisions(ctx, req.(*RequestDiffStoryRevisions))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // Stories_ServiceDesc is the grpc.ServiceDesc for Stories service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -235,6 +523,10 @@ var Stories_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "DeleteStory",
 			Handler:    _Stories_DeleteStory_Handler,
 		},
+		{
+			MethodName: "RestoreStory",
+			Handler:    _Stories_RestoreStory_Handler,
+		},
 		{
 			MethodName: "GetStories",
 			Handler:    _Stories_GetStories_Handler,
@@ -243,6 +535,38 @@ var Stories_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetStoryFullInfo",
 			Handler:    _Stories_GetStoryFullInfo_Handler,
 		},
+		{
+			MethodName: "SaveDraft",
+			Handler:    _Stories_SaveDraft_Handler,
+		},
+		{
+			MethodName: "ListDrafts",
+			Handler:    _Stories_ListDrafts_Handler,
+		},
+		{
+			MethodName: "PublishStory",
+			Handler:    _Stories_PublishStory_Handler,
+		},
+		{
+			MethodName: "ArchiveStory",
+			Handler:    _Stories_ArchiveStory_Handler,
+		},
+		{
+			MethodName: "ListStoryRevisions",
+			Handler:    _Stories_ListStoryRevisions_Handler,
+		},
+		{
+			MethodName: "GetStoryRevision",
+			Handler:    _Stories_GetStoryRevision_Handler,
+		},
+		{
+			MethodName: "RestoreStoryRevision",
+			Handler:    _Stories_RestoreStoryRevision_Handler,
+		},
+		{
+			MethodName: "DiffStoryRevisions",
+			Handler:    _Stories_DiffStoryRevisions_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "stories.proto",
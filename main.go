@@ -1,42 +1,217 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"log"
+	"log/slog"
 	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
 	"travel/config"
+	"travel/db/migrations"
+	pbAdmin "travel/genproto/admin"
 	pbInter "travel/genproto/interactions"
 	pbItiner "travel/genproto/itineraries"
 
 	pb "travel/genproto/stories"
+	"travel/pkg/connections"
+	"travel/pkg/health"
+	"travel/pkg/logger"
+	"travel/pkg/media"
+	"travel/pkg/metrics"
+	"travel/pkg/tracing"
 	"travel/service"
 	"travel/storage/postgres"
+	"travel/storage/redis"
 
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/reflection"
 )
 
 func main() {
-	listener, err := net.Listen("tcp", config.Load().CONTENT_SERVICE_PORT)
+	cfg, err := config.Load()
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	if len(os.Args) > 1 && os.Args[1] == "migrate" {
+		runMigrate(cfg, os.Args[2:])
+		return
+	}
+
+	listener, err := net.Listen("tcp", cfg.CONTENT_SERVICE_PORT)
 	if err != nil {
 		log.Panic(err)
 	}
 	defer listener.Close()
 
-	db, err := postgres.ConnectDB()
+	shutdownTracing, err := tracing.Init(cfg)
+	if err != nil {
+		log.Panicf("error with setting up tracing: %s", err)
+	}
+
+	db, err := postgres.ConnectDB(cfg)
 	if err != nil {
 		log.Panic(err)
 	}
 
-	u := service.NewContentService(db)
-	interactions := service.NewInterationsService(db)
-	itiner := service.NewItinerariesService(db)
-	server := grpc.NewServer()
+	if cfg.DB_AUTO_MIGRATE {
+		if err := migrations.Up(db); err != nil {
+			log.Panicf("error with migrating database: %s", err)
+		}
+	}
+
+	appLogger := logger.NewLogger(cfg)
+	appLogger.Info("loaded config", "config", cfg)
+
+	cache := redis.NewCache(cfg)
+	userClient, err := connections.NewUserClient(cfg)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	store, err := media.NewStore(cfg)
+	if err != nil {
+		log.Fatal(err)
+	}
+	images := media.New(cfg, store)
+
+	storiesRepo := postgres.NewStoriesRepo(db, appLogger, cfg.DB_QUERY_TIMEOUT)
+	itinerariesRepo := postgres.NewItinerariesRepo(db, appLogger, cfg.DB_QUERY_TIMEOUT)
+	u := service.NewContentService(appLogger, storiesRepo, userClient, cache, images, cfg.DELETED_GRACE_PERIOD)
+	interactions := service.NewInterationsService(appLogger,
+		postgres.NewInterationsRepo(db, appLogger, cfg.DB_QUERY_TIMEOUT),
+		userClient, cache)
+	itiner := service.NewItinerariesService(appLogger, itinerariesRepo,
+		userClient, cache, cfg.DELETED_GRACE_PERIOD)
+	admin := service.NewAdminService(appLogger,
+		postgres.NewAdminRepo(db, appLogger, cfg.DB_QUERY_TIMEOUT), storiesRepo,
+		itinerariesRepo, images, cache,
+		cfg.PURGE_RETENTION, cfg.PURGE_BATCH_SIZE)
+	server := newServer(appLogger)
 	pb.RegisterStoriesServer(server, u)
 	pbInter.RegisterInteractionsServer(server, interactions)
 	pbItiner.RegisterItinerariesServer(server, itiner)
 
-	fmt.Printf("Content service is listening on port %s...\n", config.Load().CONTENT_SERVICE_PORT)
-	if err := server.Serve(listener); err != nil {
+	// The admin RPCs purge and export any user's content, so they are only
+	// served on ADMIN_ADDR, which is meant to be reachable from inside the
+	// cluster only, and not at all when it is empty.
+	var adminServer *grpc.Server
+	var adminListener net.Listener
+	if cfg.ADMIN_ADDR != "" {
+		adminListener, err = net.Listen("tcp", cfg.ADMIN_ADDR)
+		if err != nil {
+			log.Panic(err)
+		}
+		defer adminListener.Close()
+		adminServer = newServer(appLogger)
+		pbAdmin.RegisterAdminServer(adminServer, admin)
+	}
+
+	checker := health.NewChecker(appLogger, map[string]health.Check{
+		"postgres": db.PingContext,
+		"redis":    cache.Ping,
+	}, "postgres")
+	checker.Register(server)
+	if cfg.GRPC_REFLECTION {
+		reflection.Register(server)
+	}
+
+	var metricsServer *http.Server
+	if addr := cfg.METRICS_ADDR; addr != "" {
+		metrics.RegisterPoolStats(func() map[string]float64 {
+			return postgres.PoolStats(db)
+		})
+		metricsServer = metrics.Serve(addr)
+	}
+
+	var mediaServer *http.Server
+	if local, ok := store.(*media.LocalStore); ok && cfg.MEDIA_ADDR != "" {
+		mediaServer = media.Serve(cfg.MEDIA_ADDR, local)
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt,
+		syscall.SIGTERM)
+	defer stop()
+	go checker.Run(ctx)
+	go u.RunScheduler(ctx, cfg.SCHEDULER_INTERVAL)
+	go admin.RunPurge(ctx, cfg.PURGE_INTERVAL)
+	if cfg.USER_EVENTS_STREAM != "" {
+		consumer, err := os.Hostname()
+		if err != nil {
+			consumer = "content-service"
+		}
+		events := redis.NewUserEvents(redis.NewRedicClient(cfg),
+			cfg.USER_EVENTS_STREAM, cfg.USER_EVENTS_GROUP, consumer)
+		go admin.ConsumeUserEvents(ctx, events, cfg.USER_EVENTS_MODE)
+	}
+
+	serveErr := make(chan error, 2)
+	go func() {
+		fmt.Printf("Content service is listening on port %s...\n",
+			cfg.CONTENT_SERVICE_PORT)
+		serveErr <- server.Serve(listener)
+	}()
+	if adminServer != nil {
+		go func() {
+			fmt.Printf("Admin service is listening on %s...\n", cfg.ADMIN_ADDR)
+			serveErr <- adminServer.Serve(adminListener)
+		}()
+	}
+
+	select {
+	case err := <-serveErr:
 		log.Fatalf("Error with listening content server: %s", err)
+	case <-ctx.Done():
+	}
+
+	fmt.Println("Content service is shutting down...")
+	checker.Shutdown()
+	shutdown(server, cfg.SHUTDOWN_TIMEOUT)
+	if adminServer != nil {
+		shutdown(adminServer, cfg.SHUTDOWN_TIMEOUT)
+	}
+	if metricsServer != nil {
+		metricsServer.Close()
+	}
+	if mediaServer != nil {
+		mediaServer.Close()
+	}
+	userClient.Close()
+	db.Close()
+
+	flushCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := shutdownTracing(flushCtx); err != nil {
+		log.Printf("error with flushing traces: %s", err)
+	}
+}
+
+func newServer(appLogger *slog.Logger) *grpc.Server {
+	return grpc.NewServer(
+		grpc.StatsHandler(otelgrpc.NewServerHandler()),
+		grpc.ChainUnaryInterceptor(logger.UnaryServerInterceptor(appLogger),
+			metrics.UnaryServerInterceptor()))
+}
+
+// shutdown waits for in-flight RPCs to finish, and cancels the ones still
+// running after timeout.
+func shutdown(server *grpc.Server, timeout time.Duration) {
+	stopped := make(chan struct{})
+	go func() {
+		server.GracefulStop()
+		close(stopped)
+	}()
+
+	select {
+	case <-stopped:
+	case <-time.After(timeout):
+		log.Printf("RPCs did not finish in %s, stopping content server", timeout)
+		server.Stop()
 	}
 }
@@ -0,0 +1,75 @@
+package main
+
+import (
+	"database/sql"
+	"fmt"
+	"log"
+	"strconv"
+	"travel/config"
+	"travel/db/migrations"
+	"travel/storage/postgres"
+)
+
+const migrateUsage = "usage: migrate up | down [N] | status | force VERSION"
+
+// runMigrate implements the migrate subcommand of the binary.
+func runMigrate(cfg *config.Config, args []string) {
+	if len(args) == 0 {
+		log.Fatal(migrateUsage)
+	}
+
+	db, err := postgres.ConnectDB(cfg)
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer db.Close()
+
+	switch args[0] {
+	case "up":
+		err = migrations.Up(db)
+	case "down":
+		steps := 1
+		if len(args) > 1 {
+			steps, err = strconv.Atoi(args[1])
+			if err != nil {
+				log.Fatal(migrateUsage)
+			}
+		}
+		err = migrations.Down(db, steps)
+	case "force":
+		if len(args) < 2 {
+			log.Fatal(migrateUsage)
+		}
+		var version int
+		version, err = strconv.Atoi(args[1])
+		if err != nil {
+			log.Fatal(migrateUsage)
+		}
+		err = migrations.Force(db, version)
+	case "status":
+		err = printMigrationStatus(db)
+	default:
+		log.Fatal(migrateUsage)
+	}
+	if err != nil {
+		log.Fatalf("error with migrating database: %s", err)
+	}
+}
+
+func printMigrationStatus(db *sql.DB) error {
+	status, err := migrations.GetStatus(db)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("version: %d, dirty: %t\n", status.Version, status.Dirty)
+	for _, mig := range status.Migrations {
+		state := "pending"
+		if mig.Dirty {
+			state = "dirty"
+		} else if mig.Applied {
+			state = "applied"
+		}
+		fmt.Printf("%06d %-30s %s\n", mig.Version, mig.Name, state)
+	}
+	return nil
+}
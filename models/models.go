@@ -1,5 +1,15 @@
 package models
 
+import "time"
+
+// Story statuses. Only published stories are listed.
+const (
+	StoryDraft     = "draft"
+	StoryScheduled = "scheduled"
+	StoryPublished = "published"
+	StoryArchived  = "archived"
+)
+
 type Story struct {
 	Id            string
 	Title         string
@@ -8,6 +18,7 @@ type Story struct {
 	LikesCount    int
 	CommentsCount int
 	CreatedAt     string
+	CoverImage    *Image
 }
 
 type StoryFullInfo struct {
@@ -20,6 +31,39 @@ type StoryFullInfo struct {
 	CommentsCount int
 	CreatedAt     string
 	UpdatedAt     string
+	Images        []Image
+	Status        string
+	PublishAt     string
+}
+
+// StoryImagesEdit is how an edit changes the images of a story: Added are
+// appended after the images left by removing RemoveIds or, when Replace is
+// set, all of them. Restore, when not nil, instead makes the images with its
+// ids, in order, the images of the story.
+type StoryImagesEdit struct {
+	Added     []Image
+	Replace   bool
+	RemoveIds []string
+	Restore   []string
+}
+
+// EditedStory is a story after an edit, with the images the edit deleted,
+// whose blobs can be removed once the edit is committed.
+type EditedStory struct {
+	AuthorId string
+	Images   []Image
+	Deleted  []Image
+}
+
+type StoryRevision struct {
+	StoryId   string
+	Revision  int
+	Title     string
+	Content   string
+	Location  string
+	Tags      []string
+	Images    []Image
+	CreatedAt string
 }
 
 type Comment struct {
@@ -53,3 +97,111 @@ type ItineraryFullInfo struct {
 	CreatedAt     string
 	UpdatedAt     string
 }
+
+type Image struct {
+	Id           string
+	Key          string
+	Url          string
+	ThumbnailKey string
+	ThumbnailUrl string
+	ContentType  string
+	Width        int
+	Height       int
+}
+
+// kinds of soft-deleted content
+const (
+	KindStory     = "story"
+	KindItinerary = "itinerary"
+)
+
+type DeletedItem struct {
+	Id        string
+	Kind      string
+	Title     string
+	AuthorId  string
+	DeletedAt time.Time
+}
+
+// PurgeResult counts the rows removed for good by a purge, besides the
+// children of the stories and itineraries.
+type PurgeResult struct {
+	Stories     int
+	Itineraries int
+	Comments    int
+	Images      []Image
+}
+
+// DeletedUserId is the author of the content anonymized after its user was
+// deleted.
+const DeletedUserId = "00000000-0000-0000-0000-000000000000"
+
+// modes of purging the content of a deleted user
+const (
+	PurgeAnonymize = "anonymize"
+	PurgeDelete    = "delete"
+)
+
+// UserContentResult counts the content of a user affected by purging it.
+type UserContentResult struct {
+	Stories           int
+	Itineraries       int
+	Comments          int
+	ItineraryComments int
+	Likes             int
+	Messages          int
+	// other users' stories and itineraries whose counters were updated
+	UpdatedStories     int
+	UpdatedItineraries int
+	// every story and itinerary changed, to invalidate their cached details
+	StoryIds     []string
+	ItineraryIds []string
+}
+
+// The content of a user as exported to them, deleted or not.
+
+type UserStory struct {
+	Id        string
+	Title     string
+	Content   string
+	Location  string
+	Status    string
+	PublishAt string
+	CreatedAt string
+	UpdatedAt string
+	DeletedAt string
+}
+
+type UserItinerary struct {
+	Id          string
+	Title       string
+	Description string
+	StartDate   string
+	EndDate     string
+	CreatedAt   string
+	UpdatedAt   string
+	DeletedAt   string
+}
+
+// UserComment is a comment on either a story or an itinerary.
+type UserComment struct {
+	Id          string
+	StoryId     string
+	ItineraryId string
+	Content     string
+	CreatedAt   string
+	DeletedAt   string
+}
+
+type Like struct {
+	StoryId   string
+	CreatedAt string
+}
+
+type Message struct {
+	Id          string
+	RecipientId string
+	Content     string
+	CreatedAt   string
+	DeletedAt   string
+}
@@ -0,0 +1,99 @@
+package connections
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Breaker is a circuit breaker for the calls to a service. After Failures
+// calls in a row fail because the service is unavailable, it rejects calls
+// for Cooldown, then lets one call through to probe the service and closes
+// again when it succeeds.
+type Breaker struct {
+	Failures int
+	Cooldown time.Duration
+
+	mu        sync.Mutex
+	failed    int
+	openUntil time.Time
+	probing   bool
+	now       func() time.Time
+}
+
+func NewBreaker(failures int, cooldown time.Duration) *Breaker {
+	return &Breaker{Failures: failures, Cooldown: cooldown, now: time.Now}
+}
+
+// allow reports whether a call may go through.
+func (b *Breaker) allow() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.failed < b.Failures {
+		return true
+	}
+	if b.now().Before(b.openUntil) || b.probing {
+		return false
+	}
+	b.probing = true
+	return true
+}
+
+func (b *Breaker) record(err error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.probing = false
+	if !unavailable(err) {
+		b.failed = 0
+		return
+	}
+	b.failed++
+	if b.failed >= b.Failures {
+		b.openUntil = b.now().Add(b.Cooldown)
+	}
+}
+
+// unavailable reports whether err means the service could not serve the call,
+// as opposed to the call itself being rejected.
+func unavailable(err error) bool {
+	switch status.Code(err) {
+	case codes.Unavailable, codes.DeadlineExceeded, codes.ResourceExhausted,
+		codes.Internal:
+		return true
+	}
+	return false
+}
+
+// UnaryClientInterceptor fails calls fast with Unavailable while the breaker
+// is open.
+func (b *Breaker) UnaryClientInterceptor() grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply interface{},
+		cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		if !b.allow() {
+			return status.Errorf(codes.Unavailable,
+				"circuit breaker is open for %s", method)
+		}
+		err := invoker(ctx, method, req, reply, cc, opts...)
+		b.record(err)
+		return err
+	}
+}
+
+// TimeoutInterceptor bounds every call, with its retries, by timeout unless
+// the caller set an earlier deadline.
+func TimeoutInterceptor(timeout time.Duration) grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply interface{},
+		cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		if deadline, ok := ctx.Deadline(); timeout <= 0 ||
+			ok && time.Until(deadline) < timeout {
+			return invoker(ctx, method, req, reply, cc, opts...)
+		}
+		ctx, cancel := context.WithTimeout(ctx, timeout)
+		defer cancel()
+		return invoker(ctx, method, req, reply, cc, opts...)
+	}
+}
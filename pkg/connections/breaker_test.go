@@ -0,0 +1,83 @@
+package connections
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestBreaker(t *testing.T) {
+	now := time.Now()
+	b := NewBreaker(2, time.Minute)
+	b.now = func() time.Time { return now }
+
+	calls := 0
+	result := status.Error(codes.Unavailable, "down")
+	invoke := func() error {
+		return b.UnaryClientInterceptor()(context.Background(), "/users.Users/GetProfile",
+			nil, nil, nil, func(context.Context, string, interface{}, interface{},
+				*grpc.ClientConn, ...grpc.CallOption) error {
+				calls++
+				return result
+			})
+	}
+
+	invoke()
+	invoke()
+	if err := invoke(); status.Code(err) != codes.Unavailable || calls != 2 {
+		t.Fatalf("expected the breaker to open after 2 failures, got %v after %d calls",
+			err, calls)
+	}
+
+	now = now.Add(time.Minute)
+	invoke()
+	if calls != 3 {
+		t.Fatalf("expected a trial call after the cooldown, got %d calls", calls)
+	}
+	invoke()
+	if calls != 3 {
+		t.Fatalf("expected the failed trial to open the breaker again, got %d calls", calls)
+	}
+
+	now = now.Add(time.Minute)
+	result = nil
+	invoke()
+	invoke()
+	if calls != 5 {
+		t.Errorf("expected a successful trial to close the breaker, got %d calls", calls)
+	}
+}
+
+func TestBreakerIgnoresCallErrors(t *testing.T) {
+	b := NewBreaker(1, time.Minute)
+	b.record(status.Error(codes.Unknown, "sql: no rows in result set"))
+	b.record(status.Error(codes.InvalidArgument, "invalid id"))
+	if !b.allow() {
+		t.Error("expected errors from the call itself not to open the breaker")
+	}
+}
+
+func TestTimeoutInterceptor(t *testing.T) {
+	var deadline time.Time
+	invoker := func(ctx context.Context, _ string, _, _ interface{},
+		_ *grpc.ClientConn, _ ...grpc.CallOption) error {
+		deadline, _ = ctx.Deadline()
+		return nil
+	}
+
+	TimeoutInterceptor(time.Second)(context.Background(), "", nil, nil, nil, invoker)
+	if left := time.Until(deadline); left <= 0 || left > time.Second {
+		t.Errorf("expected a one second deadline, got %s", left)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	TimeoutInterceptor(time.Second)(ctx, "", nil, nil, nil, invoker)
+	if left := time.Until(deadline); left > 10*time.Millisecond {
+		t.Errorf("expected the earlier deadline to be kept, got %s", left)
+	}
+}
@@ -1,20 +1,165 @@
 package connections
 
 import (
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"fmt"
 	"log"
+	"os"
 	"travel/config"
 	pb "travel/genproto/users"
+	"travel/pkg/fakeusers"
+	"travel/pkg/metrics"
 
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
 	"google.golang.org/grpc/credentials/insecure"
 )
 
-func NewUserClient() pb.UsersClient {
-	conn, err := grpc.NewClient(config.Load().USER_SERVICE_PORT,
-		grpc.WithTransportCredentials(insecure.NewCredentials()))
+// idempotentUserMethods are the users service calls that are safe to retry.
+var idempotentUserMethods = []string{
+	"GetProfile", "GetUsers", "GetUserStatistic", "GetFollowers",
+	"ValidateUser", "GetAuthorInfo",
+}
+
+// UserClient is the users service client shared by all the content services.
+type UserClient struct {
+	pb.UsersClient
+	close func()
+}
+
+// Close closes the connection to the users service.
+func (c *UserClient) Close() {
+	c.close()
+}
+
+// NewUserClient connects to the users service, or serves a fake one
+// in-process when USER_SERVICE_FAKE is set. Every call is bounded by
+// USER_SERVICE_TIMEOUT and goes through a circuit breaker; the idempotent ones
+// are retried when the service is unavailable.
+func NewUserClient(cfg *config.Config) (*UserClient, error) {
+	opts, err := userDialOptions(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	if cfg.USER_SERVICE_FAKE {
+		return newFakeUserClient(cfg.USER_SERVICE_FAKE_SEED, opts)
+	}
+
+	creds := insecure.NewCredentials()
+	if cfg.USER_SERVICE_TLS {
+		creds, err = tlsCredentials(cfg.USER_SERVICE_CA_FILE)
+		if err != nil {
+			return nil, err
+		}
+	}
+	conn, err := grpc.NewClient(cfg.USER_SERVICE_PORT,
+		append(opts, grpc.WithTransportCredentials(creds))...)
 	if err != nil {
-		log.Fatal(err)
+		return nil, fmt.Errorf("error with connecting to users service: %s", err)
+	}
+
+	return &UserClient{
+		UsersClient: pb.NewUsersClient(conn),
+		close:       func() { conn.Close() },
+	}, nil
+}
+
+func userDialOptions(cfg *config.Config) ([]grpc.DialOption, error) {
+	serviceConfig, err := retryServiceConfig(cfg.USER_SERVICE_RETRIES)
+	if err != nil {
+		return nil, err
+	}
+	breaker := NewBreaker(cfg.USER_SERVICE_BREAKER_FAILURES,
+		cfg.USER_SERVICE_BREAKER_COOLDOWN)
+
+	return []grpc.DialOption{
+		grpc.WithDefaultServiceConfig(serviceConfig),
+		grpc.WithStatsHandler(otelgrpc.NewClientHandler()),
+		grpc.WithChainUnaryInterceptor(
+			TimeoutInterceptor(cfg.USER_SERVICE_TIMEOUT),
+			breaker.UnaryClientInterceptor(),
+			metrics.UnaryClientInterceptor()),
+	}, nil
+}
+
+// retryServiceConfig returns the service config retrying the idempotent users
+// calls up to attempts times in total.
+func retryServiceConfig(attempts int) (string, error) {
+	type name struct {
+		Service string `json:"service"`
+		Method  string `json:"method"`
+	}
+	type retryPolicy struct {
+		MaxAttempts          int      `json:"maxAttempts"`
+		InitialBackoff       string   `json:"initialBackoff"`
+		MaxBackoff           string   `json:"maxBackoff"`
+		BackoffMultiplier    float64  `json:"backoffMultiplier"`
+		RetryableStatusCodes []string `json:"retryableStatusCodes"`
+	}
+	type methodConfig struct {
+		Name        []name       `json:"name"`
+		RetryPolicy *retryPolicy `json:"retryPolicy,omitempty"`
+	}
+
+	method := methodConfig{}
+	for _, m := range idempotentUserMethods {
+		method.Name = append(method.Name, name{Service: "users.Users", Method: m})
+	}
+	// gRPC needs at least two attempts for a retry policy.
+	if attempts > 1 {
+		method.RetryPolicy = &retryPolicy{
+			MaxAttempts:          attempts,
+			InitialBackoff:       "0.1s",
+			MaxBackoff:           "1s",
+			BackoffMultiplier:    2,
+			RetryableStatusCodes: []string{"UNAVAILABLE"},
+		}
 	}
 
-	return pb.NewUsersClient(conn)
-}
\ No newline at end of file
+	res, err := json.Marshal(map[string]interface{}{
+		"methodConfig": []methodConfig{method},
+	})
+	return string(res), err
+}
+
+// tlsCredentials trusts the certificates in caFile, or the system ones when it
+// is empty.
+func tlsCredentials(caFile string) (credentials.TransportCredentials, error) {
+	tlsConfig := &tls.Config{MinVersion: tls.VersionTLS12}
+	if caFile != "" {
+		pem, err := os.ReadFile(caFile)
+		if err != nil {
+			return nil, fmt.Errorf("error with reading users service CA: %s", err)
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("no certificates found in %s", caFile)
+		}
+		tlsConfig.RootCAs = pool
+	}
+	return credentials.NewTLS(tlsConfig), nil
+}
+
+// newFakeUserClient serves the users service in-process, seeded from the JSON
+// file at seed when it is set.
+func newFakeUserClient(seed string, opts []grpc.DialOption) (*UserClient, error) {
+	server := fakeusers.NewServer()
+	if seed != "" {
+		var err error
+		server, err = fakeusers.NewServerFromFile(seed)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	client, stop, err := fakeusers.Listen(server, opts...)
+	if err != nil {
+		return nil, err
+	}
+	log.Printf("using in-process fake users service")
+	return &UserClient{UsersClient: client, close: stop}, nil
+}
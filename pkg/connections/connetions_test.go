@@ -0,0 +1,181 @@
+package connections
+
+import (
+	"context"
+	"net"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+	"travel/config"
+	pb "travel/genproto/users"
+	"travel/pkg/fakeusers"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+// flakyUsers fails the first calls with Unavailable.
+type flakyUsers struct {
+	*fakeusers.Server
+	mu       sync.Mutex
+	failures int
+	calls    map[string]int
+	delay    time.Duration
+}
+
+func (f *flakyUsers) fail(method string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.calls[method]++
+	if f.failures > 0 {
+		f.failures--
+		return status.Error(codes.Unavailable, "users service is down")
+	}
+	return nil
+}
+
+func (f *flakyUsers) GetProfile(ctx context.Context, in *pb.RequestGetProfile) (
+	*pb.ResponseGetProfile, error) {
+	if err := f.fail("GetProfile"); err != nil {
+		return nil, err
+	}
+	select {
+	case <-time.After(f.delay):
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+	return f.Server.GetProfile(ctx, in)
+}
+
+func (f *flakyUsers) EditProfile(ctx context.Context, in *pb.RequestEditProfile) (
+	*pb.ResponseEditProfile, error) {
+	if err := f.fail("EditProfile"); err != nil {
+		return nil, err
+	}
+	return f.Server.EditProfile(ctx, in)
+}
+
+func newTestClient(t *testing.T, users *flakyUsers, cfg *config.Config) pb.UsersClient {
+	t.Helper()
+	lis := bufconn.Listen(1024 * 1024)
+	server := grpc.NewServer()
+	pb.RegisterUsersServer(server, users)
+	go server.Serve(lis)
+	t.Cleanup(server.Stop)
+
+	opts, err := userDialOptions(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	conn, err := grpc.NewClient("passthrough:///bufnet", append(opts,
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return lis.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	return pb.NewUsersClient(conn)
+}
+
+func testConfig() *config.Config {
+	return &config.Config{
+		USER_SERVICE_TIMEOUT:          time.Second,
+		USER_SERVICE_RETRIES:          3,
+		USER_SERVICE_BREAKER_FAILURES: 5,
+		USER_SERVICE_BREAKER_COOLDOWN: time.Minute,
+	}
+}
+
+func TestRetryIdempotentCalls(t *testing.T) {
+	users := &flakyUsers{
+		Server:   fakeusers.NewServer(&pb.ResponseGetProfile{Id: "user-1"}),
+		failures: 2,
+		calls:    map[string]int{},
+	}
+	client := newTestClient(t, users, testConfig())
+
+	res, err := client.GetProfile(context.Background(), &pb.RequestGetProfile{Id: "user-1"})
+	if err != nil || res.Id != "user-1" {
+		t.Fatalf("expected the call to succeed after retries, got %v, %v", res, err)
+	}
+	if users.calls["GetProfile"] != 3 {
+		t.Errorf("expected 3 attempts, got %d", users.calls["GetProfile"])
+	}
+
+	users.failures = 1
+	_, err = client.EditProfile(context.Background(), &pb.RequestEditProfile{Id: "user-1"})
+	if status.Code(err) != codes.Unavailable || users.calls["EditProfile"] != 1 {
+		t.Errorf("expected EditProfile not to be retried, got %v after %d calls",
+			err, users.calls["EditProfile"])
+	}
+}
+
+func TestCallTimeout(t *testing.T) {
+	users := &flakyUsers{
+		Server: fakeusers.NewServer(&pb.ResponseGetProfile{Id: "user-1"}),
+		calls:  map[string]int{},
+		delay:  time.Second,
+	}
+	cfg := testConfig()
+	cfg.USER_SERVICE_TIMEOUT = 50 * time.Millisecond
+	client := newTestClient(t, users, cfg)
+
+	_, err := client.GetProfile(context.Background(), &pb.RequestGetProfile{Id: "user-1"})
+	if status.Code(err) != codes.DeadlineExceeded {
+		t.Errorf("expected the call to time out, got %v", err)
+	}
+}
+
+func TestBreakerOpens(t *testing.T) {
+	users := &flakyUsers{
+		Server:   fakeusers.NewServer(),
+		failures: 100,
+		calls:    map[string]int{},
+	}
+	cfg := testConfig()
+	cfg.USER_SERVICE_RETRIES = 1
+	cfg.USER_SERVICE_BREAKER_FAILURES = 2
+	client := newTestClient(t, users, cfg)
+
+	for n := 0; n < 4; n++ {
+		_, err := client.GetProfile(context.Background(), &pb.RequestGetProfile{Id: "user-1"})
+		if status.Code(err) != codes.Unavailable {
+			t.Fatalf("expected Unavailable, got %v", err)
+		}
+		if n >= 2 && !strings.Contains(err.Error(), "circuit breaker is open") {
+			t.Errorf("expected the breaker to fail call %d fast, got %v", n, err)
+		}
+	}
+	if users.calls["GetProfile"] != 2 {
+		t.Errorf("expected the service to be called twice, got %d", users.calls["GetProfile"])
+	}
+}
+
+func TestNewFakeUserClient(t *testing.T) {
+	cfg := testConfig()
+	cfg.USER_SERVICE_FAKE = true
+	client, err := NewUserClient(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer client.Close()
+
+	if _, err := client.GetUsers(context.Background(), &pb.RequestGetUsers{}); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestTLSCredentials(t *testing.T) {
+	if _, err := tlsCredentials(""); err != nil {
+		t.Error(err)
+	}
+	if _, err := tlsCredentials("missing.pem"); err == nil {
+		t.Error("expected a missing CA file to fail")
+	}
+}
@@ -0,0 +1,304 @@
+// Package fakeusers is an in-process stand-in for the users service. It serves
+// the Users RPCs from an in-memory user table over a bufconn listener, so the
+// content service can run locally and in tests without the real service.
+package fakeusers
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"net"
+	"os"
+	"sync"
+	"time"
+	pb "travel/genproto/users"
+
+	"github.com/google/uuid"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/test/bufconn"
+	"google.golang.org/protobuf/proto"
+)
+
+const bufSize = 1024 * 1024
+
+type Server struct {
+	pb.UnimplementedUsersServer
+	mu        sync.Mutex
+	users     map[string]*pb.ResponseGetProfile
+	order     []string
+	passwords map[string]string
+	followers map[string][]string
+}
+
+func NewServer(users ...*pb.ResponseGetProfile) *Server {
+	s := &Server{
+		users:     map[string]*pb.ResponseGetProfile{},
+		passwords: map[string]string{},
+		followers: map[string][]string{},
+	}
+	s.Seed(users...)
+	return s
+}
+
+// NewServerFromFile seeds the server from a JSON array of users, using the
+// field names of responseGetProfile.
+func NewServerFromFile(path string) (*Server, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	users := []*pb.ResponseGetProfile{}
+	if err := json.Unmarshal(data, &users); err != nil {
+		return nil, err
+	}
+	return NewServer(users...), nil
+}
+
+// Seed adds users to the table. Users without an id get a generated one.
+func (s *Server) Seed(users ...*pb.ResponseGetProfile) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	now := time.Now().String()
+	for _, u := range users {
+		if u.Id == "" {
+			u.Id = uuid.NewString()
+		}
+		if u.CreatedAt == "" {
+			u.CreatedAt = now
+		}
+		if _, ok := s.users[u.Id]; !ok {
+			s.order = append(s.order, u.Id)
+		}
+		s.users[u.Id] = u
+	}
+}
+
+// Listen serves s on an in-memory listener and returns a client connected to
+// it, dialed with opts. The returned function stops the server.
+func Listen(s *Server, opts ...grpc.DialOption) (pb.UsersClient, func(), error) {
+	lis := bufconn.Listen(bufSize)
+	server := grpc.NewServer()
+	pb.RegisterUsersServer(server, s)
+	go server.Serve(lis)
+
+	opts = append(opts,
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return lis.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	conn, err := grpc.NewClient("passthrough:///bufnet", opts...)
+	if err != nil {
+		server.Stop()
+		return nil, nil, err
+	}
+
+	stop := func() {
+		conn.Close()
+		server.Stop()
+	}
+	return pb.NewUsersClient(conn), stop, nil
+}
+
+// user returns the user with id. Missing users fail the same way the real
+// service does, with the database error as an Unknown status.
+func (s *Server) user(id string) (*pb.ResponseGetProfile, error) {
+	u, ok := s.users[id]
+	if !ok {
+		return nil, sql.ErrNoRows
+	}
+	return u, nil
+}
+
+func (s *Server) GetProfile(ctx context.Context, in *pb.RequestGetProfile) (
+	*pb.ResponseGetProfile, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	u, err := s.user(in.Id)
+	if err != nil {
+		return nil, err
+	}
+	return proto.Clone(u).(*pb.ResponseGetProfile), nil
+}
+
+func (s *Server) EditProfile(ctx context.Context, in *pb.RequestEditProfile) (
+	*pb.ResponseEditProfile, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	u, err := s.user(in.Id)
+	if err != nil {
+		return nil, err
+	}
+	u.FullName = in.FullName
+	u.Bio = in.Bio
+	u.CountriesVisited = in.CountriesVisited
+	u.UpdatedAt = time.Now().String()
+	return &pb.ResponseEditProfile{
+		Id:               u.Id,
+		Username:         u.Username,
+		Email:            u.Email,
+		FullName:         u.FullName,
+		Bio:              u.Bio,
+		CountriesVisited: u.CountriesVisited,
+		UpdatedAt:        u.UpdatedAt,
+	}, nil
+}
+
+func (s *Server) GetUsers(ctx context.Context, in *pb.RequestGetUsers) (
+	*pb.ResponseGetUsers, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	resp := pb.ResponseGetUsers{
+		Total: int64(len(s.order)),
+		Page:  in.Page,
+		Limit: in.Limit,
+	}
+	for _, id := range page(s.order, in.Page, in.Limit) {
+		u := s.users[id]
+		resp.Users = append(resp.Users, &pb.User{
+			Id:               u.Id,
+			Username:         u.Username,
+			FullName:         u.FullName,
+			CountriesVisited: u.CountriesVisited,
+		})
+	}
+	return &resp, nil
+}
+
+func (s *Server) DeleteUser(ctx context.Context, in *pb.RequestDeleteUser) (
+	*pb.ResponseDeleteUser, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, err := s.user(in.Id); err != nil {
+		return nil, err
+	}
+	delete(s.users, in.Id)
+	s.order = remove(s.order, in.Id)
+	delete(s.followers, in.Id)
+	for id, followers := range s.followers {
+		s.followers[id] = remove(followers, in.Id)
+	}
+	return &pb.ResponseDeleteUser{Message: "User was deleted successfully"}, nil
+}
+
+func (s *Server) UpdatePassword(ctx context.Context, in *pb.RequestUpdatePassword) (
+	*pb.ResponseUpdatePassword, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, u := range s.users {
+		if u.Email == in.Email {
+			s.passwords[u.Id] = in.NewPassword
+			return &pb.ResponseUpdatePassword{
+				Message: "Password was updated successfully",
+			}, nil
+		}
+	}
+	return nil, sql.ErrNoRows
+}
+
+func (s *Server) GetUserStatistic(ctx context.Context, in *pb.RequestGetUserStatistic) (
+	*pb.ResponseGetUserStatistic, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	u, err := s.user(in.Id)
+	if err != nil {
+		return nil, err
+	}
+	return &pb.ResponseGetUserStatistic{
+		UserId:           u.Id,
+		CountriesVisited: u.CountriesVisited,
+		LastActive:       u.UpdatedAt,
+	}, nil
+}
+
+func (s *Server) Follow(ctx context.Context, in *pb.RequestFollow) (
+	*pb.ResponseFollow, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, err := s.user(in.FollowerId); err != nil {
+		return nil, err
+	}
+	if _, err := s.user(in.FollowingId); err != nil {
+		return nil, err
+	}
+	followers := remove(s.followers[in.FollowingId], in.FollowerId)
+	s.followers[in.FollowingId] = append(followers, in.FollowerId)
+	return &pb.ResponseFollow{
+		FollowerId:  in.FollowerId,
+		FollowingId: in.FollowingId,
+		FollowedAt:  time.Now().String(),
+	}, nil
+}
+
+func (s *Server) GetFollowers(ctx context.Context, in *pb.RequestGetFollowers) (
+	*pb.ResponseGetFollowers, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, err := s.user(in.UserId); err != nil {
+		return nil, err
+	}
+
+	ids := s.followers[in.UserId]
+	resp := pb.ResponseGetFollowers{
+		Total: int64(len(ids)),
+		Page:  in.Page,
+		Limit: in.Limit,
+	}
+	for _, id := range page(ids, in.Page, in.Limit) {
+		u := s.users[id]
+		resp.Followers = append(resp.Followers, &pb.Follower{
+			Id:       u.Id,
+			Username: u.Username,
+			FullName: u.FullName,
+		})
+	}
+	return &resp, nil
+}
+
+func (s *Server) ValidateUser(ctx context.Context, in *pb.RequestGetProfile) (
+	*pb.Status, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	_, ok := s.users[in.Id]
+	return &pb.Status{Success: ok}, nil
+}
+
+func (s *Server) GetAuthorInfo(ctx context.Context, in *pb.RequestGetAuthorInfo) (
+	*pb.ResponseGetAuthorInfo, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	u, err := s.user(in.Id)
+	if err != nil {
+		return nil, err
+	}
+	return &pb.ResponseGetAuthorInfo{
+		Id:       u.Id,
+		Username: u.Username,
+		FullName: u.FullName,
+	}, nil
+}
+
+func page(ids []string, page, limit int32) []string {
+	if limit <= 0 {
+		return nil
+	}
+	start := int(page * limit)
+	if start >= len(ids) {
+		return nil
+	}
+	end := start + int(limit)
+	if end > len(ids) {
+		end = len(ids)
+	}
+	return ids[start:end]
+}
+
+func remove(ids []string, id string) []string {
+	res := []string{}
+	for _, val := range ids {
+		if val != id {
+			res = append(res, val)
+		}
+	}
+	return res
+}
@@ -0,0 +1,80 @@
+package fakeusers
+
+import (
+	"context"
+	"testing"
+	pb "travel/genproto/users"
+)
+
+func NewClient(t *testing.T, users ...*pb.ResponseGetProfile) pb.UsersClient {
+	client, stop, err := Listen(NewServer(users...))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(stop)
+	return client
+}
+
+func TestValidateUser(t *testing.T) {
+	client := NewClient(t, &pb.ResponseGetProfile{Id: "1", Username: "saidakbar"})
+	ctx := context.Background()
+
+	res, err := client.ValidateUser(ctx, &pb.RequestGetProfile{Id: "1"})
+	if err != nil || !res.Success {
+		t.Errorf("expected user to be valid, got %v %v", res, err)
+	}
+	res, err = client.ValidateUser(ctx, &pb.RequestGetProfile{Id: "2"})
+	if err != nil || res.Success {
+		t.Errorf("expected unknown user to be invalid, got %v %v", res, err)
+	}
+}
+
+func TestGetAuthorInfo(t *testing.T) {
+	client := NewClient(t, &pb.ResponseGetProfile{
+		Id:       "1",
+		Username: "saidakbar",
+		FullName: "Saidakbar Pardaboyev",
+	})
+	ctx := context.Background()
+
+	res, err := client.GetAuthorInfo(ctx, &pb.RequestGetAuthorInfo{Id: "1"})
+	if err != nil || res.FullName != "Saidakbar Pardaboyev" {
+		t.Errorf("unexpected author: %v %v", res, err)
+	}
+
+	_, err = client.GetAuthorInfo(ctx, &pb.RequestGetAuthorInfo{Id: "2"})
+	if err == nil || err.Error() != "rpc error: code = Unknown desc = sql: no rows in result set" {
+		t.Errorf("expected the users service not found error, got %v", err)
+	}
+}
+
+func TestGetFollowers(t *testing.T) {
+	client := NewClient(t,
+		&pb.ResponseGetProfile{Id: "1", Username: "one"},
+		&pb.ResponseGetProfile{Id: "2", Username: "two"},
+		&pb.ResponseGetProfile{Id: "3", Username: "three"})
+	ctx := context.Background()
+
+	for _, id := range []string{"2", "3"} {
+		_, err := client.Follow(ctx, &pb.RequestFollow{FollowerId: id, FollowingId: "1"})
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	res, err := client.GetFollowers(ctx, &pb.RequestGetFollowers{UserId: "1", Limit: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.Total != 2 || len(res.Followers) != 1 || res.Followers[0].Username != "two" {
+		t.Errorf("unexpected followers: %v", res)
+	}
+
+	if _, err := client.DeleteUser(ctx, &pb.RequestDeleteUser{Id: "2"}); err != nil {
+		t.Fatal(err)
+	}
+	res, err = client.GetFollowers(ctx, &pb.RequestGetFollowers{UserId: "1", Limit: 10})
+	if err != nil || res.Total != 1 {
+		t.Errorf("expected deleted user to be unfollowed, got %v %v", res, err)
+	}
+}
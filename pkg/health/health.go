@@ -0,0 +1,124 @@
+// Package health reports the readiness of the service through the standard
+// grpc.health.v1 service. A Checker runs the dependency checks periodically
+// and keeps the serving status of the health server up to date.
+package health
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"sort"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+const (
+	DefaultInterval = 5 * time.Second
+	checkTimeout    = 2 * time.Second
+)
+
+// Check returns an error when a dependency is not ready.
+type Check func(ctx context.Context) error
+
+// Checker sets the status of every check under its own name, and the status of
+// the whole server ("") and of Services to SERVING only when the required
+// checks pass. The other checks, e.g. of a cache the service can run without,
+// only report under their own name.
+type Checker struct {
+	Logger   *slog.Logger
+	Server   *health.Server
+	Services []string
+	Interval time.Duration
+
+	mu       sync.Mutex
+	checks   map[string]Check
+	required map[string]bool
+}
+
+func NewChecker(logger *slog.Logger, checks map[string]Check,
+	required ...string) *Checker {
+	c := &Checker{
+		Logger:   logger,
+		Server:   health.NewServer(),
+		Interval: DefaultInterval,
+		checks:   checks,
+		required: map[string]bool{},
+	}
+	for _, name := range required {
+		c.required[name] = true
+	}
+	c.Server.SetServingStatus("", healthpb.HealthCheckResponse_NOT_SERVING)
+	return c
+}
+
+// Register adds the health service to server and reports the status of all
+// services registered on it so far.
+func (c *Checker) Register(server *grpc.Server) {
+	for name := range server.GetServiceInfo() {
+		c.Services = append(c.Services, name)
+	}
+	sort.Strings(c.Services)
+	healthpb.RegisterHealthServer(server, c.Server)
+}
+
+// Run checks the dependencies every Interval until ctx is done.
+func (c *Checker) Run(ctx context.Context) {
+	ticker := time.NewTicker(c.Interval)
+	defer ticker.Stop()
+	for {
+		c.Check(ctx)
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Check runs all checks once, updates the serving statuses and reports whether
+// the required checks passed.
+func (c *Checker) Check(ctx context.Context) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	ready := true
+	for name, check := range c.checks {
+		status := healthpb.HealthCheckResponse_SERVING
+		if err := c.run(ctx, check); err != nil {
+			c.Logger.Error(fmt.Sprintf("%s is not ready: %s", name, err))
+			status = healthpb.HealthCheckResponse_NOT_SERVING
+			if c.required[name] {
+				ready = false
+			}
+		}
+		c.Server.SetServingStatus(name, status)
+	}
+
+	status := healthpb.HealthCheckResponse_NOT_SERVING
+	if ready {
+		status = healthpb.HealthCheckResponse_SERVING
+	}
+	c.Server.SetServingStatus("", status)
+	for _, name := range c.Services {
+		c.Server.SetServingStatus(name, status)
+	}
+	return ready
+}
+
+func (c *Checker) run(ctx context.Context, check Check) error {
+	ctx, cancel := context.WithTimeout(ctx, checkTimeout)
+	defer cancel()
+	return check(ctx)
+}
+
+// Shutdown reports every service as NOT_SERVING for good, so load balancers
+// stop sending new requests while the server drains.
+func (c *Checker) Shutdown() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.Server.Shutdown()
+}
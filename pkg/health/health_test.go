@@ -0,0 +1,98 @@
+package health
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"testing"
+
+	"google.golang.org/grpc"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+func NewTestChecker(postgresErr, redisErr *error) *Checker {
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	c := NewChecker(logger, map[string]Check{
+		"postgres": func(ctx context.Context) error { return *postgresErr },
+		"redis":    func(ctx context.Context) error { return *redisErr },
+	}, "postgres")
+	c.Services = []string{"stories.Stories"}
+	return c
+}
+
+func status(t *testing.T, c *Checker, service string) healthpb.HealthCheckResponse_ServingStatus {
+	t.Helper()
+	res, err := c.Server.Check(context.Background(),
+		&healthpb.HealthCheckRequest{Service: service})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return res.Status
+}
+
+func TestCheck(t *testing.T) {
+	var postgresErr, redisErr error
+	c := NewTestChecker(&postgresErr, &redisErr)
+	if status(t, c, "") != healthpb.HealthCheckResponse_NOT_SERVING {
+		t.Error("expected server to be not serving before the first check")
+	}
+
+	if !c.Check(context.Background()) {
+		t.Error("expected all checks to pass")
+	}
+	for _, name := range []string{"", "postgres", "redis", "stories.Stories"} {
+		if s := status(t, c, name); s != healthpb.HealthCheckResponse_SERVING {
+			t.Errorf("expected %q to be serving, got %s", name, s)
+		}
+	}
+
+	redisErr = errors.New("connection refused")
+	if !c.Check(context.Background()) {
+		t.Error("expected a redis failure to keep the server ready")
+	}
+	if s := status(t, c, "redis"); s != healthpb.HealthCheckResponse_NOT_SERVING {
+		t.Errorf("expected redis to be not serving, got %s", s)
+	}
+	for _, name := range []string{"", "postgres", "stories.Stories"} {
+		if s := status(t, c, name); s != healthpb.HealthCheckResponse_SERVING {
+			t.Errorf("expected %q to be serving, got %s", name, s)
+		}
+	}
+
+	postgresErr = errors.New("connection refused")
+	if c.Check(context.Background()) {
+		t.Error("expected postgres check to fail")
+	}
+	for _, name := range []string{"", "postgres", "stories.Stories"} {
+		if s := status(t, c, name); s != healthpb.HealthCheckResponse_NOT_SERVING {
+			t.Errorf("expected %q to be not serving, got %s", name, s)
+		}
+	}
+}
+
+func TestShutdown(t *testing.T) {
+	var postgresErr, redisErr error
+	c := NewTestChecker(&postgresErr, &redisErr)
+	c.Shutdown()
+	c.Check(context.Background())
+	if s := status(t, c, ""); s != healthpb.HealthCheckResponse_NOT_SERVING {
+		t.Errorf("expected server to stay not serving after shutdown, got %s", s)
+	}
+}
+
+func TestRegister(t *testing.T) {
+	var postgresErr, redisErr error
+	c := NewTestChecker(&postgresErr, &redisErr)
+	c.Services = nil
+	server := grpc.NewServer()
+	server.RegisterService(&grpc.ServiceDesc{
+		ServiceName: "stories.Stories",
+		HandlerType: (*interface{})(nil),
+	}, struct{}{})
+	c.Register(server)
+
+	if len(c.Services) != 1 || c.Services[0] != "stories.Stories" {
+		t.Errorf("unexpected services: %v", c.Services)
+	}
+}
@@ -0,0 +1,86 @@
+package itinerary
+
+import (
+	"bytes"
+	"fmt"
+	"html/template"
+	"strings"
+	pb "travel/genproto/itineraries"
+)
+
+var markdownEscaper = strings.NewReplacer(`\`, `\\`, "`", "\\`", "*", `\*`,
+	"_", `\_`, "#", `\#`, "[", `\[`, "]", `\]`, "<", `\<`, ">", `\>`)
+
+// Markdown renders the itinerary as a printable Markdown document: its
+// description, then a section per destination listing the activities.
+func Markdown(it *pb.ResponseGetItineraryFullInfo) ([]byte, error) {
+	b := bytes.Buffer{}
+	fmt.Fprintf(&b, "# %s\n\n", markdownEscaper.Replace(it.Title))
+	fmt.Fprintf(&b, "**%s – %s**", formatDate(it.StartDate), formatDate(it.EndDate))
+	if it.Author != nil && it.Author.Username != "" {
+		fmt.Fprintf(&b, " · by %s", markdownEscaper.Replace(it.Author.Username))
+	}
+	b.WriteString("\n\n")
+	if it.Description != "" {
+		fmt.Fprintf(&b, "%s\n\n", markdownEscaper.Replace(it.Description))
+	}
+
+	for _, des := range it.Destinations {
+		if _, err := days(des); err != nil {
+			return nil, err
+		}
+		fmt.Fprintf(&b, "## %s\n\n", markdownEscaper.Replace(des.Name))
+		fmt.Fprintf(&b, "%s – %s\n\n", formatDate(des.StartDate), formatDate(des.EndDate))
+		for _, act := range des.Activities {
+			fmt.Fprintf(&b, "- %s\n", markdownEscaper.Replace(act.Activity))
+		}
+		if len(des.Activities) > 0 {
+			b.WriteString("\n")
+		}
+	}
+	return b.Bytes(), nil
+}
+
+var htmlTemplate = template.Must(template.New("itinerary").Funcs(template.FuncMap{
+	"date": formatDate,
+}).Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<title>{{.Title}}</title>
+<style>
+body { font-family: Georgia, serif; max-width: 40em; margin: 2em auto; color: #222; }
+h2 { margin-top: 1.5em; border-bottom: 1px solid #ccc; }
+.dates { color: #555; }
+@media print { body { margin: 0; } section { break-inside: avoid; } }
+</style>
+</head>
+<body>
+<h1>{{.Title}}</h1>
+<p class="dates">{{date .StartDate}} – {{date .EndDate}}{{with .Author}}{{if .Username}} · by {{.Username}}{{end}}{{end}}</p>
+{{with .Description}}<p>{{.}}</p>
+{{end}}{{range .Destinations}}<section>
+<h2>{{.Name}}</h2>
+<p class="dates">{{date .StartDate}} – {{date .EndDate}}</p>
+{{with .Activities}}<ul>
+{{range .}}<li>{{.Activity}}</li>
+{{end}}</ul>
+{{end}}</section>
+{{end}}</body>
+</html>
+`))
+
+// HTML renders the itinerary as a printable web page, laid out like Markdown.
+func HTML(it *pb.ResponseGetItineraryFullInfo) ([]byte, error) {
+	for _, des := range it.Destinations {
+		if _, err := days(des); err != nil {
+			return nil, err
+		}
+	}
+
+	b := bytes.Buffer{}
+	if err := htmlTemplate.Execute(&b, it); err != nil {
+		return nil, err
+	}
+	return b.Bytes(), nil
+}
@@ -0,0 +1,68 @@
+package itinerary
+
+import (
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"strings"
+	pb "travel/genproto/itineraries"
+)
+
+// ErrNoCoordinates is returned by GPX for itineraries none of whose
+// destinations has coordinates.
+var ErrNoCoordinates = errors.New("itinerary has no destinations with coordinates")
+
+type gpxDocument struct {
+	XMLName   xml.Name      `xml:"gpx"`
+	Xmlns     string        `xml:"xmlns,attr"`
+	Version   string        `xml:"version,attr"`
+	Creator   string        `xml:"creator,attr"`
+	Name      string        `xml:"metadata>name"`
+	Waypoints []gpxWaypoint `xml:"wpt"`
+}
+
+type gpxWaypoint struct {
+	Lat  float64 `xml:"lat,attr"`
+	Lon  float64 `xml:"lon,attr"`
+	Name string  `xml:"name"`
+	Desc string  `xml:"desc,omitempty"`
+}
+
+// GPX renders the destinations with coordinates as GPX waypoints, the others
+// are left out.
+func GPX(it *pb.ResponseGetItineraryFullInfo) ([]byte, error) {
+	doc := gpxDocument{
+		Xmlns:   "http://www.topografix.com/GPX/1/1",
+		Version: "1.1",
+		Creator: "TravelTales",
+		Name:    it.Title,
+	}
+	for _, des := range it.Destinations {
+		if des.Latitude == nil || des.Longitude == nil {
+			continue
+		}
+		activities := []string{}
+		for _, act := range des.Activities {
+			activities = append(activities, act.Activity)
+		}
+		desc := fmt.Sprintf("%s – %s", formatDate(des.StartDate), formatDate(des.EndDate))
+		if len(activities) > 0 {
+			desc += ": " + strings.Join(activities, ", ")
+		}
+		doc.Waypoints = append(doc.Waypoints, gpxWaypoint{
+			Lat:  *des.Latitude,
+			Lon:  *des.Longitude,
+			Name: des.Name,
+			Desc: desc,
+		})
+	}
+	if len(doc.Waypoints) == 0 {
+		return nil, ErrNoCoordinates
+	}
+
+	data, err := xml.MarshalIndent(doc, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append([]byte(xml.Header), append(data, '\n')...), nil
+}
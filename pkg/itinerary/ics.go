@@ -0,0 +1,87 @@
+package itinerary
+
+import (
+	"fmt"
+	"strings"
+	"time"
+	pb "travel/genproto/itineraries"
+	"unicode/utf8"
+)
+
+const (
+	icsDate      = "20060102"
+	icsTimestamp = "20060102T150405Z"
+	// the longest line in octets, longer ones are folded
+	icsLineLength = 75
+)
+
+var icsEscaper = strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\n", `\n`)
+
+// ICS renders the itinerary as an iCalendar with an all-day event for every
+// day of every destination. The summary of an event is the destination and its
+// description the activities, one per line. now is the time stamp of the
+// events.
+func ICS(it *pb.ResponseGetItineraryFullInfo, now time.Time) ([]byte, error) {
+	b := icsBuilder{}
+	b.line("BEGIN:VCALENDAR")
+	b.line("VERSION:2.0")
+	b.line("PRODID:-//TravelTales//Itineraries//EN")
+	b.line("CALSCALE:GREGORIAN")
+	b.line("X-WR-CALNAME:" + icsEscaper.Replace(it.Title))
+
+	for n, des := range it.Destinations {
+		days, err := days(des)
+		if err != nil {
+			return nil, err
+		}
+		id := des.Id
+		if id == "" {
+			id = fmt.Sprintf("%s-%d", it.Id, n)
+		}
+		activities := []string{}
+		for _, act := range des.Activities {
+			activities = append(activities, act.Activity)
+		}
+
+		for _, day := range days {
+			b.line("BEGIN:VEVENT")
+			b.line(fmt.Sprintf("UID:%s-%s@traveltales", id, day.Format(icsDate)))
+			b.line("DTSTAMP:" + now.UTC().Format(icsTimestamp))
+			b.line("DTSTART;VALUE=DATE:" + day.Format(icsDate))
+			b.line("DTEND;VALUE=DATE:" + day.AddDate(0, 0, 1).Format(icsDate))
+			b.line("SUMMARY:" + icsEscaper.Replace(des.Name))
+			b.line("LOCATION:" + icsEscaper.Replace(des.Name))
+			if len(activities) > 0 {
+				b.line("DESCRIPTION:" + icsEscaper.Replace(strings.Join(activities, "\n")))
+			}
+			if des.Latitude != nil && des.Longitude != nil {
+				b.line(fmt.Sprintf("GEO:%f;%f", *des.Latitude, *des.Longitude))
+			}
+			b.line("END:VEVENT")
+		}
+	}
+
+	b.line("END:VCALENDAR")
+	return []byte(b.String()), nil
+}
+
+// icsBuilder writes content lines ended by CRLF, folding the long ones.
+type icsBuilder struct {
+	strings.Builder
+}
+
+func (b *icsBuilder) line(line string) {
+	limit := icsLineLength
+	for len(line) > limit {
+		// never split a character
+		cut := limit
+		for cut > 0 && !utf8.RuneStart(line[cut]) {
+			cut--
+		}
+		b.WriteString(line[:cut] + "\r\n ")
+		line = line[cut:]
+		// the space of the continuation line counts
+		limit = icsLineLength - 1
+	}
+	b.WriteString(line + "\r\n")
+}
@@ -0,0 +1,407 @@
+package itinerary
+
+import (
+	"bytes"
+	"fmt"
+	"math"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+	pb "travel/genproto/itineraries"
+	"unicode/utf8"
+
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+const (
+	maxTitleLength = 200
+	maxNameLength  = 100
+)
+
+// Warning tells about an item of an import that was skipped or changed.
+type Warning struct {
+	Item    string
+	Message string
+}
+
+// ParseJSON reads an itinerary in the JSON form of RequestCreateItineraries.
+func ParseJSON(data []byte) (*pb.RequestCreateItineraries, error) {
+	req := pb.RequestCreateItineraries{}
+	if err := protojson.Unmarshal(data, &req); err != nil {
+		return nil, err
+	}
+	return &req, nil
+}
+
+var icsUnescaper = strings.NewReplacer(`\\`, `\`, `\;`, ";", `\,`, ",", `\n`, "\n",
+	`\N`, "\n")
+
+// icsEvent is a VEVENT with the properties an itinerary needs.
+type icsEvent struct {
+	item                    string
+	summary, location, desc string
+	start, end, geo         string
+	endIsDate, recurs       bool
+}
+
+// ParseICS reads an itinerary from an iCalendar: the calendar name is the
+// title and every event a destination, named after its location or else its
+// summary, with the lines of its description as activities. Events of
+// consecutive days at the same place, like the ones ICS writes, make one
+// destination. The itinerary dates are those of the first and last
+// destination.
+func ParseICS(data []byte) (*pb.RequestCreateItineraries, []Warning, error) {
+	// unfold the lines continued on the next one
+	text := strings.ReplaceAll(string(data), "\r\n", "\n")
+	text = strings.NewReplacer("\n ", "", "\n\t", "").Replace(text)
+
+	req := pb.RequestCreateItineraries{}
+	warnings := []Warning{}
+	events := []*icsEvent{}
+	var event *icsEvent
+	inCalendar, found := false, false
+	// the components other than events are skipped with what they contain
+	skipping := ""
+	for _, line := range strings.Split(text, "\n") {
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+		name, params, value, ok := parseContentLine(line)
+		if !ok {
+			return nil, nil, fmt.Errorf("invalid line: %.50q", line)
+		}
+
+		switch {
+		case skipping != "":
+			if name == "END" && value == skipping {
+				skipping = ""
+			}
+		case name == "BEGIN" && value == "VCALENDAR":
+			inCalendar, found = true, true
+		case !inCalendar:
+			return nil, nil, fmt.Errorf("not an iCalendar file")
+		case name == "BEGIN" && value == "VEVENT" && event == nil:
+			event = &icsEvent{item: fmt.Sprintf("event %d", len(events)+1)}
+		case name == "BEGIN":
+			skipping = value
+			if value != "VTIMEZONE" && value != "VALARM" {
+				warnings = append(warnings, Warning{value, "only events are imported"})
+			}
+		case name == "END" && value == "VEVENT" && event != nil:
+			events = append(events, event)
+			event = nil
+		case name == "END" && value == "VCALENDAR":
+			inCalendar = false
+		case event == nil && name == "X-WR-CALNAME":
+			req.Title = icsUnescaper.Replace(value)
+		case event == nil && name == "X-WR-CALDESC":
+			req.Description = icsUnescaper.Replace(value)
+		case event == nil:
+		case name == "UID":
+			event.item = "event " + value
+		case name == "SUMMARY":
+			event.summary = strings.TrimSpace(icsUnescaper.Replace(value))
+		case name == "LOCATION":
+			event.location = strings.TrimSpace(icsUnescaper.Replace(value))
+		case name == "DESCRIPTION":
+			event.desc = icsUnescaper.Replace(value)
+		case name == "DTSTART":
+			event.start = value
+		case name == "DTEND":
+			event.end = value
+			event.endIsDate = strings.Contains(params, "VALUE=DATE") &&
+				!strings.Contains(params, "VALUE=DATE-TIME")
+		case name == "GEO":
+			event.geo = value
+		case name == "RRULE" || name == "RDATE":
+			event.recurs = true
+		}
+	}
+	if !found {
+		return nil, nil, fmt.Errorf("not an iCalendar file")
+	}
+	if event != nil || inCalendar {
+		return nil, nil, fmt.Errorf("unexpected end of the calendar")
+	}
+
+	destinations := []*pb.Destination{}
+	for _, event := range events {
+		des, warns := event.destination()
+		warnings = append(warnings, warns...)
+		if des == nil {
+			continue
+		}
+		destinations = append(destinations, des)
+	}
+	slices.SortStableFunc(destinations, func(a, b *pb.Destination) int {
+		return strings.Compare(a.StartDate, b.StartDate)
+	})
+	for _, des := range destinations {
+		last := len(req.Destinations) - 1
+		if last >= 0 && consecutive(req.Destinations[last], des) {
+			merge(req.Destinations[last], des)
+			continue
+		}
+		req.Destinations = append(req.Destinations, des)
+	}
+
+	for _, des := range req.Destinations {
+		if req.StartDate == "" || des.StartDate < req.StartDate {
+			req.StartDate = des.StartDate
+		}
+		if des.EndDate > req.EndDate {
+			req.EndDate = des.EndDate
+		}
+	}
+	return &req, warnings, nil
+}
+
+// parseContentLine splits a line like DTSTART;VALUE=DATE:20240716 into its
+// name, parameters and value.
+func parseContentLine(line string) (name, params, value string, ok bool) {
+	// the parameters may quote colons
+	quoted := false
+	for n, r := range line {
+		if r == '"' {
+			quoted = !quoted
+		}
+		if r == ':' && !quoted {
+			name, params, _ = strings.Cut(line[:n], ";")
+			return strings.ToUpper(name), strings.ToUpper(params), line[n+1:], name != ""
+		}
+	}
+	return "", "", "", false
+}
+
+// destination maps the event to a destination, or returns nil with a warning
+// if it is skipped.
+func (e *icsEvent) destination() (*pb.Destination, []Warning) {
+	warnings := []Warning{}
+	des := pb.Destination{Name: e.location}
+	if des.Name == "" {
+		des.Name = e.summary
+	} else if e.summary != "" && e.summary != e.location {
+		des.Activities = append(des.Activities, e.summary)
+	}
+	if des.Name == "" {
+		return nil, []Warning{{e.item, "has no summary or location"}}
+	}
+
+	start, err := parseICSDate(e.start)
+	if err != nil {
+		return nil, []Warning{{e.item, "has no valid start date"}}
+	}
+	end := start
+	if e.end != "" {
+		end, err = parseICSDate(e.end)
+		if err != nil {
+			return nil, []Warning{{e.item, "has no valid end date"}}
+		}
+		// the end of an all-day event, or one ending at midnight, is the
+		// next day
+		if end.After(start) && (e.endIsDate || len(e.end) == len(icsDate) ||
+			strings.HasPrefix(e.end[len(icsDate):], "T000000")) {
+			end = end.AddDate(0, 0, -1)
+		}
+	}
+	if end.Before(start) {
+		return nil, []Warning{{e.item, "ends before it starts"}}
+	}
+	des.StartDate = start.Format(time.DateOnly)
+	des.EndDate = end.Format(time.DateOnly)
+
+	for _, line := range strings.Split(e.desc, "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			des.Activities = append(des.Activities, line)
+		}
+	}
+	if e.geo != "" {
+		lat, lon, ok := parseGeo(e.geo)
+		if ok {
+			des.Latitude, des.Longitude = &lat, &lon
+		} else {
+			warnings = append(warnings, Warning{e.item, "has invalid coordinates, left out"})
+		}
+	}
+	if e.recurs {
+		warnings = append(warnings, Warning{e.item, "recurs, only its first day is imported"})
+	}
+	return &des, warnings
+}
+
+// parseICSDate returns the date of a DATE or DATE-TIME value, at the time zone
+// it was written in.
+func parseICSDate(value string) (time.Time, error) {
+	if len(value) < len(icsDate) {
+		return time.Time{}, fmt.Errorf("invalid date: %s", value)
+	}
+	return time.Parse(icsDate, value[:len(icsDate)])
+}
+
+func parseGeo(value string) (float64, float64, bool) {
+	latValue, lonValue, ok := strings.Cut(value, ";")
+	if !ok {
+		return 0, 0, false
+	}
+	lat, err := strconv.ParseFloat(strings.TrimSpace(latValue), 64)
+	if err != nil {
+		return 0, 0, false
+	}
+	lon, err := strconv.ParseFloat(strings.TrimSpace(lonValue), 64)
+	if err != nil {
+		return 0, 0, false
+	}
+	return lat, lon, validCoordinates(lat, lon)
+}
+
+func validCoordinates(lat, lon float64) bool {
+	return math.Abs(lat) <= 90 && math.Abs(lon) <= 180
+}
+
+// consecutive tells if b is at the same place as a, starting by the day after
+// a ends.
+func consecutive(a, b *pb.Destination) bool {
+	if a.Name != b.Name {
+		return false
+	}
+	end, _ := time.Parse(time.DateOnly, a.EndDate)
+	return b.StartDate <= end.AddDate(0, 0, 1).Format(time.DateOnly)
+}
+
+// merge extends a with the days and activities of b.
+func merge(a, b *pb.Destination) {
+	if b.EndDate > a.EndDate {
+		a.EndDate = b.EndDate
+	}
+	for _, act := range b.Activities {
+		if !slices.Contains(a.Activities, act) {
+			a.Activities = append(a.Activities, act)
+		}
+	}
+	if a.Latitude == nil {
+		a.Latitude, a.Longitude = b.Latitude, b.Longitude
+	}
+}
+
+// Validate checks an itinerary to import and normalizes its dates to
+// YYYY-MM-DD. Destinations with invalid dates, without a name or outside the
+// dates of the itinerary are removed, and so are blank activities and invalid
+// coordinates, each with a warning. Names longer than the database allows are
+// cut. It fails if the itinerary itself is invalid.
+func Validate(req *pb.RequestCreateItineraries) ([]Warning, error) {
+	warnings := []Warning{}
+	req.Title = strings.TrimSpace(req.Title)
+	if req.Title == "" {
+		return nil, fmt.Errorf("itinerary has no title")
+	}
+	if cut, ok := truncate(req.Title, maxTitleLength); ok {
+		req.Title = cut
+		warnings = append(warnings, Warning{"itinerary", "title was cut to 200 characters"})
+	}
+
+	// the dates of the itinerary default to those of its destinations
+	first, last := "", ""
+	for _, des := range req.Destinations {
+		desStart, startErr := parseDate(des.StartDate)
+		desEnd, endErr := parseDate(des.EndDate)
+		if startErr != nil || endErr != nil || desEnd.Before(desStart) {
+			continue
+		}
+		if first == "" || desStart.Format(time.DateOnly) < first {
+			first = desStart.Format(time.DateOnly)
+		}
+		if desEnd.Format(time.DateOnly) > last {
+			last = desEnd.Format(time.DateOnly)
+		}
+	}
+	if req.StartDate == "" {
+		req.StartDate = first
+	}
+	if req.EndDate == "" {
+		req.EndDate = last
+	}
+
+	start, err := parseDate(req.StartDate)
+	if err != nil {
+		return nil, fmt.Errorf("itinerary has an invalid start date: %q", req.StartDate)
+	}
+	end, err := parseDate(req.EndDate)
+	if err != nil {
+		return nil, fmt.Errorf("itinerary has an invalid end date: %q", req.EndDate)
+	}
+	if end.Before(start) {
+		return nil, fmt.Errorf("itinerary ends before it starts")
+	}
+	req.StartDate, req.EndDate = start.Format(time.DateOnly), end.Format(time.DateOnly)
+
+	destinations := []*pb.Destination{}
+	for n, des := range req.Destinations {
+		item := fmt.Sprintf("destination %d", n+1)
+		des.Name = strings.TrimSpace(des.Name)
+		if des.Name == "" {
+			warnings = append(warnings, Warning{item, "has no name, skipped"})
+			continue
+		}
+		item += " (" + des.Name + ")"
+		if cut, ok := truncate(des.Name, maxNameLength); ok {
+			des.Name = cut
+			warnings = append(warnings, Warning{item, "name was cut to 100 characters"})
+		}
+
+		desStart, startErr := parseDate(des.StartDate)
+		desEnd, endErr := parseDate(des.EndDate)
+		switch {
+		case startErr != nil || endErr != nil:
+			warnings = append(warnings, Warning{item, "has invalid dates, skipped"})
+			continue
+		case desEnd.Before(desStart):
+			warnings = append(warnings, Warning{item, "ends before it starts, skipped"})
+			continue
+		case desStart.Before(start) || desEnd.After(end):
+			warnings = append(warnings,
+				Warning{item, "is outside the dates of the itinerary, skipped"})
+			continue
+		}
+		des.StartDate, des.EndDate = desStart.Format(time.DateOnly),
+			desEnd.Format(time.DateOnly)
+
+		activities := []string{}
+		for _, act := range des.Activities {
+			if act = strings.TrimSpace(act); act != "" {
+				activities = append(activities, act)
+			}
+		}
+		if len(activities) < len(des.Activities) {
+			warnings = append(warnings, Warning{item, "blank activities were skipped"})
+		}
+		des.Activities = activities
+
+		if (des.Latitude == nil) != (des.Longitude == nil) ||
+			(des.Latitude != nil && !validCoordinates(*des.Latitude, *des.Longitude)) {
+			des.Latitude, des.Longitude = nil, nil
+			warnings = append(warnings, Warning{item, "has invalid coordinates, left out"})
+		}
+		destinations = append(destinations, des)
+	}
+	req.Destinations = destinations
+	return warnings, nil
+}
+
+// parseDate parses a date of an imported itinerary, which must be YYYY-MM-DD.
+func parseDate(date string) (time.Time, error) {
+	return time.Parse(time.DateOnly, strings.TrimSpace(date))
+}
+
+// truncate cuts s to max characters, telling if it had to.
+func truncate(s string, max int) (string, bool) {
+	if utf8.RuneCountInString(s) <= max {
+		return s, false
+	}
+	return string([]rune(s)[:max]), true
+}
+
+// IsICS tells if data looks like an iCalendar file.
+func IsICS(data []byte) bool {
+	return bytes.HasPrefix(bytes.TrimSpace(data), []byte("BEGIN:VCALENDAR"))
+}
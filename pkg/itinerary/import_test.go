@@ -0,0 +1,193 @@
+package itinerary
+
+import (
+	"strings"
+	"testing"
+	"time"
+	pb "travel/genproto/itineraries"
+)
+
+func TestParseICSRoundTrip(t *testing.T) {
+	data, err := ICS(testItinerary(), time.Now())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	req, warnings, err := ParseICS(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(warnings) != 0 {
+		t.Errorf("expected no warnings, got %v", warnings)
+	}
+	if req.Title != "Uzbekistan, the Silk Road" || req.StartDate != "2024-07-16" ||
+		req.EndDate != "2024-07-20" {
+		t.Errorf("unexpected itinerary: %v", req)
+	}
+	if len(req.Destinations) != 2 {
+		t.Fatalf("expected the days to be merged into 2 destinations, got %v",
+			req.Destinations)
+	}
+	tashkent := req.Destinations[0]
+	if tashkent.Name != "Tashkent" || tashkent.StartDate != "2024-07-16" ||
+		tashkent.EndDate != "2024-07-17" || len(tashkent.Activities) != 2 ||
+		tashkent.Activities[1] != "Metro; all stations" || *tashkent.Latitude != 41.2995 {
+		t.Errorf("unexpected destination: %v", tashkent)
+	}
+}
+
+func TestParseICS(t *testing.T) {
+	data := strings.Join([]string{
+		"BEGIN:VCALENDAR",
+		"VERSION:2.0",
+		"BEGIN:VTIMEZONE",
+		"TZID:Asia/Tashkent",
+		"END:VTIMEZONE",
+		"BEGIN:VEVENT",
+		"UID:flight",
+		"SUMMARY:Flight to Bukhara",
+		"LOCATION:Bukhara",
+		"DTSTART;TZID=Asia/Tashkent:20240721T090000",
+		"DTEND;TZID=Asia/Tashkent:20240721T110000",
+		"DESCRIPTION:Ark fortress\\nLyabi-Hauz",
+		"GEO:39.77;64.42",
+		"END:VEVENT",
+		"BEGIN:VEVENT",
+		"UID:nowhere",
+		"DTSTART;VALUE=DATE:20240722",
+		"END:VEVENT",
+		"BEGIN:VEVENT",
+		"UID:backwards",
+		"SUMMARY:Khiva",
+		"DTSTART;VALUE=DATE:20240725",
+		"DTEND;VALUE=DATE:20240723",
+		"END:VEVENT",
+		"BEGIN:VEVENT",
+		"UID:weekly",
+		"SUMMARY:Khiva",
+		"DTSTART;VALUE=DATE:20240723",
+		"RRULE:FREQ=WEEKLY",
+		"GEO:191;0",
+		"END:VEVENT",
+		"BEGIN:VTODO",
+		"SUMMARY:Pack",
+		"END:VTODO",
+		"END:VCALENDAR",
+	}, "\r\n")
+
+	req, warnings, err := ParseICS([]byte(data))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(req.Destinations) != 2 || req.StartDate != "2024-07-21" ||
+		req.EndDate != "2024-07-23" {
+		t.Fatalf("unexpected itinerary: %v", req)
+	}
+	bukhara := req.Destinations[0]
+	if bukhara.Name != "Bukhara" || bukhara.EndDate != "2024-07-21" ||
+		strings.Join(bukhara.Activities, "|") != "Flight to Bukhara|Ark fortress|Lyabi-Hauz" {
+		t.Errorf("unexpected destination: %v", bukhara)
+	}
+	if req.Destinations[1].Latitude != nil {
+		t.Error("expected invalid coordinates to be left out")
+	}
+
+	items := []string{}
+	for _, warn := range warnings {
+		items = append(items, warn.Item)
+	}
+	expected := "VTODO,event nowhere,event backwards,event weekly,event weekly"
+	if strings.Join(items, ",") != expected {
+		t.Errorf("expected warnings for %s, got %v", expected, warnings)
+	}
+}
+
+func TestParseICSInvalid(t *testing.T) {
+	for _, data := range []string{
+		"",
+		"{\"title\": \"Uzbekistan\"}",
+		"BEGIN:VCALENDAR\r\nBEGIN:VEVENT\r\nSUMMARY:Khiva\r\n",
+	} {
+		if _, _, err := ParseICS([]byte(data)); err == nil {
+			t.Errorf("expected %q to fail", data)
+		}
+	}
+}
+
+func TestValidate(t *testing.T) {
+	lat := 41.3
+	req := &pb.RequestCreateItineraries{
+		Title: "  Uzbekistan ",
+		Destinations: []*pb.Destination{{
+			Name:       "Tashkent",
+			StartDate:  "2024-07-16",
+			EndDate:    "2024-07-17",
+			Activities: []string{"swimming", " "},
+			Latitude:   &lat,
+		}, {
+			Name:      "Samarkand",
+			StartDate: "2024-07-18",
+			EndDate:   "2024-07-20",
+		}, {
+			Name:      "Khiva",
+			StartDate: "2024-07-21",
+			EndDate:   "2024-07-19",
+		}, {
+			Name:      "Bukhara",
+			StartDate: "21.07.2024",
+			EndDate:   "2024-07-22",
+		}, {
+			StartDate: "2024-07-16",
+			EndDate:   "2024-07-16",
+		}},
+	}
+
+	warnings, err := Validate(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if req.Title != "Uzbekistan" || req.StartDate != "2024-07-16" ||
+		req.EndDate != "2024-07-20" {
+		t.Errorf("expected the dates of the destinations, got %v", req)
+	}
+	if len(req.Destinations) != 2 || len(req.Destinations[0].Activities) != 1 ||
+		req.Destinations[0].Latitude != nil {
+		t.Errorf("unexpected destinations: %v", req.Destinations)
+	}
+	if len(warnings) != 5 {
+		t.Errorf("expected 5 warnings, got %v", warnings)
+	}
+
+	req = &pb.RequestCreateItineraries{
+		Title:     "Uzbekistan",
+		StartDate: "2024-07-17",
+		EndDate:   "2024-07-20",
+		Destinations: []*pb.Destination{{
+			Name:      "Tashkent",
+			StartDate: "2024-07-16",
+			EndDate:   "2024-07-17",
+		}},
+	}
+	warnings, err = Validate(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(req.Destinations) != 0 || len(warnings) != 1 ||
+		!strings.Contains(warnings[0].Message, "outside") {
+		t.Errorf("expected the destination outside the itinerary to be skipped, got %v",
+			warnings)
+	}
+}
+
+func TestValidateInvalid(t *testing.T) {
+	for _, req := range []*pb.RequestCreateItineraries{
+		{StartDate: "2024-07-16", EndDate: "2024-07-20"},
+		{Title: "Uzbekistan"},
+		{Title: "Uzbekistan", StartDate: "2024-07-16", EndDate: "July"},
+		{Title: "Uzbekistan", StartDate: "2024-07-20", EndDate: "2024-07-16"},
+	} {
+		if _, err := Validate(req); err == nil {
+			t.Errorf("expected %v to fail", req)
+		}
+	}
+}
@@ -0,0 +1,58 @@
+// Package itinerary renders itineraries as documents to take elsewhere: an
+// iCalendar with an event per destination day, GPX waypoints of the
+// destinations and a printable Markdown or HTML page. It also reads the
+// itineraries imported from iCalendar or JSON files.
+package itinerary
+
+import (
+	"fmt"
+	"time"
+	pb "travel/genproto/itineraries"
+)
+
+// ParseDate parses the dates of itineraries, either plain or as scanned from a
+// date column.
+func ParseDate(date string) (time.Time, error) {
+	if len(date) > len(time.DateOnly) {
+		date = date[:len(time.DateOnly)]
+	}
+	return time.Parse(time.DateOnly, date)
+}
+
+// maxDays caps the days of a destination, as each of them is rendered, so a
+// mistyped year can't make a document of thousands of days.
+const maxDays = 366
+
+// days returns the days of the destination, its first and last included.
+func days(des *pb.DestinationEdit) ([]time.Time, error) {
+	start, err := ParseDate(des.StartDate)
+	if err != nil {
+		return nil, fmt.Errorf("invalid start date of %s: %s", des.Name, des.StartDate)
+	}
+	end, err := ParseDate(des.EndDate)
+	if err != nil {
+		return nil, fmt.Errorf("invalid end date of %s: %s", des.Name, des.EndDate)
+	}
+	if end.Before(start) {
+		return nil, fmt.Errorf("%s ends before it starts", des.Name)
+	}
+	if end.After(start.AddDate(0, 0, maxDays-1)) {
+		return nil, fmt.Errorf("%s lasts more than %d days", des.Name, maxDays)
+	}
+
+	res := []time.Time{}
+	for day := start; !day.After(end); day = day.AddDate(0, 0, 1) {
+		res = append(res, day)
+	}
+	return res, nil
+}
+
+// formatDate formats a date of an itinerary as YYYY-MM-DD, or leaves it as is
+// if it is not a date.
+func formatDate(date string) string {
+	t, err := ParseDate(date)
+	if err != nil {
+		return date
+	}
+	return t.Format(time.DateOnly)
+}
@@ -0,0 +1,169 @@
+package itinerary
+
+import (
+	"encoding/xml"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+	pb "travel/genproto/itineraries"
+	"unicode/utf8"
+)
+
+func testItinerary() *pb.ResponseGetItineraryFullInfo {
+	lat, lon := 41.2995, 69.2401
+	return &pb.ResponseGetItineraryFullInfo{
+		Id:          "1",
+		Title:       "Uzbekistan, the Silk Road",
+		Description: "Cities of the *Silk Road*",
+		StartDate:   "2024-07-16",
+		EndDate:     "2024-07-20",
+		Author:      &pb.Author{Username: "abdulaziz"},
+		Destinations: []*pb.DestinationEdit{{
+			Id:        "tashkent",
+			Name:      "Tashkent",
+			StartDate: "2024-07-16T00:00:00Z",
+			EndDate:   "2024-07-17T00:00:00Z",
+			Activities: []*pb.Activity{
+				{Activity: "Chorsu Bazaar"},
+				{Activity: "Metro; all stations"},
+			},
+			Latitude:  &lat,
+			Longitude: &lon,
+		}, {
+			Id:        "samarkand",
+			Name:      "Samarkand",
+			StartDate: "2024-07-18",
+			EndDate:   "2024-07-20",
+		}},
+	}
+}
+
+func TestICS(t *testing.T) {
+	data, err := ICS(testItinerary(), time.Date(2024, 7, 1, 12, 0, 0, 0, time.UTC))
+	if err != nil {
+		t.Fatal(err)
+	}
+	ics := string(data)
+
+	if n := strings.Count(ics, "BEGIN:VEVENT"); n != 5 {
+		t.Errorf("expected an event per destination day, got %d", n)
+	}
+	for _, line := range []string{
+		"X-WR-CALNAME:Uzbekistan\\, the Silk Road\r\n",
+		"UID:tashkent-20240717@traveltales\r\n",
+		"DTSTART;VALUE=DATE:20240720\r\nDTEND;VALUE=DATE:20240721\r\n",
+		"DESCRIPTION:Chorsu Bazaar\\nMetro\\; all stations\r\n",
+		"GEO:41.299500;69.240100\r\n",
+		"DTSTAMP:20240701T120000Z\r\n",
+	} {
+		if !strings.Contains(ics, line) {
+			t.Errorf("expected %q in:\n%s", line, ics)
+		}
+	}
+	if strings.Count(ics, "GEO:") != 2 {
+		t.Error("expected coordinates only on the days of Tashkent")
+	}
+}
+
+func TestICSFolding(t *testing.T) {
+	it := testItinerary()
+	it.Destinations[1].Name = strings.Repeat("Самарканд ", 20)
+	data, err := ICS(it, time.Now())
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, line := range strings.Split(string(data), "\r\n") {
+		if len(line) > icsLineLength {
+			t.Errorf("expected lines of up to %d octets, got %d", icsLineLength, len(line))
+		}
+		if !utf8.ValidString(line) {
+			t.Errorf("expected characters not to be split, got %q", line)
+		}
+	}
+	unfolded := strings.ReplaceAll(string(data), "\r\n ", "")
+	if !strings.Contains(unfolded, "SUMMARY:"+it.Destinations[1].Name+"\r\n") {
+		t.Error("expected the folded summary to unfold to the name")
+	}
+}
+
+func TestICSInvalidDates(t *testing.T) {
+	it := testItinerary()
+	it.Destinations[1].EndDate = "2024-07-01"
+	if _, err := ICS(it, time.Now()); err == nil {
+		t.Error("expected a destination ending before it starts to fail")
+	}
+	it.Destinations[1].EndDate = "soon"
+	if _, err := ICS(it, time.Now()); err == nil {
+		t.Error("expected an invalid date to fail")
+	}
+
+	it.Destinations[1].EndDate = "2025-07-18"
+	if _, err := ICS(it, time.Now()); err != nil {
+		t.Errorf("expected a year long destination to be rendered, got %s", err)
+	}
+	it.Destinations[1].EndDate = "2025-07-19"
+	if _, err := ICS(it, time.Now()); err == nil {
+		t.Error("expected a destination longer than a year to fail")
+	}
+	if _, err := Markdown(it); err == nil {
+		t.Error("expected the markdown of a destination longer than a year to fail")
+	}
+}
+
+func TestMarkdown(t *testing.T) {
+	data, err := Markdown(testItinerary())
+	if err != nil {
+		t.Fatal(err)
+	}
+	md := string(data)
+	for _, text := range []string{
+		"# Uzbekistan, the Silk Road\n",
+		"**2024-07-16 – 2024-07-20** · by abdulaziz\n",
+		"Cities of the \\*Silk Road\\*\n",
+		"## Tashkent\n\n2024-07-16 – 2024-07-17\n\n- Chorsu Bazaar\n- Metro; all stations\n",
+		"## Samarkand\n",
+	} {
+		if !strings.Contains(md, text) {
+			t.Errorf("expected %q in:\n%s", text, md)
+		}
+	}
+}
+
+func TestHTML(t *testing.T) {
+	it := testItinerary()
+	it.Title = "<script>alert(1)</script>"
+	data, err := HTML(it)
+	if err != nil {
+		t.Fatal(err)
+	}
+	page := string(data)
+	if strings.Contains(page, "<script>") {
+		t.Error("expected the title to be escaped")
+	}
+	if !strings.Contains(page, "<li>Chorsu Bazaar</li>") ||
+		!strings.Contains(page, "<h2>Samarkand</h2>") {
+		t.Errorf("unexpected page:\n%s", page)
+	}
+}
+
+func TestGPX(t *testing.T) {
+	data, err := GPX(testItinerary())
+	if err != nil {
+		t.Fatal(err)
+	}
+	doc := gpxDocument{}
+	if err := xml.Unmarshal(data, &doc); err != nil {
+		t.Fatal(err)
+	}
+	if len(doc.Waypoints) != 1 || doc.Waypoints[0].Name != "Tashkent" ||
+		doc.Waypoints[0].Lat != 41.2995 || doc.Waypoints[0].Lon != 69.2401 {
+		t.Errorf("expected only the destination with coordinates, got %v", doc.Waypoints)
+	}
+
+	it := testItinerary()
+	it.Destinations = it.Destinations[1:]
+	if _, err := GPX(it); !errors.Is(err, ErrNoCoordinates) {
+		t.Errorf("expected ErrNoCoordinates, got %v", err)
+	}
+}
@@ -0,0 +1,77 @@
+package logger
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/google/uuid"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	RequestIdHeader = "x-request-id"
+	UserIdHeader    = "x-user-id"
+)
+
+// UnaryServerInterceptor attaches the request id, the method and the user id
+// to the context of every RPC and logs the RPC when it finishes. The request id
+// is taken from the x-request-id header or generated, sent back in the response
+// header and passed on to the services called while handling the RPC. The user
+// id is the one of the request; the x-user-id header is set by the client, so
+// it is only kept as the unverified claimed user id.
+func UnaryServerInterceptor(logger *slog.Logger) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo,
+		handler grpc.UnaryHandler) (interface{}, error) {
+		start := time.Now()
+		md, _ := metadata.FromIncomingContext(ctx)
+
+		request := Request{
+			Id:            header(md, RequestIdHeader),
+			Method:        info.FullMethod,
+			UserId:        userId(req),
+			ClaimedUserId: header(md, UserIdHeader),
+		}
+		if request.Id == "" {
+			request.Id = uuid.NewString()
+		}
+
+		ctx = WithRequest(ctx, request)
+		ctx = metadata.AppendToOutgoingContext(ctx, RequestIdHeader, request.Id)
+		grpc.SetHeader(ctx, metadata.Pairs(RequestIdHeader, request.Id))
+
+		resp, err := handler(ctx, req)
+
+		level := slog.LevelInfo
+		if err != nil {
+			level = slog.LevelError
+		}
+		logger.Log(ctx, level, "finished call",
+			slog.String("code", status.Code(err).String()),
+			slog.Duration("duration", time.Since(start)))
+		return resp, err
+	}
+}
+
+func header(md metadata.MD, key string) string {
+	if val := md.Get(key); len(val) > 0 {
+		return val[0]
+	}
+	return ""
+}
+
+// userId returns the id of the user acting in req, for the requests that
+// carry one.
+func userId(req interface{}) string {
+	switch r := req.(type) {
+	case interface{ GetUserId() string }:
+		return r.GetUserId()
+	case interface{ GetAuthorId() string }:
+		return r.GetAuthorId()
+	case interface{ GetAutherId() string }:
+		return r.GetAutherId()
+	}
+	return ""
+}
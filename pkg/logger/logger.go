@@ -1,20 +1,107 @@
 package logger
 
 import (
+	"context"
+	"fmt"
+	"io"
 	"log"
 	"log/slog"
 	"os"
+	"strings"
+	"travel/config"
 )
 
-func NewLogger() *slog.Logger {
-	opts := &slog.HandlerOptions{
-		Level: slog.LevelDebug,
+// NewLogger returns the logger configured by LOG_LEVEL, LOG_FORMAT and
+// LOG_OUTPUT. It is created once in main and shared by the whole service.
+func NewLogger(cfg *config.Config) *slog.Logger {
+	output, err := openOutput(cfg.LOG_OUTPUT)
+	if err != nil {
+		log.Fatalf("error with opening log output: %s", err)
 	}
-
-	file, err := os.OpenFile("app.log", os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0666)
+	logger, err := New(output, cfg.LOG_LEVEL, cfg.LOG_FORMAT)
 	if err != nil {
-		log.Fatal(file)
+		log.Fatal(err)
+	}
+	return logger
+}
+
+// New returns a logger writing to w at level in format "text" or "json". Log
+// lines written with a request context carry the request attributes.
+func New(w io.Writer, level, format string) (*slog.Logger, error) {
+	var lvl slog.Level
+	if err := lvl.UnmarshalText([]byte(level)); err != nil {
+		return nil, fmt.Errorf("invalid log level: %s", level)
+	}
+	opts := &slog.HandlerOptions{Level: lvl}
+
+	var handler slog.Handler
+	switch strings.ToLower(format) {
+	case "text":
+		handler = slog.NewTextHandler(w, opts)
+	case "json":
+		handler = slog.NewJSONHandler(w, opts)
+	default:
+		return nil, fmt.Errorf("invalid log format: %s", format)
 	}
+	return slog.New(contextHandler{handler}), nil
+}
+
+// openOutput opens "stdout", "stderr" or a file to append to.
+func openOutput(output string) (io.Writer, error) {
+	switch output {
+	case "stdout", "":
+		return os.Stdout, nil
+	case "stderr":
+		return os.Stderr, nil
+	}
+	return os.OpenFile(output, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0644)
+}
+
+type requestKey struct{}
+
+// Request identifies the RPC a log line was written for.
+type Request struct {
+	Id     string
+	Method string
+	UserId string
+	// ClaimedUserId is the user id sent by the client in the x-user-id header,
+	// not verified by this service.
+	ClaimedUserId string
+}
+
+// WithRequest returns ctx carrying req, added to every line logged with it.
+func WithRequest(ctx context.Context, req Request) context.Context {
+	return context.WithValue(ctx, requestKey{}, req)
+}
+
+func RequestFrom(ctx context.Context) (Request, bool) {
+	req, ok := ctx.Value(requestKey{}).(Request)
+	return req, ok
+}
+
+// contextHandler adds the request of the context to the records.
+type contextHandler struct {
+	slog.Handler
+}
+
+func (h contextHandler) Handle(ctx context.Context, r slog.Record) error {
+	if req, ok := RequestFrom(ctx); ok {
+		r.AddAttrs(slog.String("request_id", req.Id),
+			slog.String("method", req.Method))
+		if req.UserId != "" {
+			r.AddAttrs(slog.String("user_id", req.UserId))
+		}
+		if req.ClaimedUserId != "" && req.ClaimedUserId != req.UserId {
+			r.AddAttrs(slog.String("claimed_user_id", req.ClaimedUserId))
+		}
+	}
+	return h.Handler.Handle(ctx, r)
+}
+
+func (h contextHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return contextHandler{h.Handler.WithAttrs(attrs)}
+}
 
-	return slog.New(slog.NewTextHandler(file, opts))
+func (h contextHandler) WithGroup(name string) slog.Handler {
+	return contextHandler{h.Handler.WithGroup(name)}
 }
@@ -0,0 +1,130 @@
+package logger
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"strings"
+	"testing"
+	pb "travel/genproto/stories"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+func TestNew(t *testing.T) {
+	buf := bytes.Buffer{}
+	logger, err := New(&buf, "warn", "json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	logger.Info("hidden")
+	logger.Warn("shown")
+	if strings.Contains(buf.String(), "hidden") || !strings.Contains(buf.String(), "shown") {
+		t.Errorf("expected only warnings to be logged, got %s", buf.String())
+	}
+
+	if _, err := New(&buf, "loud", "json"); err == nil {
+		t.Error("expected invalid level to fail")
+	}
+	if _, err := New(&buf, "info", "xml"); err == nil {
+		t.Error("expected invalid format to fail")
+	}
+}
+
+func lines(t *testing.T, buf *bytes.Buffer) []map[string]interface{} {
+	t.Helper()
+	res := []map[string]interface{}{}
+	for _, line := range strings.Split(strings.TrimSpace(buf.String()), "\n") {
+		entry := map[string]interface{}{}
+		if err := json.Unmarshal([]byte(line), &entry); err != nil {
+			t.Fatal(err)
+		}
+		res = append(res, entry)
+	}
+	return res
+}
+
+func TestUnaryServerInterceptor(t *testing.T) {
+	buf := bytes.Buffer{}
+	logger, _ := New(&buf, "info", "json")
+	interceptor := UnaryServerInterceptor(logger)
+
+	ctx := metadata.NewIncomingContext(context.Background(),
+		metadata.Pairs(RequestIdHeader, "req-1"))
+	info := &grpc.UnaryServerInfo{FullMethod: "/stories.Stories/CreateStory"}
+	req := &pb.RequestCreateStory{AuthorId: "user-1"}
+
+	var outgoing metadata.MD
+	interceptor(ctx, req, info, func(ctx context.Context, req interface{}) (interface{}, error) {
+		logger.ErrorContext(ctx, "error with creating story")
+		outgoing, _ = metadata.FromOutgoingContext(ctx)
+		return nil, nil
+	})
+
+	entries := lines(t, &buf)
+	if len(entries) != 2 {
+		t.Fatalf("expected the handler and the interceptor to log, got %v", entries)
+	}
+	for _, entry := range entries {
+		if entry["request_id"] != "req-1" || entry["method"] != info.FullMethod ||
+			entry["user_id"] != "user-1" {
+			t.Errorf("expected the request attributes, got %v", entry)
+		}
+	}
+	if entries[1]["msg"] != "finished call" || entries[1]["code"] != "OK" {
+		t.Errorf("unexpected call log: %v", entries[1])
+	}
+	if ids := outgoing.Get(RequestIdHeader); len(ids) != 1 || ids[0] != "req-1" {
+		t.Errorf("expected the request id to be passed on, got %v", ids)
+	}
+}
+
+func TestUnaryServerInterceptorClaimedUser(t *testing.T) {
+	buf := bytes.Buffer{}
+	logger, _ := New(&buf, "info", "json")
+	ctx := metadata.NewIncomingContext(context.Background(),
+		metadata.Pairs(UserIdHeader, "user-2"))
+	info := &grpc.UnaryServerInfo{FullMethod: "/stories.Stories/CreateStory"}
+
+	UnaryServerInterceptor(logger)(ctx, &pb.RequestCreateStory{AuthorId: "user-1"}, info,
+		func(ctx context.Context, req interface{}) (interface{}, error) {
+			return nil, nil
+		})
+
+	entry := lines(t, &buf)[0]
+	if entry["user_id"] != "user-1" || entry["claimed_user_id"] != "user-2" {
+		t.Errorf("expected the request's user id and the header's as claimed, got %v",
+			entry)
+	}
+}
+
+func TestUnaryServerInterceptorGeneratesId(t *testing.T) {
+	buf := bytes.Buffer{}
+	logger, _ := New(&buf, "info", "json")
+	info := &grpc.UnaryServerInfo{FullMethod: "/stories.Stories/GetStories"}
+
+	UnaryServerInterceptor(logger)(context.Background(), &pb.RequestGetStories{}, info,
+		func(ctx context.Context, req interface{}) (interface{}, error) {
+			return nil, nil
+		})
+
+	entry := lines(t, &buf)[0]
+	if id, _ := entry["request_id"].(string); id == "" {
+		t.Errorf("expected a generated request id, got %v", entry)
+	}
+	if _, ok := entry["user_id"]; ok {
+		t.Errorf("expected no user id for anonymous requests, got %v", entry)
+	}
+}
+
+func TestLoggingWithoutRequest(t *testing.T) {
+	buf := bytes.Buffer{}
+	logger, _ := New(&buf, "info", "text")
+	logger.With(slog.String("component", "health")).Info("postgres is ready")
+	if strings.Contains(buf.String(), "request_id") ||
+		!strings.Contains(buf.String(), "component=health") {
+		t.Errorf("unexpected log line: %s", buf.String())
+	}
+}
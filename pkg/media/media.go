@@ -0,0 +1,164 @@
+// Package media validates the images uploaded with the content, stores them
+// with their thumbnails in a blob store and keeps only their keys and URLs in
+// the database.
+package media
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"fmt"
+	"image"
+	_ "image/gif"
+	"image/jpeg"
+	"image/png"
+	"net/http"
+	"strings"
+	"travel/config"
+	"travel/models"
+
+	"github.com/google/uuid"
+)
+
+// maxPixels bounds the decoded size of an image, so a small file can't expand
+// into a huge bitmap.
+const maxPixels = 50_000_000
+
+var extensions = map[string]string{
+	"image/jpeg": ".jpg",
+	"image/png":  ".png",
+	"image/gif":  ".gif",
+}
+
+// Upload is a decoded and validated image.
+type Upload struct {
+	Data        []byte
+	ContentType string
+	Image       image.Image
+}
+
+// Media stores the uploaded images in Store.
+type Media struct {
+	Store         Store
+	MaxBytes      int
+	ThumbnailSize int
+}
+
+func New(cfg *config.Config, store Store) *Media {
+	return &Media{
+		Store:         store,
+		MaxBytes:      cfg.MEDIA_MAX_IMAGE_BYTES,
+		ThumbnailSize: cfg.MEDIA_THUMBNAIL_SIZE,
+	}
+}
+
+// Decode decodes data, base64 or a base64 data URL, and checks it is a JPEG,
+// PNG or GIF image of at most MaxBytes.
+func (m *Media) Decode(data string) (*Upload, error) {
+	if strings.HasPrefix(data, "data:") {
+		_, encoded, ok := strings.Cut(data, ",")
+		if !ok {
+			return nil, fmt.Errorf("malformed data URL")
+		}
+		data = encoded
+	}
+	if base64.StdEncoding.DecodedLen(len(data)) > m.MaxBytes+2 {
+		return nil, fmt.Errorf("image is larger than %d bytes", m.MaxBytes)
+	}
+	raw, err := base64.StdEncoding.DecodeString(data)
+	if err != nil {
+		return nil, fmt.Errorf("image is not valid base64: %s", err)
+	}
+	if len(raw) > m.MaxBytes {
+		return nil, fmt.Errorf("image is larger than %d bytes", m.MaxBytes)
+	}
+
+	contentType := http.DetectContentType(raw)
+	if _, ok := extensions[contentType]; !ok {
+		return nil, fmt.Errorf("unsupported image type: %s", contentType)
+	}
+	cfg, _, err := image.DecodeConfig(bytes.NewReader(raw))
+	if err != nil {
+		return nil, fmt.Errorf("error with decoding image: %s", err)
+	}
+	if cfg.Width*cfg.Height > maxPixels {
+		return nil, fmt.Errorf("image is too large: %dx%d", cfg.Width, cfg.Height)
+	}
+	img, _, err := image.Decode(bytes.NewReader(raw))
+	if err != nil {
+		return nil, fmt.Errorf("error with decoding image: %s", err)
+	}
+	return &Upload{Data: raw, ContentType: contentType, Image: img}, nil
+}
+
+// Save stores upload and its thumbnail under prefix.
+func (m *Media) Save(ctx context.Context, prefix string, upload *Upload) (
+	*models.Image, error) {
+	name := prefix + "/" + uuid.NewString()
+	bounds := upload.Image.Bounds()
+	res := models.Image{
+		Key:          name + extensions[upload.ContentType],
+		ContentType:  upload.ContentType,
+		Width:        bounds.Dx(),
+		Height:       bounds.Dy(),
+		ThumbnailKey: name + "_thumb" + thumbnailExtension(upload.ContentType),
+	}
+
+	thumbnail, err := encodeThumbnail(
+		Thumbnail(upload.Image, m.ThumbnailSize), upload.ContentType)
+	if err != nil {
+		return nil, err
+	}
+	if err := m.Store.Put(ctx, res.Key, upload.ContentType, upload.Data); err != nil {
+		return nil, fmt.Errorf("error with storing image: %s", err)
+	}
+	err = m.Store.Put(ctx, res.ThumbnailKey,
+		thumbnailContentType(upload.ContentType), thumbnail)
+	if err != nil {
+		m.Store.Delete(ctx, res.Key)
+		return nil, fmt.Errorf("error with storing thumbnail: %s", err)
+	}
+	res.Url = m.Store.URL(res.Key)
+	res.ThumbnailUrl = m.Store.URL(res.ThumbnailKey)
+	return &res, nil
+}
+
+// Delete removes the images and their thumbnails from the store, returning
+// the first error.
+func (m *Media) Delete(ctx context.Context, images []models.Image) error {
+	var first error
+	for _, img := range images {
+		for _, key := range []string{img.Key, img.ThumbnailKey} {
+			if err := m.Store.Delete(ctx, key); err != nil && first == nil {
+				first = err
+			}
+		}
+	}
+	return first
+}
+
+// Thumbnails of JPEGs are JPEGs; the others are PNGs to keep transparency.
+func thumbnailContentType(contentType string) string {
+	if contentType == "image/jpeg" {
+		return contentType
+	}
+	return "image/png"
+}
+
+func thumbnailExtension(contentType string) string {
+	return extensions[thumbnailContentType(contentType)]
+}
+
+func encodeThumbnail(img image.Image, contentType string) ([]byte, error) {
+	buf := bytes.Buffer{}
+	var err error
+	if thumbnailContentType(contentType) == "image/jpeg" {
+		err = jpeg.Encode(&buf, img, &jpeg.Options{Quality: 80})
+	} else {
+		err = png.Encode(&buf, img)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error with encoding thumbnail: %s", err)
+	}
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,169 @@
+package media
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"image"
+	"image/color"
+	"image/png"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"travel/models"
+)
+
+func encodePNG(t *testing.T, width, height int) string {
+	t.Helper()
+	img := image.NewRGBA(image.Rect(0, 0, width, height))
+	img.Set(0, 0, color.RGBA{R: 255, A: 255})
+	buf := bytes.Buffer{}
+	if err := png.Encode(&buf, img); err != nil {
+		t.Fatal(err)
+	}
+	return base64.StdEncoding.EncodeToString(buf.Bytes())
+}
+
+func newTestMedia(t *testing.T) *Media {
+	store, err := NewLocalStore(t.TempDir(), "http://localhost:9091/media/")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &Media{Store: store, MaxBytes: 1 << 20, ThumbnailSize: 32}
+}
+
+func TestDecode(t *testing.T) {
+	m := newTestMedia(t)
+	data := encodePNG(t, 40, 20)
+
+	for _, input := range []string{data, "data:image/png;base64," + data} {
+		upload, err := m.Decode(input)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if upload.ContentType != "image/png" || upload.Image.Bounds().Dx() != 40 {
+			t.Errorf("unexpected upload: %s %v", upload.ContentType, upload.Image.Bounds())
+		}
+	}
+}
+
+func TestDecodeInvalid(t *testing.T) {
+	m := newTestMedia(t)
+	m.MaxBytes = 200
+	text := base64.StdEncoding.EncodeToString([]byte("just some text"))
+	truncated := encodePNG(t, 4, 4)[:40]
+
+	inputs := map[string]string{
+		"base64_encoded_image1": "not valid base64",
+		text:                    "unsupported image type",
+		truncated:               "error with decoding image",
+		encodePNG(t, 500, 500):  "larger than 200 bytes",
+		"data:image/png;base64": "malformed data URL",
+	}
+	for input, expected := range inputs {
+		_, err := m.Decode(input)
+		if err == nil || !strings.Contains(err.Error(), expected) {
+			t.Errorf("expected %q, got %v", expected, err)
+		}
+	}
+}
+
+func TestSave(t *testing.T) {
+	m := newTestMedia(t)
+	upload, err := m.Decode(encodePNG(t, 100, 50))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	img, err := m.Save(context.Background(), "stories", upload)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(img.Key, "stories/") || !strings.HasSuffix(img.Key, ".png") ||
+		img.Width != 100 || img.Height != 50 {
+		t.Errorf("unexpected image: %+v", img)
+	}
+	if img.Url != "http://localhost:9091/media/"+img.Key {
+		t.Errorf("unexpected url: %s", img.Url)
+	}
+
+	dir := m.Store.(*LocalStore).Dir
+	file, err := os.Open(filepath.Join(dir, img.ThumbnailKey))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer file.Close()
+	thumbnail, _, err := image.DecodeConfig(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if thumbnail.Width != 32 || thumbnail.Height != 16 {
+		t.Errorf("expected a 32x16 thumbnail, got %dx%d", thumbnail.Width, thumbnail.Height)
+	}
+
+	if err := m.Delete(context.Background(), []models.Image{*img}); err != nil {
+		t.Fatal(err)
+	}
+	if entries, _ := os.ReadDir(filepath.Join(dir, "stories")); len(entries) != 0 {
+		t.Errorf("expected the blobs to be deleted, got %v", entries)
+	}
+}
+
+func TestThumbnail(t *testing.T) {
+	small := image.NewRGBA(image.Rect(0, 0, 10, 10))
+	if Thumbnail(small, 32) != image.Image(small) {
+		t.Error("expected small images to be kept")
+	}
+	tall := Thumbnail(image.NewRGBA(image.Rect(0, 0, 50, 200)), 32).Bounds()
+	if tall.Dx() != 8 || tall.Dy() != 32 {
+		t.Errorf("expected 8x32, got %v", tall)
+	}
+}
+
+func TestLocalStore(t *testing.T) {
+	store, err := NewLocalStore(t.TempDir(), "http://localhost:9091/media")
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.Background()
+
+	for _, key := range []string{"../escape.png", "/abs.png", "a//b.png"} {
+		if err := store.Put(ctx, key, "image/png", []byte("x")); err == nil {
+			t.Errorf("expected key %q to be rejected", key)
+		}
+	}
+	if err := store.Put(ctx, "stories/a.png", "image/png", []byte("png")); err != nil {
+		t.Fatal(err)
+	}
+
+	server := httptest.NewServer(store.Handler())
+	defer server.Close()
+	res, err := http.Get(server.URL + "/stories/a.png")
+	if err != nil {
+		t.Fatal(err)
+	}
+	body, _ := io.ReadAll(res.Body)
+	res.Body.Close()
+	if string(body) != "png" {
+		t.Errorf("unexpected blob: %s", body)
+	}
+	res, err = http.Get(server.URL + "/stories/")
+	if err != nil {
+		t.Fatal(err)
+	}
+	res.Body.Close()
+	if res.StatusCode != http.StatusNotFound {
+		t.Errorf("expected directories not to be listed, got %d", res.StatusCode)
+	}
+
+	if err := store.Delete(ctx, "stories/a.png"); err != nil {
+		t.Fatal(err)
+	}
+	if err := store.Delete(ctx, "stories/a.png"); err != nil {
+		t.Errorf("expected deleting a missing blob to succeed, got %s", err)
+	}
+}
@@ -0,0 +1,26 @@
+package media
+
+import (
+	"image"
+
+	"golang.org/x/image/draw"
+)
+
+// Thumbnail scales img down to fit in a size x size square, keeping its
+// aspect ratio. Images already smaller are returned as they are.
+func Thumbnail(img image.Image, size int) image.Image {
+	bounds := img.Bounds()
+	width, height := bounds.Dx(), bounds.Dy()
+	if width <= size && height <= size {
+		return img
+	}
+
+	if width >= height {
+		width, height = size, max(1, height*size/width)
+	} else {
+		width, height = max(1, width*size/height), size
+	}
+	res := image.NewRGBA(image.Rect(0, 0, width, height))
+	draw.CatmullRom.Scale(res, res.Bounds(), img, bounds, draw.Over, nil)
+	return res
+}
@@ -0,0 +1,153 @@
+// Package metrics defines the Prometheus metrics of the content service and
+// serves them over HTTP for scraping.
+package metrics
+
+import (
+	"context"
+	"errors"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
+)
+
+// Registry holds all metrics of the service, with the Go runtime and process
+// metrics.
+var Registry = prometheus.NewRegistry()
+
+var (
+	RPCDuration = promauto.With(Registry).NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "grpc_server_handling_seconds",
+		Help:    "Latency of the RPCs handled by the content service.",
+		Buckets: prometheus.DefBuckets,
+	}, []string{"method", "code"})
+
+	RPCErrors = promauto.With(Registry).NewCounterVec(prometheus.CounterOpts{
+		Name: "grpc_server_errors_total",
+		Help: "RPCs of the content service that returned an error.",
+	}, []string{"method", "code"})
+
+	UsersClientDuration = promauto.With(Registry).NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "users_client_handling_seconds",
+		Help:    "Latency of the calls to the users service.",
+		Buckets: prometheus.DefBuckets,
+	}, []string{"method", "code"})
+
+	CacheRequests = promauto.With(Registry).NewCounterVec(prometheus.CounterOpts{
+		Name: "cache_requests_total",
+		Help: "Cache lookups by result: hit, miss or error.",
+	}, []string{"cache", "result"})
+)
+
+func init() {
+	Registry.MustRegister(collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}))
+}
+
+// UnaryServerInterceptor records the latency and the errors of every RPC by
+// method and status code.
+func UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo,
+		handler grpc.UnaryHandler) (interface{}, error) {
+		start := time.Now()
+		resp, err := handler(ctx, req)
+
+		code := status.Code(err).String()
+		RPCDuration.WithLabelValues(info.FullMethod, code).
+			Observe(time.Since(start).Seconds())
+		if err != nil {
+			RPCErrors.WithLabelValues(info.FullMethod, code).Inc()
+		}
+		return resp, err
+	}
+}
+
+// UnaryClientInterceptor records the latency of the calls to the users service
+// by method and status code.
+func UnaryClientInterceptor() grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply interface{},
+		cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		start := time.Now()
+		err := invoker(ctx, method, req, reply, cc, opts...)
+		UsersClientDuration.WithLabelValues(method, status.Code(err).String()).
+			Observe(time.Since(start).Seconds())
+		return err
+	}
+}
+
+// CacheResult counts a lookup in cache as a hit when err is nil, and as a miss
+// when err is miss.
+func CacheResult(cache string, err, miss error) {
+	result := "hit"
+	switch {
+	case errors.Is(err, miss):
+		result = "miss"
+	case err != nil:
+		result = "error"
+	}
+	CacheRequests.WithLabelValues(cache, result).Inc()
+}
+
+// poolCollector exports the connection pool statistics returned by stats as
+// db_pool_<name> metrics: counters for the names ending with _total, gauges for
+// the others.
+type poolCollector struct {
+	stats func() map[string]float64
+	descs map[string]*prometheus.Desc
+}
+
+// RegisterPoolStats exports the connection pool statistics returned by stats,
+// read at every scrape.
+func RegisterPoolStats(stats func() map[string]float64) {
+	c := poolCollector{stats: stats, descs: map[string]*prometheus.Desc{}}
+	for name := range stats() {
+		c.descs[name] = prometheus.NewDesc("db_pool_"+name,
+			"Database connection pool statistic "+name+".", nil, nil)
+	}
+	Registry.MustRegister(c)
+}
+
+func (c poolCollector) Describe(ch chan<- *prometheus.Desc) {
+	for _, desc := range c.descs {
+		ch <- desc
+	}
+}
+
+func (c poolCollector) Collect(ch chan<- prometheus.Metric) {
+	for name, val := range c.stats() {
+		if desc, ok := c.descs[name]; ok {
+			kind := prometheus.GaugeValue
+			if strings.HasSuffix(name, "_total") {
+				kind = prometheus.CounterValue
+			}
+			ch <- prometheus.MustNewConstMetric(desc, kind, val)
+		}
+	}
+}
+
+// Serve serves the metrics on addr under /metrics. The returned server is
+// stopped with Shutdown.
+func Serve(addr string) *http.Server {
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", promhttp.HandlerFor(Registry, promhttp.HandlerOpts{}))
+	server := &http.Server{
+		Addr:              addr,
+		Handler:           mux,
+		ReadHeaderTimeout: 5 * time.Second,
+	}
+
+	go func() {
+		err := server.ListenAndServe()
+		if err != nil && !errors.Is(err, http.ErrServerClosed) {
+			log.Printf("error with serving metrics: %s", err)
+		}
+	}()
+	return server
+}
@@ -0,0 +1,76 @@
+package metrics
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestUnaryServerInterceptor(t *testing.T) {
+	interceptor := UnaryServerInterceptor()
+	info := &grpc.UnaryServerInfo{FullMethod: "/stories.Stories/GetStories"}
+
+	interceptor(context.Background(), nil, info,
+		func(ctx context.Context, req interface{}) (interface{}, error) {
+			return nil, nil
+		})
+	interceptor(context.Background(), nil, info,
+		func(ctx context.Context, req interface{}) (interface{}, error) {
+			return nil, status.Error(codes.NotFound, "story not found")
+		})
+
+	if n := testutil.CollectAndCount(RPCDuration); n != 2 {
+		t.Errorf("expected a histogram per status code, got %d", n)
+	}
+	errs := testutil.ToFloat64(RPCErrors.WithLabelValues(info.FullMethod, "NotFound"))
+	if errs != 1 {
+		t.Errorf("expected 1 error, got %v", errs)
+	}
+	errs = testutil.ToFloat64(RPCErrors.WithLabelValues(info.FullMethod, "OK"))
+	if errs != 0 {
+		t.Errorf("expected successful RPCs not to count as errors, got %v", errs)
+	}
+}
+
+func TestCacheResult(t *testing.T) {
+	miss := errors.New("miss")
+	CacheResult("test", nil, miss)
+	CacheResult("test", nil, miss)
+	CacheResult("test", miss, miss)
+	CacheResult("test", errors.New("connection refused"), miss)
+
+	for result, want := range map[string]float64{"hit": 2, "miss": 1, "error": 1} {
+		got := testutil.ToFloat64(CacheRequests.WithLabelValues("test", result))
+		if got != want {
+			t.Errorf("expected %v %s, got %v", want, result, got)
+		}
+	}
+}
+
+func TestRegisterPoolStats(t *testing.T) {
+	RegisterPoolStats(func() map[string]float64 {
+		return map[string]float64{"open_connections": 3, "waits_total": 2}
+	})
+
+	rec := httptest.NewRecorder()
+	promhttp.HandlerFor(Registry, promhttp.HandlerOpts{}).
+		ServeHTTP(rec, httptest.NewRequest("GET", "/metrics", nil))
+	body, _ := io.ReadAll(rec.Body)
+	for _, line := range []string{
+		"# TYPE db_pool_open_connections gauge", "db_pool_open_connections 3",
+		"# TYPE db_pool_waits_total counter", "db_pool_waits_total 2",
+	} {
+		if !strings.Contains(string(body), line) {
+			t.Errorf("expected %q in the pool stats, got:\n%s", line, body)
+		}
+	}
+}
@@ -0,0 +1,171 @@
+// Package textdiff compares texts line by line and formats the changes as
+// unified diffs.
+package textdiff
+
+import (
+	"fmt"
+	"strings"
+)
+
+type Op int
+
+const (
+	Equal Op = iota
+	Delete
+	Insert
+)
+
+// Line is a line of an edit script: kept, deleted from the old text or
+// inserted from the new one.
+type Line struct {
+	Op   Op
+	Text string
+}
+
+// Lines returns the shortest edit script turning a into b, computed with
+// Myers' algorithm.
+func Lines(a, b []string) []Line {
+	n, m := len(a), len(b)
+	offset := n + m + 1
+	v := make([]int, 2*offset+1)
+	trace := [][]int{}
+
+search:
+	for d := 0; d <= n+m; d++ {
+		trace = append(trace, append([]int(nil), v...))
+		for k := -d; k <= d; k += 2 {
+			var x int
+			if k == -d || (k != d && v[offset+k-1] < v[offset+k+1]) {
+				x = v[offset+k+1]
+			} else {
+				x = v[offset+k-1] + 1
+			}
+			y := x - k
+			for x < n && y < m && a[x] == b[y] {
+				x++
+				y++
+			}
+			v[offset+k] = x
+			if x >= n && y >= m {
+				break search
+			}
+		}
+	}
+
+	res := []Line{}
+	x, y := n, m
+	for d := len(trace) - 1; d >= 0; d-- {
+		v := trace[d]
+		k := x - y
+		prevK := k - 1
+		if k == -d || (k != d && v[offset+k-1] < v[offset+k+1]) {
+			prevK = k + 1
+		}
+		prevX := v[offset+prevK]
+		prevY := prevX - prevK
+		for x > prevX && y > prevY {
+			res = append(res, Line{Equal, a[x-1]})
+			x--
+			y--
+		}
+		if d > 0 {
+			if x == prevX {
+				res = append(res, Line{Insert, b[y-1]})
+			} else {
+				res = append(res, Line{Delete, a[x-1]})
+			}
+		}
+		x, y = prevX, prevY
+	}
+
+	for i, j := 0, len(res)-1; i < j; i, j = i+1, j-1 {
+		res[i], res[j] = res[j], res[i]
+	}
+	return res
+}
+
+// Split splits text into lines, without the final newline.
+func Split(text string) []string {
+	if text == "" {
+		return nil
+	}
+	return strings.Split(strings.TrimSuffix(text, "\n"), "\n")
+}
+
+// Unified returns the changes from a to b like diff -u, with context lines
+// around them, or "" when the texts are equal.
+func Unified(fromName, toName, a, b string, context int) string {
+	lines := Lines(Split(a), Split(b))
+	c := nextChange(lines, 0)
+	if c < 0 {
+		return ""
+	}
+
+	buf := strings.Builder{}
+	fmt.Fprintf(&buf, "--- %s\n+++ %s\n", fromName, toName)
+	for c >= 0 {
+		start := max(c-context, 0)
+		last := c
+		for {
+			next := nextChange(lines, last+1)
+			if next < 0 || next-last-1 > 2*context {
+				break
+			}
+			last = next
+		}
+		stop := min(last+1+context, len(lines))
+
+		writeHunk(&buf, lines, start, stop)
+		c = nextChange(lines, stop)
+	}
+	return buf.String()
+}
+
+func nextChange(lines []Line, from int) int {
+	for i := from; i < len(lines); i++ {
+		if lines[i].Op != Equal {
+			return i
+		}
+	}
+	return -1
+}
+
+func writeHunk(buf *strings.Builder, lines []Line, start, stop int) {
+	aStart, bStart := 1, 1
+	for _, line := range lines[:start] {
+		if line.Op != Insert {
+			aStart++
+		}
+		if line.Op != Delete {
+			bStart++
+		}
+	}
+	aLen, bLen := 0, 0
+	for _, line := range lines[start:stop] {
+		if line.Op != Insert {
+			aLen++
+		}
+		if line.Op != Delete {
+			bLen++
+		}
+	}
+	// an empty range is numbered by the line before it
+	if aLen == 0 {
+		aStart--
+	}
+	if bLen == 0 {
+		bStart--
+	}
+
+	fmt.Fprintf(buf, "@@ -%d,%d +%d,%d @@\n", aStart, aLen, bStart, bLen)
+	for _, line := range lines[start:stop] {
+		prefix := " "
+		switch line.Op {
+		case Delete:
+			prefix = "-"
+		case Insert:
+			prefix = "+"
+		}
+		buf.WriteString(prefix + line.Text + "\n")
+	}
+}
@@ -0,0 +1,77 @@
+package textdiff
+
+import (
+	"strings"
+	"testing"
+)
+
+func apply(a []string, script []Line) ([]string, []string) {
+	from, to := []string{}, []string{}
+	for _, line := range script {
+		if line.Op != Insert {
+			from = append(from, line.Text)
+		}
+		if line.Op != Delete {
+			to = append(to, line.Text)
+		}
+	}
+	return from, to
+}
+
+func TestLines(t *testing.T) {
+	cases := []struct {
+		a, b    string
+		changes int
+	}{
+		{"", "", 0},
+		{"a\nb\nc", "a\nb\nc", 0},
+		{"", "a\nb", 2},
+		{"a\nb", "", 2},
+		{"a\nb\nc\na\nb\nb\na", "c\nb\na\nb\na\nc", 5},
+		{"title\nfirst\nsecond", "title\nsecond\nthird", 2},
+	}
+	for _, c := range cases {
+		a, b := Split(c.a), Split(c.b)
+		script := Lines(a, b)
+		from, to := apply(a, script)
+		if strings.Join(from, "\n") != c.a || strings.Join(to, "\n") != c.b {
+			t.Errorf("script %v doesn't turn %q into %q", script, c.a, c.b)
+		}
+		changes := 0
+		for _, line := range script {
+			if line.Op != Equal {
+				changes++
+			}
+		}
+		if changes != c.changes {
+			t.Errorf("expected %d changes from %q to %q, got %d", c.changes, c.a, c.b,
+				changes)
+		}
+	}
+}
+
+func TestUnified(t *testing.T) {
+	a := "one\ntwo\nthree\nfour\nfive\nsix\nseven\neight\nnine\n"
+	b := "one\n2\nthree\nfour\nfive\nsix\nseven\neight\nnine\nten\n"
+
+	expected := `--- a
++++ b
+@@ -1,3 +1,3 @@
+ one
+-two
++2
+ three
+@@ -9,1 +9,2 @@
+ nine
++ten
+`
+	if res := Unified("a", "b", a, b, 1); res != expected {
+		t.Errorf("unexpected diff:\n%s", res)
+	}
+	if res := Unified("a", "b", a, a, 3); res != "" {
+		t.Errorf("expected no diff for equal texts, got %s", res)
+	}
+	if res := Unified("a", "b", "", "new\n", 3); res != "--- a\n+++ b\n@@ -0,0 +1,1 @@\n+new\n" {
+		t.Errorf("unexpected diff from an empty text:\n%s", res)
+	}
+}
@@ -0,0 +1,86 @@
+// Package tracing sets up OpenTelemetry tracing for the content service. The
+// exporter is selected by TRACING_EXPORTER; spans are created by the gRPC
+// stats handlers and by the instrumented postgres and redis clients.
+package tracing
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"travel/config"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+)
+
+const ServiceName = "content-service"
+
+// Init installs the global tracer provider configured by TRACING_EXPORTER:
+// "none" to disable tracing, "stdout", "file" to write spans as JSON to
+// TRACING_FILE, or "otlp" to send them to TRACING_OTLP_ENDPOINT. The returned
+// function flushes the spans still buffered.
+func Init(cfg *config.Config) (func(context.Context) error, error) {
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(
+		propagation.TraceContext{}, propagation.Baggage{}))
+
+	exporter, closer, err := newExporter(cfg)
+	if err != nil || exporter == nil {
+		return func(context.Context) error { return nil }, err
+	}
+
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithSampler(sdktrace.ParentBased(
+			sdktrace.TraceIDRatioBased(cfg.TRACING_SAMPLE_RATIO))),
+		sdktrace.WithResource(resource.NewSchemaless(
+			semconv.ServiceName(ServiceName))),
+	)
+	otel.SetTracerProvider(provider)
+
+	shutdown := func(ctx context.Context) error {
+		err := provider.Shutdown(ctx)
+		if closer != nil {
+			closer.Close()
+		}
+		return err
+	}
+	return shutdown, nil
+}
+
+func newExporter(cfg *config.Config) (sdktrace.SpanExporter, io.Closer, error) {
+	switch cfg.TRACING_EXPORTER {
+	case "none", "":
+		return nil, nil, nil
+	case "stdout":
+		exporter, err := stdouttrace.New(stdouttrace.WithPrettyPrint())
+		return exporter, nil, err
+	case "file":
+		file, err := os.OpenFile(cfg.TRACING_FILE,
+			os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0644)
+		if err != nil {
+			return nil, nil, fmt.Errorf("error with opening traces file: %s", err)
+		}
+		exporter, err := stdouttrace.New(stdouttrace.WithWriter(file))
+		if err != nil {
+			file.Close()
+			return nil, nil, err
+		}
+		return exporter, file, nil
+	case "otlp":
+		opts := []otlptracegrpc.Option{
+			otlptracegrpc.WithEndpoint(cfg.TRACING_OTLP_ENDPOINT),
+		}
+		if cfg.TRACING_OTLP_INSECURE {
+			opts = append(opts, otlptracegrpc.WithInsecure())
+		}
+		exporter, err := otlptracegrpc.New(context.Background(), opts...)
+		return exporter, nil, err
+	}
+	return nil, nil, fmt.Errorf("unknown tracing exporter: %s", cfg.TRACING_EXPORTER)
+}
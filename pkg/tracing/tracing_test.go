@@ -0,0 +1,56 @@
+package tracing
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"travel/config"
+
+	"go.opentelemetry.io/otel"
+)
+
+func TestFileExporter(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "traces.json")
+	shutdown, err := Init(&config.Config{
+		TRACING_EXPORTER:     "file",
+		TRACING_FILE:         path,
+		TRACING_SAMPLE_RATIO: 1,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, span := otel.Tracer("test").Start(context.Background(), "GetStories")
+	span.End()
+	if err := shutdown(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(data), `"Name":"GetStories"`) ||
+		!strings.Contains(string(data), ServiceName) {
+		t.Errorf("expected the span to be written to the file, got %s", data)
+	}
+}
+
+func TestUnknownExporter(t *testing.T) {
+	_, err := Init(&config.Config{TRACING_EXPORTER: "jaeger"})
+	if err == nil {
+		t.Error("expected unknown exporter to fail")
+	}
+}
+
+func TestNoExporter(t *testing.T) {
+	shutdown, err := Init(&config.Config{TRACING_EXPORTER: "none"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := shutdown(context.Background()); err != nil {
+		t.Error(err)
+	}
+}
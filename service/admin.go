@@ -0,0 +1,298 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+	pb "travel/genproto/admin"
+	pbUser "travel/genproto/users"
+	"travel/models"
+	"travel/pkg/media"
+	"travel/storage"
+	"travel/storage/redis"
+)
+
+const (
+	userEventsRetryDelay    = 5 * time.Second
+	userEventsMaxDeliveries = 5
+)
+
+// authorInfo gets the author from the users service, except for the content
+// kept under models.DeletedUserId, which the users service does not know.
+func authorInfo(ctx context.Context, users pbUser.UsersClient, id string) (
+	*pbUser.ResponseGetAuthorInfo, error) {
+	if id == models.DeletedUserId {
+		return &pbUser.ResponseGetAuthorInfo{Id: id, Username: "deleted"}, nil
+	}
+	return users.GetAuthorInfo(ctx, &pbUser.RequestGetAuthorInfo{Id: id})
+}
+
+type Admin struct {
+	pb.UnimplementedAdminServer
+	Logger    *slog.Logger
+	AdminRepo storage.AdminStorage
+	// the children of the stories and itineraries exported are read with
+	// the repos of their services
+	StoriesRepo     storage.StoriesStorage
+	ItinerariesRepo storage.ItinerariesStorage
+	Media           *media.Media
+	Cache           *redis.DetailsRedisClient
+	// how long deleted content is kept before it is purged
+	Retention time.Duration
+	// how many stories or itineraries are purged in one transaction
+	BatchSize int
+}
+
+func NewAdminService(logger *slog.Logger, repo storage.AdminStorage,
+	stories storage.StoriesStorage, itineraries storage.ItinerariesStorage,
+	m *media.Media, cache redis.Cache, retention time.Duration,
+	batchSize int) *Admin {
+	return &Admin{
+		Logger:          logger,
+		AdminRepo:       repo,
+		StoriesRepo:     stories,
+		ItinerariesRepo: itineraries,
+		Media:           m,
+		Cache:           redis.NewDetailsRedisClient(cache),
+		Retention:       retention,
+		BatchSize:       batchSize,
+	}
+}
+
+// ListDeleted lists the deleted stories and itineraries with when they are
+// purged.
+func (a *Admin) ListDeleted(ctx context.Context, in *pb.RequestListDeleted) (
+	*pb.ResponseListDeleted, error) {
+	if in.Kind != "" && in.Kind != models.KindStory && in.Kind != models.KindItinerary {
+		return nil, fmt.Errorf("error: unknown kind: %s", in.Kind)
+	}
+
+	items, err := a.AdminRepo.ListDeleted(ctx, in.Kind, in.Page, in.Limit)
+	if err != nil {
+		a.Logger.ErrorContext(ctx,
+			fmt.Sprintf("error with getting deleted items: %s", err))
+		return nil, err
+	}
+
+	resp := pb.ResponseListDeleted{}
+	for _, val := range *items {
+		resp.Items = append(resp.Items, &pb.DeletedItem{
+			Id:        val.Id,
+			Kind:      val.Kind,
+			Title:     val.Title,
+			AuthorId:  val.AuthorId,
+			DeletedAt: val.DeletedAt.Format(time.RFC3339),
+			PurgeAt:   val.DeletedAt.Add(a.Retention).Format(time.RFC3339),
+		})
+	}
+
+	total, err := a.AdminRepo.CountDeleted(ctx, in.Kind)
+	if err != nil {
+		a.Logger.ErrorContext(ctx,
+			fmt.Sprintf("error with getting total deleted items count: %s", err))
+		return nil, err
+	}
+	resp.Total = int64(total)
+	resp.Page = in.Page
+	resp.Limit = in.Limit
+
+	return &resp, nil
+}
+
+// RunPurge purges the content deleted longer than the retention ago, checking
+// every interval until ctx is done.
+func (a *Admin) RunPurge(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		a.Purge(ctx, time.Now())
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Purge permanently deletes the stories, itineraries and comments deleted
+// before now minus the retention, in batches. Image blobs are deleted once the
+// batch of their stories is committed.
+func (a *Admin) Purge(ctx context.Context, now time.Time) *models.PurgeResult {
+	cutoff := now.Add(-a.Retention)
+	total := models.PurgeResult{}
+
+	for ctx.Err() == nil {
+		res, err := a.AdminRepo.PurgeStories(ctx, cutoff, a.BatchSize)
+		if err != nil {
+			a.Logger.ErrorContext(ctx,
+				fmt.Sprintf("error with purging stories: %s", err))
+			break
+		}
+		if err := a.Media.Delete(ctx, res.Images); err != nil {
+			a.Logger.ErrorContext(ctx,
+				fmt.Sprintf("error with deleting purged images: %s", err))
+		}
+		total.Stories += res.Stories
+		total.Images = append(total.Images, res.Images...)
+		if res.Stories < a.BatchSize {
+			break
+		}
+	}
+
+	for ctx.Err() == nil {
+		res, err := a.AdminRepo.PurgeItineraries(ctx, cutoff, a.BatchSize)
+		if err != nil {
+			a.Logger.ErrorContext(ctx,
+				fmt.Sprintf("error with purging itineraries: %s", err))
+			break
+		}
+		total.Itineraries += res.Itineraries
+		if res.Itineraries < a.BatchSize {
+			break
+		}
+	}
+
+	res, err := a.AdminRepo.PurgeComments(ctx, cutoff)
+	if err != nil {
+		a.Logger.ErrorContext(ctx,
+			fmt.Sprintf("error with purging comments: %s", err))
+	} else {
+		total.Comments = res.Comments
+	}
+
+	if total.Stories+total.Itineraries+total.Comments > 0 {
+		a.Logger.InfoContext(ctx, fmt.Sprintf(
+			"purged %d stories, %d itineraries and %d comments deleted before %s",
+			total.Stories, total.Itineraries, total.Comments,
+			cutoff.Format(time.RFC3339)))
+	}
+	return &total
+}
+
+// PurgeUserContent deletes or anonymizes the content of a deleted user, see
+// requestPurgeUserContent. Purging the same user again affects nothing.
+func (a *Admin) PurgeUserContent(ctx context.Context,
+	in *pb.RequestPurgeUserContent) (*pb.ResponsePurgeUserContent, error) {
+	if in.UserId == "" {
+		return nil, fmt.Errorf("error: user_id is required")
+	}
+	mode := in.Mode
+	if mode == "" {
+		mode = models.PurgeAnonymize
+	}
+	if mode != models.PurgeAnonymize && mode != models.PurgeDelete {
+		return nil, fmt.Errorf("error: unknown mode: %s", mode)
+	}
+
+	res, err := a.purgeUserContent(ctx, in.UserId, mode)
+	if err != nil {
+		return nil, err
+	}
+	return &pb.ResponsePurgeUserContent{
+		Stories:            int64(res.Stories),
+		Itineraries:        int64(res.Itineraries),
+		Comments:           int64(res.Comments),
+		ItineraryComments:  int64(res.ItineraryComments),
+		Likes:              int64(res.Likes),
+		Messages:           int64(res.Messages),
+		UpdatedStories:     int64(res.UpdatedStories),
+		UpdatedItineraries: int64(res.UpdatedItineraries),
+	}, nil
+}
+
+func (a *Admin) purgeUserContent(ctx context.Context, userId, mode string) (
+	*models.UserContentResult, error) {
+	res, err := a.AdminRepo.PurgeUserContent(ctx, userId, mode, time.Now())
+	if err != nil {
+		a.Logger.ErrorContext(ctx,
+			fmt.Sprintf("error with purging content of user %s: %s", userId, err))
+		return nil, err
+	}
+
+	keys := []string{}
+	for _, id := range res.StoryIds {
+		keys = append(keys, redis.StoryKey(id))
+	}
+	for _, id := range res.ItineraryIds {
+		keys = append(keys, redis.ItineraryKey(id))
+	}
+	if len(keys) > 0 {
+		if err := a.Cache.Delete(ctx, keys...); err != nil {
+			a.Logger.ErrorContext(ctx,
+				fmt.Sprintf("error with invalidating purged content cache: %s", err))
+		}
+	}
+
+	a.Logger.InfoContext(ctx, fmt.Sprintf("purged content of user %s (%s): "+
+		"%d stories, %d itineraries, %d comments, %d itinerary comments, "+
+		"%d likes, %d messages", userId, mode, res.Stories, res.Itineraries,
+		res.Comments, res.ItineraryComments, res.Likes, res.Messages))
+	return res, nil
+}
+
+// UserEventSource delivers the events of the users service until they are
+// acknowledged.
+type UserEventSource interface {
+	Read(ctx context.Context) ([]redis.UserEvent, error)
+	Ack(ctx context.Context, id string) error
+	// Park sets the event aside and acknowledges it.
+	Park(ctx context.Context, event redis.UserEvent) error
+	// Retry makes the next Read deliver the events not acknowledged again.
+	Retry()
+}
+
+// ConsumeUserEvents purges the content of the users deleted in the users
+// service as their events arrive, until ctx is done. An event is acknowledged
+// once handled; other event types are acknowledged and ignored. An event still
+// failing after userEventsMaxDeliveries deliveries is parked, so it doesn't
+// hold up the ones after it.
+func (a *Admin) ConsumeUserEvents(ctx context.Context, events UserEventSource,
+	mode string) {
+	for ctx.Err() == nil {
+		batch, err := events.Read(ctx)
+		if err != nil {
+			if ctx.Err() == nil {
+				a.Logger.ErrorContext(ctx,
+					fmt.Sprintf("error with reading user events: %s", err))
+				sleep(ctx, userEventsRetryDelay)
+			}
+			continue
+		}
+
+		for _, event := range batch {
+			if event.Type == redis.UserDeleted && event.UserId == "" {
+				a.Logger.ErrorContext(ctx,
+					fmt.Sprintf("user event %s has no user_id", event.Id))
+			} else if event.Type == redis.UserDeleted {
+				_, err := a.purgeUserContent(ctx, event.UserId, mode)
+				if err != nil && event.Deliveries >= userEventsMaxDeliveries {
+					a.Logger.ErrorContext(ctx, fmt.Sprintf(
+						"parking user event %s after %d deliveries: %s", event.Id,
+						event.Deliveries, err))
+					if err := events.Park(ctx, event); err != nil {
+						a.Logger.ErrorContext(ctx,
+							fmt.Sprintf("error with parking user event: %s", err))
+					}
+					continue
+				}
+				if err != nil {
+					events.Retry()
+					sleep(ctx, userEventsRetryDelay)
+					break
+				}
+			}
+			if err := events.Ack(ctx, event.Id); err != nil {
+				a.Logger.ErrorContext(ctx,
+					fmt.Sprintf("error with acknowledging user event: %s", err))
+			}
+		}
+	}
+}
+
+func sleep(ctx context.Context, d time.Duration) {
+	select {
+	case <-ctx.Done():
+	case <-time.After(d):
+	}
+}
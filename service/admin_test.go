@@ -0,0 +1,319 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+	pbAdmin "travel/genproto/admin"
+	pbInter "travel/genproto/interactions"
+	pbItiner "travel/genproto/itineraries"
+	pb "travel/genproto/stories"
+	pbUser "travel/genproto/users"
+	"travel/models"
+	"travel/storage/redis"
+)
+
+const testRetention = 24 * time.Hour
+
+var otherAuthor = &pbUser.ResponseGetAuthorInfo{
+	Id:       "2c5f2a8e-8f0b-4c4e-a9c5-0d3b7a1e6f11",
+	Username: "abdulaziz",
+}
+
+func NewAdminTestService() (*Admin, *Stories, *fakeAdminRepo) {
+	stories := newFakeStoriesRepo()
+	images, _ := newTestMedia()
+	cache := redis.NewMemoryCache()
+	s := NewContentService(newTestLogger(), stories,
+		newFakeUsers(testAuthor, otherAuthor), cache, images, testGracePeriod)
+	repo := &fakeAdminRepo{
+		stories:      stories,
+		itineraries:  newFakeItinerariesRepo(),
+		interactions: newFakeInteractionsRepo(stories),
+	}
+	return NewAdminService(newTestLogger(), repo, stories, repo.itineraries, images,
+		cache, testRetention, 1), s, repo
+}
+
+func TestListDeleted(t *testing.T) {
+	a, s, repo := NewAdminTestService()
+	stories, itineraries := repo.stories, repo.itineraries
+	ctx := context.Background()
+	storyId, _ := stories.CreateStory(ctx, &pb.RequestCreateStory{
+		AuthorId: testAuthor.Id,
+		Title:    "Go Home",
+	})
+	s.DeleteStory(ctx, &pb.RequestDeleteStory{StoryId: storyId})
+	itineraryId, _ := itineraries.CreateItinerary(ctx,
+		&pbItiner.RequestCreateItineraries{AutherId: testAuthor.Id, Title: "Uzbekistan"})
+	itineraries.DeleteItinerary(ctx, itineraryId)
+
+	resp, err := a.ListDeleted(ctx, &pbAdmin.RequestListDeleted{Limit: 10})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.Total != 2 || len(resp.Items) != 2 {
+		t.Fatalf("expected 2 deleted items, got %v", resp.Items)
+	}
+	if resp.Items[0].Id != itineraryId {
+		t.Errorf("expected the latest deleted first, got %v", resp.Items)
+	}
+	item := resp.Items[1]
+	deletedAt, _ := time.Parse(time.RFC3339, item.DeletedAt)
+	purgeAt, _ := time.Parse(time.RFC3339, item.PurgeAt)
+	if item.Id != storyId || item.Kind != models.KindStory ||
+		purgeAt.Sub(deletedAt) != testRetention {
+		t.Errorf("unexpected deleted story: %v", item)
+	}
+
+	resp, err = a.ListDeleted(ctx, &pbAdmin.RequestListDeleted{
+		Kind:  models.KindItinerary,
+		Limit: 10,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.Total != 1 || resp.Items[0].Id != itineraryId {
+		t.Errorf("expected only the itinerary, got %v", resp.Items)
+	}
+
+	if _, err := a.ListDeleted(ctx, &pbAdmin.RequestListDeleted{Kind: "tip"}); err == nil {
+		t.Error("expected unknown kind to fail")
+	}
+}
+
+func TestPurge(t *testing.T) {
+	a, s, repo := NewAdminTestService()
+	stories, itineraries := repo.stories, repo.itineraries
+	store := a.Media.Store.(*fakeStore)
+	ctx := context.Background()
+
+	ids := []string{}
+	for _, title := range []string{"one", "two", "three"} {
+		resp, err := s.CreateStory(ctx, &pb.RequestCreateStory{
+			AuthorId: testAuthor.Id,
+			Title:    title,
+			Images:   []string{testImage(t)},
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		ids = append(ids, resp.Id)
+	}
+	itineraryId, _ := itineraries.CreateItinerary(ctx,
+		&pbItiner.RequestCreateItineraries{AutherId: testAuthor.Id, Title: "Uzbekistan"})
+	itineraries.DeleteItinerary(ctx, itineraryId)
+	for _, id := range ids[:2] {
+		s.DeleteStory(ctx, &pb.RequestDeleteStory{StoryId: id})
+	}
+
+	// nothing is old enough yet
+	res := a.Purge(ctx, time.Now())
+	if res.Stories != 0 || res.Itineraries != 0 || len(stories.stories) != 3 {
+		t.Fatalf("expected nothing to be purged, got %v", res)
+	}
+
+	// a batch size of 1 purges in several batches
+	res = a.Purge(ctx, time.Now().Add(testRetention+time.Minute))
+	if res.Stories != 2 || res.Itineraries != 1 || len(res.Images) != 2 {
+		t.Errorf("expected 2 stories and 1 itinerary to be purged, got %v", res)
+	}
+	if _, ok := stories.stories[ids[2]]; !ok || len(stories.stories) != 1 {
+		t.Error("expected only the live story to be kept")
+	}
+	if len(store.blobs) != 2 {
+		t.Errorf("expected the purged images to be deleted, got %d blobs", len(store.blobs))
+	}
+	if _, ok := itineraries.itineraries[itineraryId]; ok {
+		t.Error("expected the itinerary to be purged")
+	}
+}
+
+// seedUserContent creates a story and an itinerary of testAuthor, and a story
+// of another user that testAuthor liked and commented on.
+func seedUserContent(t *testing.T, repo *fakeAdminRepo) (own, other string) {
+	t.Helper()
+	ctx := context.Background()
+	own, _ = repo.stories.CreateStory(ctx, &pb.RequestCreateStory{
+		AuthorId: testAuthor.Id,
+		Title:    "Go Home",
+	})
+	other, _ = repo.stories.CreateStory(ctx, &pb.RequestCreateStory{
+		AuthorId: otherAuthor.Id,
+		Title:    "Unforgettable Journey to Bali",
+	})
+	repo.itineraries.CreateItinerary(ctx, &pbItiner.RequestCreateItineraries{
+		AutherId: testAuthor.Id,
+		Title:    "Uzbekistan",
+	})
+
+	interactions := repo.interactions
+	for _, userId := range []string{testAuthor.Id, "someone"} {
+		interactions.LikeStory(ctx, other)
+		interactions.CreateLike(ctx, &pbInter.RequestLikeStory{StoryId: other, UserId: userId})
+		interactions.CreateComment(ctx, &pbInter.RequestCreateComment{
+			StoryId:  other,
+			AuthorId: userId,
+			Content:  "Nice",
+		})
+	}
+	return own, other
+}
+
+func TestPurgeUserContent(t *testing.T) {
+	a, s, repo := NewAdminTestService()
+	ctx := context.Background()
+	own, other := seedUserContent(t, repo)
+	if _, err := s.GetStoryFullInfo(ctx, &pb.RequestGetStoryFullInfo{Id: other}); err != nil {
+		t.Fatal(err)
+	}
+
+	resp, err := a.PurgeUserContent(ctx, &pbAdmin.RequestPurgeUserContent{
+		UserId: testAuthor.Id,
+		Mode:   models.PurgeDelete,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.Stories != 1 || resp.Itineraries != 1 || resp.Comments != 1 ||
+		resp.Likes != 1 || resp.UpdatedStories != 1 {
+		t.Errorf("unexpected purge report: %v", resp)
+	}
+	if _, err := s.GetStoryFullInfo(ctx, &pb.RequestGetStoryFullInfo{Id: own}); err == nil {
+		t.Error("expected the user's story to be deleted")
+	}
+	story, err := s.GetStoryFullInfo(ctx, &pb.RequestGetStoryFullInfo{Id: other})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if story.LikesCount != 1 || story.CommentsCount != 1 {
+		t.Errorf("expected the other story's counters to be updated past the cache, got %d likes and %d comments",
+			story.LikesCount, story.CommentsCount)
+	}
+
+	resp, err = a.PurgeUserContent(ctx, &pbAdmin.RequestPurgeUserContent{
+		UserId: testAuthor.Id,
+		Mode:   models.PurgeDelete,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.Stories+resp.Itineraries+resp.Comments+resp.Likes != 0 {
+		t.Errorf("expected purging again to affect nothing, got %v", resp)
+	}
+}
+
+func TestPurgeUserContentAnonymize(t *testing.T) {
+	a, s, repo := NewAdminTestService()
+	ctx := context.Background()
+	own, other := seedUserContent(t, repo)
+
+	resp, err := a.PurgeUserContent(ctx, &pbAdmin.RequestPurgeUserContent{
+		UserId: testAuthor.Id,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	story, err := s.GetStoryFullInfo(ctx, &pb.RequestGetStoryFullInfo{Id: own})
+	if err != nil || resp.Stories != 1 || resp.Itineraries != 1 {
+		t.Fatalf("expected the user's story to be kept, got %v: %v", resp, err)
+	}
+	if story.Author.Id != models.DeletedUserId {
+		t.Errorf("expected the story to be kept without its author, got %v", story.Author)
+	}
+	comments := repo.interactions.comments[other]
+	if resp.Comments != 1 || len(comments) != 2 ||
+		comments[0].AuthorId != models.DeletedUserId {
+		t.Errorf("expected the comment to be kept without its author, got %v", comments)
+	}
+	if repo.stories.stories[other].info.CommentsCount != 2 {
+		t.Error("expected anonymized comments to be counted")
+	}
+
+	_, err = a.PurgeUserContent(ctx, &pbAdmin.RequestPurgeUserContent{
+		UserId: testAuthor.Id,
+		Mode:   "forget",
+	})
+	if err == nil {
+		t.Error("expected unknown mode to fail")
+	}
+}
+
+// fakeUserEvents delivers its events once, then cancels the consumer.
+type fakeUserEvents struct {
+	events  []redis.UserEvent
+	acked   []string
+	parked  []string
+	retries int
+	cancel  context.CancelFunc
+}
+
+func (f *fakeUserEvents) Read(ctx context.Context) ([]redis.UserEvent, error) {
+	events := f.events
+	f.events = nil
+	if len(events) == 0 {
+		f.cancel()
+	}
+	return events, nil
+}
+
+func (f *fakeUserEvents) Ack(ctx context.Context, id string) error {
+	f.acked = append(f.acked, id)
+	return nil
+}
+
+func (f *fakeUserEvents) Park(ctx context.Context, event redis.UserEvent) error {
+	f.parked = append(f.parked, event.Id)
+	return nil
+}
+
+func (f *fakeUserEvents) Retry() {
+	f.retries++
+	f.cancel()
+}
+
+func TestConsumeUserEvents(t *testing.T) {
+	a, _, repo := NewAdminTestService()
+	own, _ := seedUserContent(t, repo)
+	ctx, cancel := context.WithCancel(context.Background())
+	events := &fakeUserEvents{cancel: cancel, events: []redis.UserEvent{
+		{Id: "1-0", Type: "user.created", UserId: "someone"},
+		{Id: "2-0", Type: redis.UserDeleted, UserId: testAuthor.Id},
+	}}
+
+	a.ConsumeUserEvents(ctx, events, models.PurgeDelete)
+	if len(events.acked) != 2 || events.retries != 0 {
+		t.Errorf("expected both events to be acknowledged, got %v", events.acked)
+	}
+	if !repo.stories.stories[own].deleted {
+		t.Error("expected the deleted user's story to be deleted")
+	}
+}
+
+func TestConsumeUserEventsRetry(t *testing.T) {
+	a, _, repo := NewAdminTestService()
+	repo.err = errors.New("connection refused")
+	ctx, cancel := context.WithCancel(context.Background())
+	events := &fakeUserEvents{cancel: cancel, events: []redis.UserEvent{
+		{Id: "1-0", Type: redis.UserDeleted, UserId: testAuthor.Id, Deliveries: 1},
+	}}
+
+	a.ConsumeUserEvents(ctx, events, models.PurgeDelete)
+	if len(events.acked) != 0 || len(events.parked) != 0 || events.retries != 1 {
+		t.Errorf("expected the failed event to be retried, got %d acks and %d retries",
+			len(events.acked), events.retries)
+	}
+
+	ctx, cancel = context.WithCancel(context.Background())
+	events = &fakeUserEvents{cancel: cancel, events: []redis.UserEvent{
+		{Id: "1-0", Type: redis.UserDeleted, UserId: testAuthor.Id,
+			Deliveries: userEventsMaxDeliveries},
+		{Id: "2-0", Type: "user.created", UserId: "someone"},
+	}}
+	a.ConsumeUserEvents(ctx, events, models.PurgeDelete)
+	if len(events.parked) != 1 || len(events.acked) != 1 || events.retries != 0 {
+		t.Errorf("expected the event to be parked after %d deliveries, got %v parked",
+			userEventsMaxDeliveries, events.parked)
+	}
+}
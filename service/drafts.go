@@ -0,0 +1,284 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+	pb "travel/genproto/stories"
+	pbUser "travel/genproto/users"
+	"travel/models"
+	"travel/storage/redis"
+)
+
+// SaveDraft creates a draft, or updates one of the author's drafts or
+// scheduled stories when in.Id is set.
+func (s *Stories) SaveDraft(ctx context.Context, in *pb.RequestSaveDraft) (
+	*pb.ResponseSaveDraft, error) {
+	id := in.Id
+	if id == "" {
+		var err error
+		id, err = s.createDraft(ctx, in)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		status, err := s.storyStatus(ctx, in.Id, in.AuthorId)
+		if err != nil {
+			return nil, err
+		}
+		if status != models.StoryDraft && status != models.StoryScheduled {
+			return nil, fmt.Errorf("error: story is already %s", status)
+		}
+
+		_, err = s.EditStory(ctx, &pb.RequestEditStory{
+			Id:             in.Id,
+			Title:          in.Title,
+			Content:        in.Content,
+			Location:       in.Location,
+			Tags:           in.Tags,
+			AddImages:      in.AddImages,
+			RemoveImageIds: in.RemoveImageIds,
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	draft, err := s.StoriesRepo.GetDraft(ctx, id)
+	if err != nil {
+		s.Logger.ErrorContext(ctx, fmt.Sprintf("error with getting draft: %s", err))
+		return nil, err
+	}
+	res, err := s.toPbDraft(ctx, draft)
+	if err != nil {
+		return nil, err
+	}
+	return &pb.ResponseSaveDraft{Draft: res}, nil
+}
+
+func (s *Stories) createDraft(ctx context.Context, in *pb.RequestSaveDraft) (
+	string, error) {
+	valid, err := s.UserClient.ValidateUser(ctx, &pbUser.RequestGetProfile{Id: in.AuthorId})
+	if err != nil || !valid.Success {
+		s.Logger.ErrorContext(ctx, fmt.Sprintf("error with validating user: %s", err))
+		return "", fmt.Errorf("error: invalid userID: %s", err)
+	}
+
+	images, err := s.saveImages(ctx, in.AddImages)
+	if err != nil {
+		s.Logger.ErrorContext(ctx, fmt.Sprintf("error with saving images: %s", err))
+		return "", err
+	}
+
+	id, err := s.StoriesRepo.CreateDraft(ctx, &pb.RequestCreateStory{
+		AuthorId: in.AuthorId,
+		Title:    in.Title,
+		Content:  in.Content,
+		Location: in.Location,
+	})
+	if err != nil {
+		s.Logger.ErrorContext(ctx, fmt.Sprintf("error with creating draft: %s", err))
+		s.deleteImages(ctx, images)
+		return "", err
+	}
+
+	err = s.StoriesRepo.CreateStoryImages(ctx, id, images)
+	if err != nil {
+		s.Logger.ErrorContext(ctx,
+			fmt.Sprintf("error with creating story images: %s", err))
+		s.deleteImages(ctx, images)
+		return "", err
+	}
+
+	err = s.StoriesRepo.CreateStoryTags(ctx, id, &in.Tags)
+	if err != nil {
+		s.Logger.ErrorContext(ctx,
+			fmt.Sprintf("error with creating story tags: %s", err))
+		return "", err
+	}
+	return id, nil
+}
+
+// storyStatus returns the status of the story after checking it belongs to
+// authorId.
+func (s *Stories) storyStatus(ctx context.Context, id, authorId string) (
+	string, error) {
+	author, status, err := s.StoriesRepo.GetStoryStatus(ctx, id)
+	if err != nil {
+		s.Logger.ErrorContext(ctx, fmt.Sprintf("error with getting story status: %s", err))
+		return "", err
+	}
+	if author != authorId {
+		return "", fmt.Errorf("error: story doesn't belong to the author")
+	}
+	return status, nil
+}
+
+func (s *Stories) toPbDraft(ctx context.Context, draft *models.StoryFullInfo) (
+	*pb.Draft, error) {
+	tags, err := s.StoriesRepo.GetStoryTags(ctx, draft.Id)
+	if err != nil {
+		return nil, err
+	}
+	images, err := s.StoriesRepo.GetStoryImages(ctx, draft.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.Draft{
+		Id:        draft.Id,
+		Title:     draft.Title,
+		Content:   draft.Content,
+		Location:  draft.Location,
+		Tags:      *tags,
+		Images:    toPbImages(*images),
+		Status:    draft.Status,
+		PublishAt: draft.PublishAt,
+		CreatedAt: draft.CreatedAt,
+		UpdatedAt: draft.UpdatedAt,
+	}, nil
+}
+
+// ListDrafts lists the author's stories not published yet.
+func (s *Stories) ListDrafts(ctx context.Context, in *pb.RequestListDrafts) (
+	*pb.ResponseListDrafts, error) {
+	drafts, err := s.StoriesRepo.GetDrafts(ctx, in.AuthorId, in.Page, in.Limit)
+	if err != nil {
+		s.Logger.ErrorContext(ctx, fmt.Sprintf("error with getting drafts: %s", err))
+		return nil, err
+	}
+
+	resp := pb.ResponseListDrafts{}
+	for _, val := range *drafts {
+		draft, err := s.toPbDraft(ctx, &val)
+		if err != nil {
+			s.Logger.ErrorContext(ctx, fmt.Sprintf("error with getting draft: %s", err))
+			return nil, err
+		}
+		resp.Drafts = append(resp.Drafts, draft)
+	}
+
+	total, err := s.StoriesRepo.CountDrafts(ctx, in.AuthorId)
+	if err != nil {
+		s.Logger.ErrorContext(ctx,
+			fmt.Sprintf("error with getting total drafts count: %s", err))
+		return nil, err
+	}
+	resp.Total = int64(total)
+	resp.Page = in.Page
+	resp.Limit = in.Limit
+
+	return &resp, nil
+}
+
+// PublishStory publishes a draft now, or schedules it when in.PublishAt is in
+// the future. Scheduled stories can be published now or rescheduled.
+func (s *Stories) PublishStory(ctx context.Context, in *pb.RequestPublishStory) (
+	*pb.ResponsePublishStory, error) {
+	now := time.Now()
+	publishAt := now
+	if in.PublishAt != "" {
+		var err error
+		publishAt, err = time.Parse(time.RFC3339, in.PublishAt)
+		if err != nil {
+			return nil, fmt.Errorf("error: invalid publish_at: %s", err)
+		}
+	}
+	status := models.StoryPublished
+	if publishAt.After(now) {
+		status = models.StoryScheduled
+	}
+
+	current, err := s.storyStatus(ctx, in.Id, in.AuthorId)
+	if err != nil {
+		return nil, err
+	}
+	if current != models.StoryDraft && current != models.StoryScheduled {
+		return nil, fmt.Errorf("error: story is already %s", current)
+	}
+
+	err = s.StoriesRepo.PublishStory(ctx, in.Id, status, publishAt)
+	if err != nil {
+		s.Logger.ErrorContext(ctx, fmt.Sprintf("error with publishing story: %s", err))
+		return nil, err
+	}
+
+	err = s.Cache.Delete(ctx, redis.StoryKey(in.Id))
+	if err != nil {
+		s.Logger.ErrorContext(ctx,
+			fmt.Sprintf("error with invalidating story cache: %s", err))
+	}
+
+	return &pb.ResponsePublishStory{
+		Id:        in.Id,
+		Status:    status,
+		PublishAt: publishAt.Format(time.RFC3339),
+	}, nil
+}
+
+// ArchiveStory takes a published story of the author down. It stays in the
+// author's export and can't be published again.
+func (s *Stories) ArchiveStory(ctx context.Context, in *pb.RequestArchiveStory) (
+	*pb.ResponseArchiveStory, error) {
+	current, err := s.storyStatus(ctx, in.Id, in.AuthorId)
+	if err != nil {
+		return nil, err
+	}
+	if current != models.StoryPublished {
+		return nil, fmt.Errorf("error: story is %s, not published", current)
+	}
+
+	err = s.StoriesRepo.ArchiveStory(ctx, in.Id)
+	if err != nil {
+		s.Logger.ErrorContext(ctx, fmt.Sprintf("error with archiving story: %s", err))
+		return nil, err
+	}
+
+	err = s.Cache.Delete(ctx, redis.StoryKey(in.Id))
+	if err != nil {
+		s.Logger.ErrorContext(ctx,
+			fmt.Sprintf("error with invalidating story cache: %s", err))
+	}
+
+	return &pb.ResponseArchiveStory{
+		Id:     in.Id,
+		Status: models.StoryArchived,
+	}, nil
+}
+
+// RunScheduler publishes the scheduled stories when they are due, checking
+// every interval until ctx is done.
+func (s *Stories) RunScheduler(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		s.PublishDueStories(ctx, time.Now())
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// PublishDueStories publishes the stories scheduled by now.
+func (s *Stories) PublishDueStories(ctx context.Context, now time.Time) {
+	ids, err := s.StoriesRepo.PublishDueStories(ctx, now)
+	if err != nil {
+		s.Logger.ErrorContext(ctx,
+			fmt.Sprintf("error with publishing scheduled stories: %s", err))
+		return
+	}
+
+	for _, id := range *ids {
+		err := s.Cache.Delete(ctx, redis.StoryKey(id))
+		if err != nil {
+			s.Logger.ErrorContext(ctx,
+				fmt.Sprintf("error with invalidating story cache: %s", err))
+		}
+	}
+	if len(*ids) > 0 {
+		s.Logger.InfoContext(ctx,
+			fmt.Sprintf("published %d scheduled stories", len(*ids)))
+	}
+}
@@ -0,0 +1,196 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+	pb "travel/genproto/stories"
+	"travel/models"
+)
+
+func TestSaveDraft(t *testing.T) {
+	s, _, _ := NewStoriesService()
+	ctx := context.Background()
+
+	saved, err := s.SaveDraft(ctx, &pb.RequestSaveDraft{
+		AuthorId:  testAuthor.Id,
+		Title:     "Unforgettable Journey to Bali",
+		Tags:      []string{"beach"},
+		AddImages: []string{testImage(t)},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	draft := saved.Draft
+	if draft.Status != models.StoryDraft || len(draft.Images) != 1 || len(draft.Tags) != 1 {
+		t.Errorf("unexpected draft: %v", draft)
+	}
+
+	stories, _ := s.GetStories(ctx, &pb.RequestGetStories{Limit: 10})
+	if len(stories.Stories) != 0 || stories.Total != 0 {
+		t.Errorf("expected drafts not to be listed, got %v", stories)
+	}
+	if _, err := s.GetStoryFullInfo(ctx, &pb.RequestGetStoryFullInfo{Id: draft.Id}); err == nil {
+		t.Error("expected drafts not to be served")
+	}
+
+	saved, err = s.SaveDraft(ctx, &pb.RequestSaveDraft{
+		Id:       draft.Id,
+		AuthorId: testAuthor.Id,
+		Title:    "Journey to Bali",
+		Tags:     []string{"beach", "food"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if saved.Draft.Title != "Journey to Bali" || len(saved.Draft.Tags) != 2 ||
+		len(saved.Draft.Images) != 1 {
+		t.Errorf("unexpected updated draft: %v", saved.Draft)
+	}
+	revisions, err := s.ListStoryRevisions(ctx, &pb.RequestListStoryRevisions{
+		AuthorId: testAuthor.Id,
+		StoryId:  draft.Id,
+		Limit:    10,
+	})
+	if err != nil || revisions.Total != 0 {
+		t.Errorf("expected autosaves to keep no revisions, got %v, %v", revisions, err)
+	}
+
+	_, err = s.SaveDraft(ctx, &pb.RequestSaveDraft{Id: draft.Id, AuthorId: "other"})
+	if err == nil {
+		t.Error("expected other authors not to edit the draft")
+	}
+}
+
+func TestListDrafts(t *testing.T) {
+	s, repo, _ := NewStoriesService()
+	ctx := context.Background()
+	repo.CreateStory(ctx, &pb.RequestCreateStory{AuthorId: testAuthor.Id, Title: "Published"})
+	repo.CreateDraft(ctx, &pb.RequestCreateStory{AuthorId: "other", Title: "Other"})
+	for _, title := range []string{"one", "two", "three"} {
+		s.SaveDraft(ctx, &pb.RequestSaveDraft{AuthorId: testAuthor.Id, Title: title})
+	}
+
+	resp, err := s.ListDrafts(ctx, &pb.RequestListDrafts{
+		AuthorId: testAuthor.Id,
+		Limit:    2,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(resp.Drafts) != 2 || resp.Total != 3 {
+		t.Errorf("expected 2 of the author's 3 drafts, got %d of %d",
+			len(resp.Drafts), resp.Total)
+	}
+}
+
+func TestPublishStory(t *testing.T) {
+	s, _, _ := NewStoriesService()
+	ctx := context.Background()
+	saved, err := s.SaveDraft(ctx, &pb.RequestSaveDraft{
+		AuthorId: testAuthor.Id,
+		Title:    "Go Home",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	id := saved.Draft.Id
+
+	_, err = s.PublishStory(ctx, &pb.RequestPublishStory{Id: id, AuthorId: "other"})
+	if err == nil {
+		t.Error("expected other authors not to publish the draft")
+	}
+	_, err = s.PublishStory(ctx, &pb.RequestPublishStory{
+		Id:        id,
+		AuthorId:  testAuthor.Id,
+		PublishAt: "tomorrow",
+	})
+	if err == nil {
+		t.Error("expected an invalid publish_at to fail")
+	}
+
+	resp, err := s.PublishStory(ctx, &pb.RequestPublishStory{Id: id, AuthorId: testAuthor.Id})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.Status != models.StoryPublished {
+		t.Errorf("expected the story to be published, got %s", resp.Status)
+	}
+	if _, err := s.GetStoryFullInfo(ctx, &pb.RequestGetStoryFullInfo{Id: id}); err != nil {
+		t.Errorf("expected the published story to be served, got %s", err)
+	}
+
+	_, err = s.PublishStory(ctx, &pb.RequestPublishStory{Id: id, AuthorId: testAuthor.Id})
+	if err == nil {
+		t.Error("expected publishing twice to fail")
+	}
+}
+
+func TestArchiveStory(t *testing.T) {
+	s, _, _ := NewStoriesService()
+	ctx := context.Background()
+	saved, _ := s.SaveDraft(ctx, &pb.RequestSaveDraft{
+		AuthorId: testAuthor.Id,
+		Title:    "Go Home",
+	})
+	id := saved.Draft.Id
+
+	_, err := s.ArchiveStory(ctx, &pb.RequestArchiveStory{Id: id, AuthorId: testAuthor.Id})
+	if err == nil {
+		t.Error("expected drafts not to be archived")
+	}
+	s.PublishStory(ctx, &pb.RequestPublishStory{Id: id, AuthorId: testAuthor.Id})
+	_, err = s.ArchiveStory(ctx, &pb.RequestArchiveStory{Id: id, AuthorId: "other"})
+	if err == nil {
+		t.Error("expected other authors not to archive the story")
+	}
+
+	resp, err := s.ArchiveStory(ctx, &pb.RequestArchiveStory{Id: id, AuthorId: testAuthor.Id})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.Status != models.StoryArchived {
+		t.Errorf("expected the story to be archived, got %s", resp.Status)
+	}
+	if _, err := s.GetStoryFullInfo(ctx, &pb.RequestGetStoryFullInfo{Id: id}); err == nil {
+		t.Error("expected archived stories not to be served")
+	}
+	_, err = s.PublishStory(ctx, &pb.RequestPublishStory{Id: id, AuthorId: testAuthor.Id})
+	if err == nil {
+		t.Error("expected archived stories not to be published again")
+	}
+}
+
+func TestScheduledPublishing(t *testing.T) {
+	s, _, _ := NewStoriesService()
+	ctx := context.Background()
+	saved, _ := s.SaveDraft(ctx, &pb.RequestSaveDraft{
+		AuthorId: testAuthor.Id,
+		Title:    "Go Home",
+	})
+	publishAt := time.Now().Add(time.Hour).Truncate(time.Second)
+
+	resp, err := s.PublishStory(ctx, &pb.RequestPublishStory{
+		Id:        saved.Draft.Id,
+		AuthorId:  testAuthor.Id,
+		PublishAt: publishAt.Format(time.RFC3339),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.Status != models.StoryScheduled {
+		t.Fatalf("expected the story to be scheduled, got %s", resp.Status)
+	}
+
+	s.PublishDueStories(ctx, time.Now())
+	stories, _ := s.GetStories(ctx, &pb.RequestGetStories{Limit: 10})
+	if len(stories.Stories) != 0 {
+		t.Fatal("expected the story not to be published before it is due")
+	}
+
+	s.PublishDueStories(ctx, publishAt)
+	stories, _ = s.GetStories(ctx, &pb.RequestGetStories{Limit: 10})
+	if len(stories.Stories) != 1 {
+		t.Error("expected the story to be published when due")
+	}
+}
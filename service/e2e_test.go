@@ -0,0 +1,109 @@
+package service
+
+import (
+	"context"
+	"net"
+	"testing"
+	pbInter "travel/genproto/interactions"
+	pb "travel/genproto/stories"
+	pbUser "travel/genproto/users"
+	"travel/pkg/fakeusers"
+	"travel/storage/redis"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+// NewContentClients serves the content services over bufconn, backed by the
+// in-process fake users service, and returns clients connected to them.
+func NewContentClients(t *testing.T) (pb.StoriesClient, pbInter.InteractionsClient) {
+	users, stopUsers, err := fakeusers.Listen(fakeusers.NewServer(
+		&pbUser.ResponseGetProfile{
+			Id:       testAuthor.Id,
+			Username: testAuthor.Username,
+			FullName: testAuthor.FullName,
+		}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(stopUsers)
+
+	stories := newFakeStoriesRepo()
+	cache := redis.NewMemoryCache()
+	images, _ := newTestMedia()
+	server := grpc.NewServer()
+	pb.RegisterStoriesServer(server, NewContentService(newTestLogger(), stories,
+		users, cache, images, testGracePeriod))
+	pbInter.RegisterInteractionsServer(server, NewInterationsService(newTestLogger(),
+		newFakeInteractionsRepo(stories), users, cache))
+
+	lis := bufconn.Listen(1024 * 1024)
+	go server.Serve(lis)
+	t.Cleanup(server.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return lis.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	return pb.NewStoriesClient(conn), pbInter.NewInteractionsClient(conn)
+}
+
+func TestStoryEndToEnd(t *testing.T) {
+	stories, interactions := NewContentClients(t)
+	ctx := context.Background()
+
+	story, err := stories.CreateStory(ctx, &pb.RequestCreateStory{
+		AuthorId: testAuthor.Id,
+		Title:    "Unforgettable Journey to Bali",
+		Tags:     []string{"beach"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = interactions.CreateComment(ctx, &pbInter.RequestCreateComment{
+		StoryId:  story.Id,
+		AuthorId: testAuthor.Id,
+		Content:  "I have never seen story like this",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = interactions.LikeStory(ctx, &pbInter.RequestLikeStory{
+		StoryId: story.Id,
+		UserId:  testAuthor.Id,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	full, err := stories.GetStoryFullInfo(ctx, &pb.RequestGetStoryFullInfo{Id: story.Id})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if full.Author.FullName != testAuthor.FullName || full.LikesCount != 1 {
+		t.Errorf("unexpected story: %v", full)
+	}
+
+	comments, err := interactions.GetComments(ctx, &pbInter.RequestGetComments{
+		StoryId: story.Id,
+		Limit:   10,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(comments.Comments) != 1 {
+		t.Errorf("expected 1 comment, got %d", len(comments.Comments))
+	}
+
+	_, err = stories.CreateStory(ctx, &pb.RequestCreateStory{AuthorId: "unknown"})
+	if err == nil {
+		t.Error("expected unknown author to be rejected")
+	}
+}
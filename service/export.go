@@ -0,0 +1,361 @@
+package service
+
+import (
+	"archive/zip"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	pb "travel/genproto/admin"
+)
+
+const exportChunkSize = 64 << 10
+
+// The records of an export, one JSON object per line. Images are exported by
+// their URLs.
+
+type exportImage struct {
+	Url          string `json:"url"`
+	ThumbnailUrl string `json:"thumbnail_url"`
+	ContentType  string `json:"content_type"`
+	Width        int    `json:"width"`
+	Height       int    `json:"height"`
+}
+
+type exportStory struct {
+	Id        string        `json:"id"`
+	Title     string        `json:"title"`
+	Content   string        `json:"content"`
+	Location  string        `json:"location"`
+	Status    string        `json:"status"`
+	Tags      []string      `json:"tags"`
+	Images    []exportImage `json:"images"`
+	PublishAt string        `json:"publish_at,omitempty"`
+	CreatedAt string        `json:"created_at"`
+	UpdatedAt string        `json:"updated_at"`
+	DeletedAt string        `json:"deleted_at,omitempty"`
+}
+
+type exportDestination struct {
+	Name       string   `json:"name"`
+	StartDate  string   `json:"start_date"`
+	EndDate    string   `json:"end_date"`
+	Latitude   *float64 `json:"latitude,omitempty"`
+	Longitude  *float64 `json:"longitude,omitempty"`
+	Activities []string `json:"activities"`
+}
+
+type exportItinerary struct {
+	Id           string              `json:"id"`
+	Title        string              `json:"title"`
+	Description  string              `json:"description"`
+	StartDate    string              `json:"start_date"`
+	EndDate      string              `json:"end_date"`
+	Destinations []exportDestination `json:"destinations"`
+	CreatedAt    string              `json:"created_at"`
+	UpdatedAt    string              `json:"updated_at"`
+	DeletedAt    string              `json:"deleted_at,omitempty"`
+}
+
+type exportComment struct {
+	Id          string `json:"id"`
+	StoryId     string `json:"story_id,omitempty"`
+	ItineraryId string `json:"itinerary_id,omitempty"`
+	Content     string `json:"content"`
+	CreatedAt   string `json:"created_at"`
+	DeletedAt   string `json:"deleted_at,omitempty"`
+}
+
+type exportLike struct {
+	StoryId   string `json:"story_id"`
+	CreatedAt string `json:"created_at"`
+}
+
+type exportMessage struct {
+	Id          string `json:"id"`
+	RecipientId string `json:"recipient_id"`
+	Content     string `json:"content"`
+	CreatedAt   string `json:"created_at"`
+	DeletedAt   string `json:"deleted_at,omitempty"`
+}
+
+// ExportUserContent streams everything the user authored: their stories with
+// tags and images, itineraries with destinations and activities, comments,
+// likes and sent messages, deleted or not. Images are exported as their URLs
+// only, the files are not included. As JSON lines every record is
+// {"type": ..., "data": ...}; the ZIP archive has a JSON lines file per type.
+func (a *Admin) ExportUserContent(in *pb.RequestExportUserContent,
+	stream pb.Admin_ExportUserContentServer) error {
+	ctx := stream.Context()
+	if in.UserId == "" {
+		return fmt.Errorf("error: user_id is required")
+	}
+
+	chunks := &chunkWriter{stream: stream}
+	var out exportWriter
+	switch in.Format {
+	case "", "jsonl":
+		chunks.first = &pb.ExportChunk{
+			Filename:    in.UserId + ".jsonl",
+			ContentType: "application/jsonl",
+		}
+		out = &jsonlWriter{enc: json.NewEncoder(chunks)}
+	case "zip":
+		chunks.first = &pb.ExportChunk{
+			Filename:    in.UserId + ".zip",
+			ContentType: "application/zip",
+		}
+		out = &zipWriter{zip: zip.NewWriter(chunks)}
+	default:
+		return fmt.Errorf("error: unknown format: %s", in.Format)
+	}
+
+	err := a.exportUserContent(ctx, in.UserId, out)
+	if err == nil {
+		err = out.Close()
+	}
+	if err == nil {
+		err = chunks.Flush()
+	}
+	if err != nil {
+		a.Logger.ErrorContext(ctx,
+			fmt.Sprintf("error with exporting content of user %s: %s", in.UserId, err))
+		return err
+	}
+	return nil
+}
+
+func (a *Admin) exportUserContent(ctx context.Context, userId string,
+	out exportWriter) error {
+	stories, err := a.AdminRepo.GetUserStories(ctx, userId)
+	if err != nil {
+		return fmt.Errorf("error with getting stories: %s", err)
+	}
+	if err := out.Begin("story"); err != nil {
+		return err
+	}
+	for _, val := range *stories {
+		tags, err := a.StoriesRepo.GetStoryTags(ctx, val.Id)
+		if err != nil {
+			return fmt.Errorf("error with getting story tags: %s", err)
+		}
+		images, err := a.StoriesRepo.GetStoryImages(ctx, val.Id)
+		if err != nil {
+			return fmt.Errorf("error with getting story images: %s", err)
+		}
+		story := exportStory{
+			Id:        val.Id,
+			Title:     val.Title,
+			Content:   val.Content,
+			Location:  val.Location,
+			Status:    val.Status,
+			Tags:      *tags,
+			Images:    []exportImage{},
+			PublishAt: val.PublishAt,
+			CreatedAt: val.CreatedAt,
+			UpdatedAt: val.UpdatedAt,
+			DeletedAt: val.DeletedAt,
+		}
+		for _, img := range *images {
+			story.Images = append(story.Images, exportImage{
+				Url:          img.Url,
+				ThumbnailUrl: img.ThumbnailUrl,
+				ContentType:  img.ContentType,
+				Width:        img.Width,
+				Height:       img.Height,
+			})
+		}
+		if err := out.Write(story); err != nil {
+			return err
+		}
+	}
+
+	itineraries, err := a.AdminRepo.GetUserItineraries(ctx, userId)
+	if err != nil {
+		return fmt.Errorf("error with getting itineraries: %s", err)
+	}
+	if err := out.Begin("itinerary"); err != nil {
+		return err
+	}
+	for _, val := range *itineraries {
+		destinations, err := a.ItinerariesRepo.GetItinerariesDestinations(ctx, val.Id)
+		if err != nil {
+			return fmt.Errorf("error with getting itinerary destinations: %s", err)
+		}
+		itinerary := exportItinerary{
+			Id:           val.Id,
+			Title:        val.Title,
+			Description:  val.Description,
+			StartDate:    val.StartDate,
+			EndDate:      val.EndDate,
+			Destinations: []exportDestination{},
+			CreatedAt:    val.CreatedAt,
+			UpdatedAt:    val.UpdatedAt,
+			DeletedAt:    val.DeletedAt,
+		}
+		for _, des := range *destinations {
+			destination := exportDestination{
+				Name:       des.Name,
+				StartDate:  des.StartDate,
+				EndDate:    des.EndDate,
+				Latitude:   des.Latitude,
+				Longitude:  des.Longitude,
+				Activities: []string{},
+			}
+			for _, act := range des.Activities {
+				destination.Activities = append(destination.Activities, act.Activity)
+			}
+			itinerary.Destinations = append(itinerary.Destinations, destination)
+		}
+		if err := out.Write(itinerary); err != nil {
+			return err
+		}
+	}
+
+	comments, err := a.AdminRepo.GetUserComments(ctx, userId)
+	if err != nil {
+		return fmt.Errorf("error with getting comments: %s", err)
+	}
+	if err := out.Begin("comment"); err != nil {
+		return err
+	}
+	for _, val := range *comments {
+		err := out.Write(exportComment{
+			Id:          val.Id,
+			StoryId:     val.StoryId,
+			ItineraryId: val.ItineraryId,
+			Content:     val.Content,
+			CreatedAt:   val.CreatedAt,
+			DeletedAt:   val.DeletedAt,
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	likes, err := a.AdminRepo.GetUserLikes(ctx, userId)
+	if err != nil {
+		return fmt.Errorf("error with getting likes: %s", err)
+	}
+	if err := out.Begin("like"); err != nil {
+		return err
+	}
+	for _, val := range *likes {
+		if err := out.Write(exportLike(val)); err != nil {
+			return err
+		}
+	}
+
+	messages, err := a.AdminRepo.GetUserMessages(ctx, userId)
+	if err != nil {
+		return fmt.Errorf("error with getting messages: %s", err)
+	}
+	if err := out.Begin("message"); err != nil {
+		return err
+	}
+	for _, val := range *messages {
+		if err := out.Write(exportMessage(val)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// exportWriter writes the records of an export, grouped by their type.
+type exportWriter interface {
+	Begin(kind string) error
+	Write(record any) error
+	Close() error
+}
+
+type jsonlWriter struct {
+	enc  *json.Encoder
+	kind string
+}
+
+func (w *jsonlWriter) Begin(kind string) error {
+	w.kind = kind
+	return nil
+}
+
+func (w *jsonlWriter) Write(record any) error {
+	return w.enc.Encode(struct {
+		Type string `json:"type"`
+		Data any    `json:"data"`
+	}{w.kind, record})
+}
+
+func (w *jsonlWriter) Close() error {
+	return nil
+}
+
+// zipWriter writes the records of each type to their own file, e.g.
+// stories.jsonl, in the archive.
+type zipWriter struct {
+	zip *zip.Writer
+	enc *json.Encoder
+}
+
+func (w *zipWriter) Begin(kind string) error {
+	name := kind + "s.jsonl"
+	if kind == "story" {
+		name = "stories.jsonl"
+	} else if kind == "itinerary" {
+		name = "itineraries.jsonl"
+	}
+	file, err := w.zip.Create(name)
+	if err != nil {
+		return err
+	}
+	w.enc = json.NewEncoder(file)
+	return nil
+}
+
+func (w *zipWriter) Write(record any) error {
+	return w.enc.Encode(record)
+}
+
+func (w *zipWriter) Close() error {
+	return w.zip.Close()
+}
+
+// chunkWriter sends what is written to it as chunks of exportChunkSize
+// bytes, the first one carrying the file name and content type.
+type chunkWriter struct {
+	stream pb.Admin_ExportUserContentServer
+	first  *pb.ExportChunk
+	buf    []byte
+}
+
+func (w *chunkWriter) Write(p []byte) (int, error) {
+	w.buf = append(w.buf, p...)
+	for len(w.buf) >= exportChunkSize {
+		if err := w.send(w.buf[:exportChunkSize]); err != nil {
+			return 0, err
+		}
+		w.buf = w.buf[exportChunkSize:]
+	}
+	return len(p), nil
+}
+
+// Flush sends what is left, and the first chunk even when nothing was
+// written.
+func (w *chunkWriter) Flush() error {
+	if len(w.buf) == 0 && w.first == nil {
+		return nil
+	}
+	err := w.send(w.buf)
+	w.buf = nil
+	return err
+}
+
+func (w *chunkWriter) send(data []byte) error {
+	chunk := &pb.ExportChunk{}
+	if w.first != nil {
+		chunk, w.first = w.first, nil
+	}
+	chunk.Data = append([]byte{}, data...)
+	return w.stream.Send(chunk)
+}
+
+var _ io.Writer = (*chunkWriter)(nil)
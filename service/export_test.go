@@ -0,0 +1,175 @@
+package service
+
+import (
+	"archive/zip"
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"testing"
+	pbAdmin "travel/genproto/admin"
+	pbItiner "travel/genproto/itineraries"
+
+	"google.golang.org/grpc"
+)
+
+// fakeExportStream collects the chunks sent by ExportUserContent.
+type fakeExportStream struct {
+	grpc.ServerStream
+	chunks []*pbAdmin.ExportChunk
+}
+
+func (f *fakeExportStream) Context() context.Context {
+	return context.Background()
+}
+
+func (f *fakeExportStream) Send(chunk *pbAdmin.ExportChunk) error {
+	f.chunks = append(f.chunks, chunk)
+	return nil
+}
+
+func (f *fakeExportStream) data() []byte {
+	data := []byte{}
+	for _, chunk := range f.chunks {
+		data = append(data, chunk.Data...)
+	}
+	return data
+}
+
+type exportRecord struct {
+	Type string          `json:"type"`
+	Data json.RawMessage `json:"data"`
+}
+
+func TestExportUserContent(t *testing.T) {
+	a, _, repo := NewAdminTestService()
+	ctx := context.Background()
+	own, other := seedUserContent(t, repo)
+	repo.stories.CreateStoryTags(ctx, own, &[]string{"home"})
+	lat, lon := 39.65, 66.96
+	itineraries, _ := repo.itineraries.GetAllItineraries(ctx,
+		&pbItiner.RequestGetAllItineraries{})
+	repo.itineraries.addDestinations((*itineraries)[0].Id,
+		[]*pbItiner.Destination{{
+			Name:       "Samarkand",
+			StartDate:  "2024-07-01",
+			EndDate:    "2024-07-03",
+			Latitude:   &lat,
+			Longitude:  &lon,
+			Activities: []string{"Registan"},
+		}})
+	repo.stories.DeleteStory(ctx, own)
+
+	stream := &fakeExportStream{}
+	err := a.ExportUserContent(&pbAdmin.RequestExportUserContent{
+		UserId: testAuthor.Id,
+	}, stream)
+	if err != nil {
+		t.Fatal(err)
+	}
+	first := stream.chunks[0]
+	if first.Filename != testAuthor.Id+".jsonl" || first.ContentType == "" {
+		t.Errorf("expected the first chunk to name the file, got %v", first)
+	}
+
+	records := map[string][]json.RawMessage{}
+	lines := bufio.NewScanner(bytes.NewReader(stream.data()))
+	for lines.Scan() {
+		record := exportRecord{}
+		if err := json.Unmarshal(lines.Bytes(), &record); err != nil {
+			t.Fatal(err)
+		}
+		records[record.Type] = append(records[record.Type], record.Data)
+	}
+	if len(records["story"]) != 1 || len(records["itinerary"]) != 1 ||
+		len(records["comment"]) != 1 || len(records["like"]) != 1 {
+		t.Fatalf("expected only the user's content, got %v", records)
+	}
+
+	story := exportStory{}
+	json.Unmarshal(records["story"][0], &story)
+	if story.Id != own || story.DeletedAt == "" || len(story.Tags) != 1 {
+		t.Errorf("expected the deleted story with its tags, got %v", story)
+	}
+	itinerary := exportItinerary{}
+	json.Unmarshal(records["itinerary"][0], &itinerary)
+	if len(itinerary.Destinations) != 1 ||
+		itinerary.Destinations[0].Activities[0] != "Registan" ||
+		*itinerary.Destinations[0].Latitude != lat {
+		t.Errorf("expected the itinerary with its destinations, got %v", itinerary)
+	}
+	like := exportLike{}
+	json.Unmarshal(records["like"][0], &like)
+	if like.StoryId != other {
+		t.Errorf("expected the liked story, got %v", like)
+	}
+}
+
+func TestExportUserContentZip(t *testing.T) {
+	a, _, repo := NewAdminTestService()
+	seedUserContent(t, repo)
+
+	stream := &fakeExportStream{}
+	err := a.ExportUserContent(&pbAdmin.RequestExportUserContent{
+		UserId: testAuthor.Id,
+		Format: "zip",
+	}, stream)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if stream.chunks[0].ContentType != "application/zip" {
+		t.Errorf("unexpected content type: %s", stream.chunks[0].ContentType)
+	}
+
+	data := stream.data()
+	archive, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	lines := map[string]int{}
+	for _, file := range archive.File {
+		r, _ := file.Open()
+		content, _ := io.ReadAll(r)
+		r.Close()
+		lines[file.Name] = bytes.Count(content, []byte("\n"))
+	}
+	expected := map[string]int{
+		"stories.jsonl":     1,
+		"itineraries.jsonl": 1,
+		"comments.jsonl":    1,
+		"likes.jsonl":       1,
+		"messages.jsonl":    0,
+	}
+	for name, num := range expected {
+		if n, ok := lines[name]; !ok || n != num {
+			t.Errorf("expected %d records in %s, got %v", num, name, lines)
+		}
+	}
+}
+
+func TestExportUserContentInvalid(t *testing.T) {
+	a, _, repo := NewAdminTestService()
+
+	stream := &fakeExportStream{}
+	err := a.ExportUserContent(&pbAdmin.RequestExportUserContent{
+		UserId: testAuthor.Id,
+		Format: "csv",
+	}, stream)
+	if err == nil {
+		t.Error("expected unknown format to fail")
+	}
+	err = a.ExportUserContent(&pbAdmin.RequestExportUserContent{}, stream)
+	if err == nil {
+		t.Error("expected missing user_id to fail")
+	}
+
+	repo.err = errors.New("connection refused")
+	err = a.ExportUserContent(&pbAdmin.RequestExportUserContent{
+		UserId: testAuthor.Id,
+	}, stream)
+	if err == nil {
+		t.Error("expected the repo error to be returned")
+	}
+}
@@ -0,0 +1,1066 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log/slog"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+	pbInter "travel/genproto/interactions"
+	pbItiner "travel/genproto/itineraries"
+	pb "travel/genproto/stories"
+	pbUser "travel/genproto/users"
+	"travel/models"
+	"travel/pkg/media"
+
+	"github.com/google/uuid"
+	"google.golang.org/grpc"
+)
+
+const testGracePeriod = time.Hour
+
+func newTestLogger() *slog.Logger {
+	return slog.New(slog.NewTextHandler(discard{}, nil))
+}
+
+type discard struct{}
+
+func (discard) Write(p []byte) (int, error) { return len(p), nil }
+
+// fakeUsers serves the users service RPCs the content service calls from an
+// in-memory user table.
+type fakeUsers struct {
+	pbUser.UsersClient
+	users map[string]*pbUser.ResponseGetAuthorInfo
+	calls int
+}
+
+func newFakeUsers(users ...*pbUser.ResponseGetAuthorInfo) *fakeUsers {
+	f := &fakeUsers{users: map[string]*pbUser.ResponseGetAuthorInfo{}}
+	for _, u := range users {
+		f.users[u.Id] = u
+	}
+	return f
+}
+
+func (f *fakeUsers) ValidateUser(ctx context.Context, in *pbUser.RequestGetProfile,
+	opts ...grpc.CallOption) (*pbUser.Status, error) {
+	f.calls++
+	_, ok := f.users[in.Id]
+	return &pbUser.Status{Success: ok}, nil
+}
+
+func (f *fakeUsers) GetAuthorInfo(ctx context.Context, in *pbUser.RequestGetAuthorInfo,
+	opts ...grpc.CallOption) (*pbUser.ResponseGetAuthorInfo, error) {
+	f.calls++
+	u, ok := f.users[in.Id]
+	if !ok {
+		return nil, fmt.Errorf("rpc error: code = Unknown desc = %s", sql.ErrNoRows)
+	}
+	return u, nil
+}
+
+type fakeStory struct {
+	info      models.StoryFullInfo
+	tags      []string
+	images    []models.Image
+	detached  []models.Image
+	publishAt time.Time
+	revisions []models.StoryRevision
+	deleted   bool
+	deletedAt time.Time
+}
+
+type fakeStoriesRepo struct {
+	mu      sync.Mutex
+	stories map[string]*fakeStory
+	order   []string
+}
+
+func newFakeStoriesRepo() *fakeStoriesRepo {
+	return &fakeStoriesRepo{stories: map[string]*fakeStory{}}
+}
+
+func (f *fakeStoriesRepo) CreateStory(ctx context.Context,
+	story *pb.RequestCreateStory) (string, error) {
+	return f.createStory(story, models.StoryPublished)
+}
+
+func (f *fakeStoriesRepo) CreateDraft(ctx context.Context,
+	story *pb.RequestCreateStory) (string, error) {
+	return f.createStory(story, models.StoryDraft)
+}
+
+func (f *fakeStoriesRepo) createStory(story *pb.RequestCreateStory,
+	status string) (string, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	id := uuid.NewString()
+	f.stories[id] = &fakeStory{info: models.StoryFullInfo{
+		Id:       id,
+		Title:    story.Title,
+		Content:  story.Content,
+		AuthorId: story.AuthorId,
+		Location: story.Location,
+		Status:   status,
+	}}
+	f.order = append(f.order, id)
+	return id, nil
+}
+
+func (f *fakeStoriesRepo) find(id string) (*fakeStory, error) {
+	s, ok := f.stories[id]
+	if !ok || s.deleted {
+		return nil, sql.ErrNoRows
+	}
+	return s, nil
+}
+
+func (f *fakeStoriesRepo) CreateStoryTags(ctx context.Context, storyId string,
+	tags *[]string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	s, err := f.find(storyId)
+	if err != nil {
+		return err
+	}
+	s.tags = append(s.tags, *tags...)
+	return nil
+}
+
+func (f *fakeStoriesRepo) EditStory(ctx context.Context, story *pb.RequestEditStory,
+	images *models.StoryImagesEdit) (*models.EditedStory, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	s, err := f.find(story.Id)
+	if err != nil {
+		return nil, err
+	}
+	kept, removed := s.images, []models.Image{}
+	if images != nil {
+		kept, removed, err = s.editImages(images)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if !unpublished(s) {
+		s.revisions = append(s.revisions, s.revision(len(s.revisions)+1))
+	}
+	s.info.Title = story.Title
+	s.info.Content = story.Content
+	s.info.Location = story.Location
+	s.info.UpdatedAt = time.Now().Format(time.RFC3339Nano)
+	s.tags = append([]string{}, story.Tags...)
+	if images != nil && images.Restore != nil {
+		s.detached = slices.DeleteFunc(s.detached, func(img models.Image) bool {
+			return slices.Contains(images.Restore, img.Id)
+		})
+	}
+	s.images = kept
+	return &models.EditedStory{
+		AuthorId: s.info.AuthorId,
+		Images:   append([]models.Image{}, kept...),
+		Deleted:  s.remove(removed),
+	}, nil
+}
+
+// editImages returns the images the story keeps and the ones it removes,
+// without changing it, so a failing edit changes nothing.
+func (s *fakeStory) editImages(images *models.StoryImagesEdit) (
+	[]models.Image, []models.Image, error) {
+	kept, removed := []models.Image{}, []models.Image{}
+	if images.Restore != nil {
+		all := append(append([]models.Image{}, s.images...), s.detached...)
+		for _, id := range images.Restore {
+			n := slices.IndexFunc(all, func(img models.Image) bool { return img.Id == id })
+			if n < 0 {
+				return nil, nil, fmt.Errorf("image is not found with the id")
+			}
+			kept = append(kept, all[n])
+		}
+		for _, img := range s.images {
+			if !slices.Contains(images.Restore, img.Id) {
+				removed = append(removed, img)
+			}
+		}
+		return kept, removed, nil
+	}
+
+	for _, img := range s.images {
+		if images.Replace || slices.Contains(images.RemoveIds, img.Id) {
+			removed = append(removed, img)
+		} else {
+			kept = append(kept, img)
+		}
+	}
+	if !images.Replace && len(removed) != len(images.RemoveIds) {
+		return nil, nil, fmt.Errorf("image is not found with the id")
+	}
+	for n := range images.Added {
+		images.Added[n].Id = uuid.NewString()
+	}
+	return append(kept, images.Added...), removed, nil
+}
+
+func (s *fakeStory) revision(n int) models.StoryRevision {
+	tags := append([]string{}, s.tags...)
+	slices.Sort(tags)
+	return models.StoryRevision{
+		StoryId:  s.info.Id,
+		Revision: n,
+		Title:    s.info.Title,
+		Content:  s.info.Content,
+		Location: s.info.Location,
+		Tags:     tags,
+		Images:   append([]models.Image{}, s.images...),
+	}
+}
+
+// remove detaches the images a revision refers to and returns the others.
+func (s *fakeStory) remove(images []models.Image) []models.Image {
+	deleted := []models.Image{}
+	for _, img := range images {
+		kept := slices.ContainsFunc(s.revisions, func(r models.StoryRevision) bool {
+			return slices.ContainsFunc(r.Images, func(i models.Image) bool {
+				return i.Id == img.Id
+			})
+		})
+		if kept {
+			s.detached = append(s.detached, img)
+		} else {
+			deleted = append(deleted, img)
+		}
+	}
+	return deleted
+}
+
+func (f *fakeStoriesRepo) DeleteStoryTags(ctx context.Context,
+	storyId string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if s, ok := f.stories[storyId]; ok {
+		s.tags = nil
+	}
+	return nil
+}
+
+func (f *fakeStoriesRepo) GetStories(ctx context.Context,
+	filter *pb.RequestGetStories) (*[]models.Story, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	stories := []models.Story{}
+	skip := int(filter.Limit * filter.Page)
+	for _, id := range f.order {
+		s := f.stories[id]
+		if s.deleted || s.info.Status != models.StoryPublished {
+			continue
+		}
+		if skip > 0 {
+			skip--
+			continue
+		}
+		if len(stories) >= int(filter.Limit) {
+			break
+		}
+		story := models.Story{
+			Id:            s.info.Id,
+			Title:         s.info.Title,
+			AuthorId:      s.info.AuthorId,
+			Location:      s.info.Location,
+			LikesCount:    s.info.LikesCount,
+			CommentsCount: s.info.CommentsCount,
+		}
+		if len(s.images) > 0 {
+			cover := s.images[0]
+			story.CoverImage = &cover
+		}
+		stories = append(stories, story)
+	}
+	return &stories, nil
+}
+
+func (f *fakeStoriesRepo) FindNumberOfStories(ctx context.Context) (int, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	count := 0
+	for _, s := range f.stories {
+		if !s.deleted && s.info.Status == models.StoryPublished {
+			count++
+		}
+	}
+	return count, nil
+}
+
+func (f *fakeStoriesRepo) GetStoryFullInfo(ctx context.Context, id string) (
+	*models.StoryFullInfo, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	s, err := f.find(id)
+	if err != nil {
+		return nil, err
+	}
+	if s.info.Status != models.StoryPublished {
+		return nil, sql.ErrNoRows
+	}
+	info := s.info
+	info.Images = append([]models.Image{}, s.images...)
+	return &info, nil
+}
+
+func (f *fakeStoriesRepo) GetStoryTags(ctx context.Context, storyId string) (
+	*[]string, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	tags := []string{}
+	if s, ok := f.stories[storyId]; ok {
+		tags = append(tags, s.tags...)
+	}
+	return &tags, nil
+}
+
+func (f *fakeStoriesRepo) DeleteStory(ctx context.Context, id string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	s, err := f.find(id)
+	if err != nil {
+		return fmt.Errorf("story is not found with the id")
+	}
+	s.deleted = true
+	s.deletedAt = time.Now()
+	return nil
+}
+
+func (f *fakeStoriesRepo) RestoreStory(ctx context.Context, id, authorId string,
+	since time.Time) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	s, ok := f.stories[id]
+	if !ok || !s.deleted || s.info.AuthorId != authorId || s.deletedAt.Before(since) {
+		return fmt.Errorf("deleted story is not found with the id")
+	}
+	s.deleted = false
+	return nil
+}
+
+func (f *fakeStoriesRepo) CreateStoryImages(ctx context.Context, storyId string,
+	images []models.Image) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	s, err := f.find(storyId)
+	if err != nil {
+		return err
+	}
+	for n := range images {
+		images[n].Id = uuid.NewString()
+	}
+	s.images = append(s.images, images...)
+	return nil
+}
+
+func (f *fakeStoriesRepo) GetStoryImages(ctx context.Context, storyId string) (
+	*[]models.Image, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	images := []models.Image{}
+	if s, ok := f.stories[storyId]; ok {
+		images = append(images, s.images...)
+	}
+	return &images, nil
+}
+
+func (f *fakeStoriesRepo) GetStoryStatus(ctx context.Context, id string) (
+	string, string, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	s, err := f.find(id)
+	if err != nil {
+		return "", "", err
+	}
+	return s.info.AuthorId, s.info.Status, nil
+}
+
+func unpublished(s *fakeStory) bool {
+	return s.info.Status == models.StoryDraft || s.info.Status == models.StoryScheduled
+}
+
+func (f *fakeStoriesRepo) GetDraft(ctx context.Context, id string) (
+	*models.StoryFullInfo, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	s, err := f.find(id)
+	if err != nil || !unpublished(s) {
+		return nil, sql.ErrNoRows
+	}
+	info := s.info
+	return &info, nil
+}
+
+func (f *fakeStoriesRepo) GetDrafts(ctx context.Context, authorId string, page,
+	limit int32) (*[]models.StoryFullInfo, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	drafts := []models.StoryFullInfo{}
+	skip := int(limit * page)
+	for _, id := range f.order {
+		s := f.stories[id]
+		if s.deleted || s.info.AuthorId != authorId || !unpublished(s) {
+			continue
+		}
+		if skip > 0 {
+			skip--
+			continue
+		}
+		if len(drafts) >= int(limit) {
+			break
+		}
+		drafts = append(drafts, s.info)
+	}
+	return &drafts, nil
+}
+
+func (f *fakeStoriesRepo) CountDrafts(ctx context.Context, authorId string) (
+	int, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	count := 0
+	for _, s := range f.stories {
+		if !s.deleted && s.info.AuthorId == authorId && unpublished(s) {
+			count++
+		}
+	}
+	return count, nil
+}
+
+func (f *fakeStoriesRepo) PublishStory(ctx context.Context, id, status string,
+	publishAt time.Time) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	s, err := f.find(id)
+	if err != nil || !unpublished(s) {
+		return fmt.Errorf("story is not a draft")
+	}
+	s.info.Status = status
+	s.info.PublishAt = publishAt.Format(time.RFC3339)
+	s.publishAt = publishAt
+	return nil
+}
+
+func (f *fakeStoriesRepo) ArchiveStory(ctx context.Context, id string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	s, err := f.find(id)
+	if err != nil || s.info.Status != models.StoryPublished {
+		return fmt.Errorf("story is not published")
+	}
+	s.info.Status = models.StoryArchived
+	return nil
+}
+
+func (f *fakeStoriesRepo) PublishDueStories(ctx context.Context, now time.Time) (
+	*[]string, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	ids := []string{}
+	for _, id := range f.order {
+		s := f.stories[id]
+		if !s.deleted && s.info.Status == models.StoryScheduled &&
+			!s.publishAt.After(now) {
+			s.info.Status = models.StoryPublished
+			ids = append(ids, id)
+		}
+	}
+	return &ids, nil
+}
+
+func (f *fakeStoriesRepo) GetStoryRevisions(ctx context.Context, storyId string,
+	page, limit int32) (*[]models.StoryRevision, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	revisions := []models.StoryRevision{}
+	s, ok := f.stories[storyId]
+	if !ok {
+		return &revisions, nil
+	}
+	for n := len(s.revisions) - 1 - int(limit*page); n >= 0 &&
+		len(revisions) < int(limit); n-- {
+		revisions = append(revisions, s.revisions[n])
+	}
+	return &revisions, nil
+}
+
+func (f *fakeStoriesRepo) CountStoryRevisions(ctx context.Context,
+	storyId string) (int, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if s, ok := f.stories[storyId]; ok {
+		return len(s.revisions), nil
+	}
+	return 0, nil
+}
+
+func (f *fakeStoriesRepo) GetStoryRevision(ctx context.Context, storyId string,
+	revision int) (*models.StoryRevision, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	s, ok := f.stories[storyId]
+	if !ok || revision < 1 || revision > len(s.revisions) {
+		return nil, sql.ErrNoRows
+	}
+	res := s.revisions[revision-1]
+	return &res, nil
+}
+
+func (f *fakeStoriesRepo) GetCurrentRevision(ctx context.Context,
+	storyId string) (*models.StoryRevision, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	s, err := f.find(storyId)
+	if err != nil {
+		return nil, err
+	}
+	res := s.revision(0)
+	return &res, nil
+}
+
+// fakeStore keeps the blobs in memory.
+type fakeStore struct {
+	mu    sync.Mutex
+	blobs map[string][]byte
+}
+
+func newTestMedia() (*media.Media, *fakeStore) {
+	store := &fakeStore{blobs: map[string][]byte{}}
+	return &media.Media{Store: store, MaxBytes: 1 << 20, ThumbnailSize: 16}, store
+}
+
+func (f *fakeStore) Put(ctx context.Context, key, contentType string,
+	data []byte) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.blobs[key] = data
+	return nil
+}
+
+func (f *fakeStore) Delete(ctx context.Context, key string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	delete(f.blobs, key)
+	return nil
+}
+
+func (f *fakeStore) URL(key string) string {
+	return "http://media.test/" + key
+}
+
+type fakeInteractionsRepo struct {
+	stories  *fakeStoriesRepo
+	comments map[string][]models.Comment
+	likes    map[string]bool
+}
+
+func newFakeInteractionsRepo(stories *fakeStoriesRepo) *fakeInteractionsRepo {
+	return &fakeInteractionsRepo{
+		stories:  stories,
+		comments: map[string][]models.Comment{},
+		likes:    map[string]bool{},
+	}
+}
+
+// published returns the story if it is published, the only ones users can
+// comment on and like.
+func (f *fakeInteractionsRepo) published(storyId string) (*fakeStory, error) {
+	s, err := f.stories.find(storyId)
+	if err != nil || s.info.Status != models.StoryPublished {
+		return nil, fmt.Errorf("story not found with the id")
+	}
+	return s, nil
+}
+
+func (f *fakeInteractionsRepo) CreateComment(ctx context.Context,
+	req *pbInter.RequestCreateComment) (string, error) {
+	f.stories.mu.Lock()
+	defer f.stories.mu.Unlock()
+	s, err := f.published(req.StoryId)
+	if err != nil {
+		return "", err
+	}
+	s.info.CommentsCount++
+	id := uuid.NewString()
+	f.comments[req.StoryId] = append(f.comments[req.StoryId], models.Comment{
+		Id:       id,
+		Content:  req.Content,
+		AuthorId: req.AuthorId,
+	})
+	return id, nil
+}
+
+func (f *fakeInteractionsRepo) GetComments(ctx context.Context,
+	req *pbInter.RequestGetComments) (*[]models.Comment, error) {
+	comments := []models.Comment{}
+	for n, c := range f.comments[req.StoryId] {
+		if n >= int(req.Limit*req.Page) && len(comments) < int(req.Limit) {
+			comments = append(comments, c)
+		}
+	}
+	return &comments, nil
+}
+
+func (f *fakeInteractionsRepo) CountComments(ctx context.Context,
+	storyId string) (int, error) {
+	return len(f.comments[storyId]), nil
+}
+
+func (f *fakeInteractionsRepo) LikeStory(ctx context.Context,
+	storyId string) error {
+	f.stories.mu.Lock()
+	defer f.stories.mu.Unlock()
+	s, err := f.published(storyId)
+	if err != nil {
+		return err
+	}
+	s.info.LikesCount++
+	return nil
+}
+
+func (f *fakeInteractionsRepo) CreateLike(ctx context.Context,
+	req *pbInter.RequestLikeStory) error {
+	key := req.UserId + "/" + req.StoryId
+	if f.likes[key] {
+		return fmt.Errorf("duplicate key value violates unique constraint")
+	}
+	f.likes[key] = true
+	return nil
+}
+
+type fakeItinerariesRepo struct {
+	itineraries  map[string]*models.ItineraryFullInfo
+	deleted      map[string]time.Time
+	destinations map[string][]*pbItiner.DestinationEdit
+	order        []string
+	top          []*pbItiner.DestionationInfo
+	topCalls     int
+}
+
+func newFakeItinerariesRepo() *fakeItinerariesRepo {
+	return &fakeItinerariesRepo{
+		itineraries:  map[string]*models.ItineraryFullInfo{},
+		deleted:      map[string]time.Time{},
+		destinations: map[string][]*pbItiner.DestinationEdit{},
+	}
+}
+
+func (f *fakeItinerariesRepo) CreateItinerary(ctx context.Context,
+	req *pbItiner.RequestCreateItineraries) (string, error) {
+	id := uuid.NewString()
+	f.itineraries[id] = &models.ItineraryFullInfo{
+		Id:          id,
+		Title:       req.Title,
+		Description: req.Description,
+		StartDate:   req.StartDate,
+		EndDate:     req.EndDate,
+		AutherId:    req.AutherId,
+	}
+	f.order = append(f.order, id)
+	f.addDestinations(id, req.Destinations)
+	return id, nil
+}
+
+func (f *fakeItinerariesRepo) addDestinations(itineraryId string,
+	destinations []*pbItiner.Destination) {
+	for _, des := range destinations {
+		edit := pbItiner.DestinationEdit{
+			Id:        uuid.NewString(),
+			Name:      des.Name,
+			StartDate: des.StartDate,
+			EndDate:   des.EndDate,
+			Latitude:  des.Latitude,
+			Longitude: des.Longitude,
+		}
+		for _, act := range des.Activities {
+			edit.Activities = append(edit.Activities, &pbItiner.Activity{
+				Id:       uuid.NewString(),
+				Activity: act,
+			})
+		}
+		f.destinations[itineraryId] = append(f.destinations[itineraryId], &edit)
+	}
+}
+
+func (f *fakeItinerariesRepo) UpdateItinerary(ctx context.Context,
+	req *pbItiner.RequestEditItineraries) error {
+	it, ok := f.itineraries[req.Id]
+	if !ok {
+		return fmt.Errorf("itinerary not found with the id: %s", req.Id)
+	}
+	it.Title = req.Title
+	it.Description = req.Description
+	it.StartDate = req.StartDate
+	it.EndDate = req.EndDate
+	return nil
+}
+
+func (f *fakeItinerariesRepo) DeleteItinerary(ctx context.Context, id string) error {
+	_, deleted := f.deleted[id]
+	if _, ok := f.itineraries[id]; !ok || deleted {
+		return fmt.Errorf("itinerary not found with the id: %s", id)
+	}
+	f.deleted[id] = time.Now()
+	return nil
+}
+
+func (f *fakeItinerariesRepo) RestoreItinerary(ctx context.Context, id,
+	authorId string, since time.Time) error {
+	deletedAt, deleted := f.deleted[id]
+	if !deleted || f.itineraries[id].AutherId != authorId || deletedAt.Before(since) {
+		return fmt.Errorf("deleted itinerary not found with the id: %s", id)
+	}
+	delete(f.deleted, id)
+	return nil
+}
+
+func (f *fakeItinerariesRepo) GetAllItineraries(ctx context.Context,
+	req *pbItiner.RequestGetAllItineraries) (*[]models.Itinerary, error) {
+	res := []models.Itinerary{}
+	for _, id := range f.order {
+		it, ok := f.itineraries[id]
+		if _, deleted := f.deleted[id]; !ok || deleted {
+			continue
+		}
+		res = append(res, models.Itinerary{
+			Id:        it.Id,
+			Title:     it.Title,
+			StartDate: it.StartDate,
+			EndDate:   it.EndDate,
+			AutherId:  it.AutherId,
+		})
+	}
+	return &res, nil
+}
+
+func (f *fakeItinerariesRepo) FindNumberOfItineraries(ctx context.Context) (
+	int, error) {
+	return len(f.itineraries) - len(f.deleted), nil
+}
+
+func (f *fakeItinerariesRepo) GetItinerariesFullInfo(ctx context.Context,
+	id string) (*models.ItineraryFullInfo, error) {
+	it, ok := f.itineraries[id]
+	if _, deleted := f.deleted[id]; !ok || deleted {
+		return nil, sql.ErrNoRows
+	}
+	res := *it
+	return &res, nil
+}
+
+func (f *fakeItinerariesRepo) GetItinerariesDestinations(ctx context.Context,
+	id string) (*[]*pbItiner.DestinationEdit, error) {
+	res := append([]*pbItiner.DestinationEdit{}, f.destinations[id]...)
+	return &res, nil
+}
+
+func (f *fakeItinerariesRepo) WriteCommentToItinerary(ctx context.Context,
+	req *pbItiner.RequestWriteCommentToItinerary) (string, error) {
+	if _, ok := f.itineraries[req.ItineraryId]; !ok {
+		return "", sql.ErrNoRows
+	}
+	return uuid.NewString(), nil
+}
+
+func (f *fakeItinerariesRepo) CreateDestination(ctx context.Context,
+	req *pbItiner.RequestCreateDestination) (string, error) {
+	id := uuid.NewString()
+	f.top = append(f.top, &pbItiner.DestionationInfo{
+		Id:          id,
+		Name:        req.Name,
+		Country:     req.Country,
+		Description: req.Description,
+	})
+	return id, nil
+}
+
+func (f *fakeItinerariesRepo) GetTopDestinations(ctx context.Context,
+	req *pbItiner.RequestGetDestinations) (
+	*pbItiner.ResponseGetDestinations, error) {
+	f.topCalls++
+	res := pbItiner.ResponseGetDestinations{}
+	for n, des := range f.top {
+		if n >= int(req.Limit*req.Page) && len(res.Destinations) < int(req.Limit) {
+			res.Destinations = append(res.Destinations, des)
+		}
+	}
+	return &res, nil
+}
+
+// fakeAdminRepo lists and purges the deleted content of the fake stories and
+// itineraries repos.
+type fakeAdminRepo struct {
+	stories      *fakeStoriesRepo
+	itineraries  *fakeItinerariesRepo
+	interactions *fakeInteractionsRepo
+	err          error
+}
+
+func (f *fakeAdminRepo) deleted(kind string) []models.DeletedItem {
+	items := []models.DeletedItem{}
+	if kind == "" || kind == models.KindStory {
+		for _, id := range f.stories.order {
+			if s, ok := f.stories.stories[id]; ok && s.deleted {
+				items = append(items, models.DeletedItem{
+					Id:        id,
+					Kind:      models.KindStory,
+					Title:     s.info.Title,
+					AuthorId:  s.info.AuthorId,
+					DeletedAt: s.deletedAt,
+				})
+			}
+		}
+	}
+	if kind == "" || kind == models.KindItinerary {
+		for _, id := range f.itineraries.order {
+			if deletedAt, ok := f.itineraries.deleted[id]; ok {
+				it := f.itineraries.itineraries[id]
+				items = append(items, models.DeletedItem{
+					Id:        id,
+					Kind:      models.KindItinerary,
+					Title:     it.Title,
+					AuthorId:  it.AutherId,
+					DeletedAt: deletedAt,
+				})
+			}
+		}
+	}
+	return items
+}
+
+func (f *fakeAdminRepo) ListDeleted(ctx context.Context, kind string, page,
+	limit int32) (*[]models.DeletedItem, error) {
+	f.stories.mu.Lock()
+	defer f.stories.mu.Unlock()
+	items := f.deleted(kind)
+	slices.SortStableFunc(items, func(a, b models.DeletedItem) int {
+		return b.DeletedAt.Compare(a.DeletedAt)
+	})
+	start := min(int(page*limit), len(items))
+	res := items[start:min(start+int(limit), len(items))]
+	return &res, nil
+}
+
+func (f *fakeAdminRepo) CountDeleted(ctx context.Context, kind string) (int, error) {
+	f.stories.mu.Lock()
+	defer f.stories.mu.Unlock()
+	return len(f.deleted(kind)), nil
+}
+
+func (f *fakeAdminRepo) PurgeStories(ctx context.Context, cutoff time.Time,
+	limit int) (*models.PurgeResult, error) {
+	f.stories.mu.Lock()
+	defer f.stories.mu.Unlock()
+	res := models.PurgeResult{Images: []models.Image{}}
+	for id, s := range f.stories.stories {
+		if res.Stories < limit && s.deleted && s.deletedAt.Before(cutoff) {
+			delete(f.stories.stories, id)
+			res.Stories++
+			res.Images = append(res.Images, s.images...)
+		}
+	}
+	f.stories.order = slices.DeleteFunc(f.stories.order, func(id string) bool {
+		return f.stories.stories[id] == nil
+	})
+	return &res, nil
+}
+
+func (f *fakeAdminRepo) PurgeItineraries(ctx context.Context, cutoff time.Time,
+	limit int) (*models.PurgeResult, error) {
+	res := models.PurgeResult{}
+	for id, deletedAt := range f.itineraries.deleted {
+		if res.Itineraries < limit && deletedAt.Before(cutoff) {
+			delete(f.itineraries.itineraries, id)
+			delete(f.itineraries.destinations, id)
+			delete(f.itineraries.deleted, id)
+			res.Itineraries++
+		}
+	}
+	f.itineraries.order = slices.DeleteFunc(f.itineraries.order, func(id string) bool {
+		return f.itineraries.itineraries[id] == nil
+	})
+	return &res, nil
+}
+
+func (f *fakeAdminRepo) PurgeComments(ctx context.Context, cutoff time.Time) (
+	*models.PurgeResult, error) {
+	return &models.PurgeResult{}, nil
+}
+
+func (f *fakeAdminRepo) PurgeUserContent(ctx context.Context, userId, mode string,
+	now time.Time) (*models.UserContentResult, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	f.stories.mu.Lock()
+	defer f.stories.mu.Unlock()
+	res := models.UserContentResult{}
+	for id, s := range f.stories.stories {
+		if s.info.AuthorId != userId {
+			continue
+		}
+		if mode == models.PurgeAnonymize {
+			s.info.AuthorId = models.DeletedUserId
+		} else if !s.deleted {
+			s.deleted, s.deletedAt = true, now
+		} else {
+			continue
+		}
+		res.Stories++
+		res.StoryIds = append(res.StoryIds, id)
+	}
+	for id, it := range f.itineraries.itineraries {
+		_, deleted := f.itineraries.deleted[id]
+		if it.AutherId != userId {
+			continue
+		}
+		if mode == models.PurgeAnonymize {
+			it.AutherId = models.DeletedUserId
+		} else if !deleted {
+			f.itineraries.deleted[id] = now
+		} else {
+			continue
+		}
+		res.Itineraries++
+		res.ItineraryIds = append(res.ItineraryIds, id)
+	}
+
+	touched := map[string]bool{}
+	for storyId, comments := range f.interactions.comments {
+		kept := []models.Comment{}
+		for _, c := range comments {
+			if c.AuthorId != userId {
+				kept = append(kept, c)
+				continue
+			}
+			res.Comments++
+			touched[storyId] = true
+			if mode == models.PurgeAnonymize {
+				c.AuthorId = models.DeletedUserId
+				kept = append(kept, c)
+			}
+		}
+		f.interactions.comments[storyId] = kept
+	}
+	for key := range f.interactions.likes {
+		if storyId, ok := strings.CutPrefix(key, userId+"/"); ok {
+			delete(f.interactions.likes, key)
+			res.Likes++
+			touched[storyId] = true
+		}
+	}
+	for storyId := range touched {
+		s, ok := f.stories.stories[storyId]
+		if !ok || s.info.AuthorId == userId {
+			continue
+		}
+		s.info.LikesCount = 0
+		for key := range f.interactions.likes {
+			if strings.HasSuffix(key, "/"+storyId) {
+				s.info.LikesCount++
+			}
+		}
+		s.info.CommentsCount = len(f.interactions.comments[storyId])
+		res.UpdatedStories++
+		res.StoryIds = append(res.StoryIds, storyId)
+	}
+	return &res, nil
+}
+
+func (f *fakeAdminRepo) GetUserStories(ctx context.Context, userId string) (
+	*[]models.UserStory, error) {
+	f.stories.mu.Lock()
+	defer f.stories.mu.Unlock()
+	res := []models.UserStory{}
+	for _, id := range f.stories.order {
+		s := f.stories.stories[id]
+		if s.info.AuthorId != userId {
+			continue
+		}
+		story := models.UserStory{
+			Id:       id,
+			Title:    s.info.Title,
+			Content:  s.info.Content,
+			Location: s.info.Location,
+			Status:   s.info.Status,
+		}
+		if s.deleted {
+			story.DeletedAt = s.deletedAt.Format(time.RFC3339Nano)
+		}
+		res = append(res, story)
+	}
+	return &res, nil
+}
+
+func (f *fakeAdminRepo) GetUserItineraries(ctx context.Context, userId string) (
+	*[]models.UserItinerary, error) {
+	res := []models.UserItinerary{}
+	for _, id := range f.itineraries.order {
+		it := f.itineraries.itineraries[id]
+		if it.AutherId != userId {
+			continue
+		}
+		itinerary := models.UserItinerary{
+			Id:          id,
+			Title:       it.Title,
+			Description: it.Description,
+			StartDate:   it.StartDate,
+			EndDate:     it.EndDate,
+		}
+		if deletedAt, ok := f.itineraries.deleted[id]; ok {
+			itinerary.DeletedAt = deletedAt.Format(time.RFC3339Nano)
+		}
+		res = append(res, itinerary)
+	}
+	return &res, nil
+}
+
+func (f *fakeAdminRepo) GetUserComments(ctx context.Context, userId string) (
+	*[]models.UserComment, error) {
+	res := []models.UserComment{}
+	for _, storyId := range f.stories.order {
+		for _, c := range f.interactions.comments[storyId] {
+			if c.AuthorId == userId {
+				res = append(res, models.UserComment{
+					Id:      c.Id,
+					StoryId: storyId,
+					Content: c.Content,
+				})
+			}
+		}
+	}
+	return &res, nil
+}
+
+func (f *fakeAdminRepo) GetUserLikes(ctx context.Context, userId string) (
+	*[]models.Like, error) {
+	res := []models.Like{}
+	for key := range f.interactions.likes {
+		if storyId, ok := strings.CutPrefix(key, userId+"/"); ok {
+			res = append(res, models.Like{StoryId: storyId})
+		}
+	}
+	slices.SortFunc(res, func(a, b models.Like) int {
+		return strings.Compare(a.StoryId, b.StoryId)
+	})
+	return &res, nil
+}
+
+func (f *fakeAdminRepo) GetUserMessages(ctx context.Context, userId string) (
+	*[]models.Message, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	return &[]models.Message{}, nil
+}
@@ -2,51 +2,56 @@ package service
 
 import (
 	"context"
-	"database/sql"
 	"fmt"
 	"log/slog"
 	"time"
 	pb "travel/genproto/interactions"
 	pbUser "travel/genproto/users"
-	"travel/pkg/connections"
-	"travel/pkg/logger"
-	"travel/storage/postgres"
+	"travel/storage"
+	"travel/storage/redis"
 )
 
 type Interations struct {
 	pb.UnimplementedInteractionsServer
 	Logger          *slog.Logger
-	InterationsRepo *postgres.InterationsRepo
+	InterationsRepo storage.InteractionsStorage
 	UserClient      pbUser.UsersClient
+	Cache           *redis.DetailsRedisClient
 }
 
-func NewInterationsService(db *sql.DB) *Interations {
-	InterationsRepo := postgres.NewInterationsRepo(db)
-	Logger := logger.NewLogger()
-	userClient := connections.NewUserClient()
+func NewInterationsService(logger *slog.Logger, repo storage.InteractionsStorage,
+	userClient pbUser.UsersClient, cache redis.Cache) *Interations {
 	return &Interations{
-		Logger:          Logger,
-		InterationsRepo: InterationsRepo,
+		Logger:          logger,
+		InterationsRepo: repo,
 		UserClient:      userClient,
+		Cache:           redis.NewDetailsRedisClient(cache),
 	}
 }
 
-func (i *Interations) CreateComment(ctx context.Context, in *pb.RequestCreateComment) (
+func (i *Interations) CreateComment(ctx context.Context,
+	in *pb.RequestCreateComment) (
 	*pb.ResponseCreateComment, error) {
 
 	// checking user exists
 	valid, err := i.UserClient.ValidateUser(ctx, &pbUser.RequestGetProfile{Id: in.AuthorId})
 	if err != nil || !valid.Success {
-		i.Logger.Error(fmt.Sprintf("error with validating user: %s", err))
+		i.Logger.ErrorContext(ctx, fmt.Sprintf("error with validating user: %s", err))
 		return nil, fmt.Errorf("error: invalid userID: %s", err)
 	}
 
-	id, err := i.InterationsRepo.CreateComment(in)
+	id, err := i.InterationsRepo.CreateComment(ctx, in)
 	if err != nil {
-		i.Logger.Error(fmt.Sprintf("error with creating comment: %s", err))
+		i.Logger.ErrorContext(ctx, fmt.Sprintf("error with creating comment: %s", err))
 		return nil, err
 	}
 
+	err = i.Cache.Delete(ctx, redis.StoryKey(in.StoryId))
+	if err != nil {
+		i.Logger.ErrorContext(ctx,
+			fmt.Sprintf("error with invalidating story cache: %s", err))
+	}
+
 	resp := pb.ResponseCreateComment{
 		Id:        id,
 		StoryId:   in.StoryId,
@@ -59,9 +64,10 @@ func (i *Interations) CreateComment(ctx context.Context, in *pb.RequestCreateCom
 
 func (i *Interations) GetComments(ctx context.Context, in *pb.RequestGetComments) (
 	*pb.ResponseGetComments, error) {
-	comments, err := i.InterationsRepo.GetComments(in)
+	comments, err := i.InterationsRepo.GetComments(ctx, in)
 	if err != nil {
-		i.Logger.Error(fmt.Sprintf("error with getting comments by story Id: %s", err))
+		i.Logger.ErrorContext(ctx,
+			fmt.Sprintf("error with getting comments by story Id: %s", err))
 		return nil, err
 	}
 
@@ -74,14 +80,13 @@ func (i *Interations) GetComments(ctx context.Context, in *pb.RequestGetComments
 			CreatedAt: com.CreatedAt,
 		}
 
-		author, err := i.UserClient.GetAuthorInfo(ctx, &pbUser.RequestGetAuthorInfo{
-			Id: com.AuthorId,
-		})
+		author, err := authorInfo(ctx, i.UserClient, com.AuthorId)
 		if err != nil {
 			if err.Error() == "rpc error: code = Unknown desc = sql: no rows in result set" {
 				continue
 			}
-			i.Logger.Error(fmt.Sprintf("error with getting Author info: %s", err))
+			i.Logger.ErrorContext(ctx,
+				fmt.Sprintf("error with getting Author info: %s", err))
 			return nil, err
 		}
 
@@ -95,7 +100,7 @@ func (i *Interations) GetComments(ctx context.Context, in *pb.RequestGetComments
 	resp.Limit = in.Limit
 	resp.Page = in.Page
 
-	count, err := i.InterationsRepo.CountComments(in.StoryId)
+	count, err := i.InterationsRepo.CountComments(ctx, in.StoryId)
 	if err != nil {
 		return nil, err
 	}
@@ -109,21 +114,29 @@ func (i *Interations) LikeStory(ctx context.Context, in *pb.RequestLikeStory) (
 	// checking user exists
 	valid, err := i.UserClient.ValidateUser(ctx, &pbUser.RequestGetProfile{Id: in.UserId})
 	if err != nil || !valid.Success {
-		i.Logger.Error(fmt.Sprintf("error with validating user: %s", err))
+		i.Logger.ErrorContext(ctx, fmt.Sprintf("error with validating user: %s", err))
 		return nil, fmt.Errorf("error: invalid userID: %s", err)
 	}
 
-	err = i.InterationsRepo.LikeStory(in.StoryId)
+	err = i.InterationsRepo.LikeStory(ctx, in.StoryId)
 	if err != nil {
-		i.Logger.Error(fmt.Sprintf("error with add one to like_count column of stories: %s", err))
+		i.Logger.ErrorContext(ctx,
+			fmt.Sprintf("error with add one to like_count column of stories: %s", err))
 		return nil, err
 	}
 
-	err = i.InterationsRepo.CreateLike(in)
+	err = i.InterationsRepo.CreateLike(ctx, in)
 	if err != nil {
-		i.Logger.Error(fmt.Sprintf("error with add like info to like table: %s", err))
+		i.Logger.ErrorContext(ctx,
+			fmt.Sprintf("error with add like info to like table: %s", err))
 		return nil, err
 	}
+
+	err = i.Cache.Delete(ctx, redis.StoryKey(in.StoryId))
+	if err != nil {
+		i.Logger.ErrorContext(ctx,
+			fmt.Sprintf("error with invalidating story cache: %s", err))
+	}
 	return &pb.ResponseLikeStory{
 		StoryId: in.StoryId,
 		UserId:  in.UserId,
@@ -0,0 +1,110 @@
+package service
+
+import (
+	"context"
+	"testing"
+	pb "travel/genproto/interactions"
+	pbStory "travel/genproto/stories"
+	pbUser "travel/genproto/users"
+	"travel/storage/redis"
+)
+
+func NewInteractionsService() (*Interations, *Stories, string) {
+	stories := newFakeStoriesRepo()
+	users := newFakeUsers(testAuthor)
+	cache := redis.NewMemoryCache()
+	images, _ := newTestMedia()
+	id, _ := stories.CreateStory(context.Background(), &pbStory.RequestCreateStory{
+		AuthorId: testAuthor.Id,
+		Title:    "Go Home",
+	})
+	return NewInterationsService(newTestLogger(), newFakeInteractionsRepo(stories),
+			users, cache),
+		NewContentService(newTestLogger(), stories, users, cache, images,
+			testGracePeriod), id
+}
+
+func TestCreateComment(t *testing.T) {
+	i, _, storyId := NewInteractionsService()
+	ctx := context.Background()
+
+	_, err := i.CreateComment(ctx, &pb.RequestCreateComment{
+		StoryId:  storyId,
+		AuthorId: testAuthor.Id,
+		Content:  "I have never seen story like this",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	resp, err := i.GetComments(ctx, &pb.RequestGetComments{StoryId: storyId, Limit: 10})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.Total != 1 || resp.Comments[0].Author.Username != testAuthor.Username {
+		t.Errorf("unexpected comments: %v", resp)
+	}
+
+	draft, _ := i.InterationsRepo.(*fakeInteractionsRepo).stories.CreateDraft(ctx,
+		&pbStory.RequestCreateStory{AuthorId: testAuthor.Id, Title: "Draft"})
+	_, err = i.CreateComment(ctx, &pb.RequestCreateComment{
+		StoryId:  draft,
+		AuthorId: testAuthor.Id,
+		Content:  "Too early",
+	})
+	if err == nil {
+		t.Error("expected commenting on a draft to fail")
+	}
+}
+
+func TestGetCommentsSkipsDeletedAuthors(t *testing.T) {
+	i, _, storyId := NewInteractionsService()
+	ctx := context.Background()
+	i.InterationsRepo.CreateComment(ctx, &pb.RequestCreateComment{
+		StoryId:  storyId,
+		AuthorId: "deleted-user",
+	})
+
+	resp, err := i.GetComments(ctx, &pb.RequestGetComments{StoryId: storyId, Limit: 10})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(resp.Comments) != 0 {
+		t.Errorf("expected comment of deleted user to be skipped")
+	}
+}
+
+func TestLikeStory(t *testing.T) {
+	i, s, storyId := NewInteractionsService()
+	ctx := context.Background()
+
+	if _, err := s.GetStoryFullInfo(ctx, &pbStory.RequestGetStoryFullInfo{Id: storyId}); err != nil {
+		t.Fatal(err)
+	}
+
+	_, err := i.LikeStory(ctx, &pb.RequestLikeStory{StoryId: storyId, UserId: testAuthor.Id})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	story, err := s.GetStoryFullInfo(ctx, &pbStory.RequestGetStoryFullInfo{Id: storyId})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if story.LikesCount != 1 {
+		t.Errorf("expected like to invalidate cache, got %d likes", story.LikesCount)
+	}
+}
+
+func TestLikeStoryInvalidUser(t *testing.T) {
+	i, _, storyId := NewInteractionsService()
+	i.UserClient = newFakeUsers(&pbUser.ResponseGetAuthorInfo{Id: "other"})
+
+	_, err := i.LikeStory(context.Background(), &pb.RequestLikeStory{
+		StoryId: storyId,
+		UserId:  testAuthor.Id,
+	})
+	if err == nil {
+		t.Error("expected invalid user error")
+	}
+}
@@ -2,60 +2,64 @@ package service
 
 import (
 	"context"
-	"database/sql"
+	"errors"
 	"fmt"
 	"log/slog"
+	"path"
+	"strings"
 	"time"
 	pb "travel/genproto/itineraries"
 	pbUser "travel/genproto/users"
-	"travel/pkg/connections"
-	"travel/pkg/logger"
-	"travel/storage/postgres"
+	"travel/pkg/itinerary"
+	"travel/pkg/metrics"
+	"travel/storage"
 	"travel/storage/redis"
+)
 
-	rd "github.com/redis/go-redis/v9"
+const (
+	destinationsLockRetries    = 10
+	destinationsLockRetryDelay = 50 * time.Millisecond
 )
 
 type Itineraries struct {
 	pb.UnimplementedItinerariesServer
 	Logger          *slog.Logger
-	ItinerariesRepo *postgres.ItinerariesRepo
+	ItinerariesRepo storage.ItinerariesStorage
 	UserClient      pbUser.UsersClient
-	Redis           redis.DestinationRedisClient
+	Redis           *redis.DestinationRedisClient
+	Cache           *redis.DetailsRedisClient
+	// how long after deleting an itinerary its author can restore it
+	GracePeriod time.Duration
 }
 
-func NewItinerariesService(db *sql.DB) *Itineraries {
-	ItinerariesRepo := postgres.NewItinerariesRepo(db)
-	Logger := logger.NewLogger()
-	userClient := connections.NewUserClient()
-	redisClient := redis.NewDestinationRedisClient()
+func NewItinerariesService(logger *slog.Logger, repo storage.ItinerariesStorage,
+	userClient pbUser.UsersClient, cache redis.Cache,
+	gracePeriod time.Duration) *Itineraries {
 	return &Itineraries{
-		Logger:          Logger,
-		ItinerariesRepo: ItinerariesRepo,
+		Logger:          logger,
+		ItinerariesRepo: repo,
 		UserClient:      userClient,
-		Redis:           *redisClient,
+		Redis:           redis.NewDestinationRedisClient(cache),
+		Cache:           redis.NewDetailsRedisClient(cache),
+		GracePeriod:     gracePeriod,
 	}
 }
 
-func (i *Itineraries) CreateItineraries(ctx context.Context, in *pb.RequestCreateItineraries) (
+func (i *Itineraries) CreateItineraries(ctx context.Context,
+	in *pb.RequestCreateItineraries) (
 	*pb.ResponseCreateItineraries, error) {
 
 	// checking user exists
 	valid, err := i.UserClient.ValidateUser(ctx, &pbUser.RequestGetProfile{Id: in.AutherId})
 	if err != nil || !valid.Success {
-		i.Logger.Error(fmt.Sprintf("error with validating user: %s", err))
+		i.Logger.ErrorContext(ctx, fmt.Sprintf("error with validating user: %s", err))
 		return nil, fmt.Errorf("error: invalid userID: %s", err)
 	}
 
-	id, err := i.ItinerariesRepo.CreateItineraries(in)
-	if err != nil {
-		i.Logger.Error(fmt.Sprintf("error with creating itineraries table: %s", err))
-		return nil, err
-	}
-
-	err = i.ItinerariesRepo.CreateItinerariesDestinations(id, in.Destinations)
+	id, err := i.ItinerariesRepo.CreateItinerary(ctx, in)
 	if err != nil {
-		i.Logger.Error(fmt.Sprintf("error with creating itineraries' destnation: %s", err))
+		i.Logger.ErrorContext(ctx,
+			fmt.Sprintf("error with creating itinerary: %s", err))
 		return nil, err
 	}
 
@@ -70,7 +74,8 @@ func (i *Itineraries) CreateItineraries(ctx context.Context, in *pb.RequestCreat
 	}, nil
 }
 
-func (i *Itineraries) EditItineraries(ctx context.Context, in *pb.RequestEditItineraries) (
+func (i *Itineraries) EditItineraries(ctx context.Context,
+	in *pb.RequestEditItineraries) (
 	*pb.ResponseEditItineraries, error) {
 
 	// checking user exists
@@ -78,30 +83,21 @@ func (i *Itineraries) EditItineraries(ctx context.Context, in *pb.RequestEditIti
 		Id: in.AuthorId,
 	})
 	if err != nil || !valid.Success {
-		i.Logger.Error(fmt.Sprintf("error with validating user: %s", err))
+		i.Logger.ErrorContext(ctx, fmt.Sprintf("error with validating user: %s", err))
 		return nil, fmt.Errorf("error: invalid userID: %s", err)
 	}
 
-	tx, err := i.ItinerariesRepo.DB.Begin()
-	if err != nil {
-		i.Logger.Error(fmt.Sprintf("error with creating transaction: %s", err))
-		return nil, fmt.Errorf("error with creating transaction: %s", err)
-
-	}
-	defer tx.Commit()
-
-	err = postgres.EditItineraries(tx, in)
+	err = i.ItinerariesRepo.UpdateItinerary(ctx, in)
 	if err != nil {
-		tx.Rollback()
-		i.Logger.Error(fmt.Sprintf("error with editing itineraries table: %s", err))
+		i.Logger.ErrorContext(ctx,
+			fmt.Sprintf("error with editing itineraries: %s", err))
 		return nil, err
 	}
 
-	err = postgres.EditItinerariesDestinations(tx, in.Destinations)
+	err = i.Cache.Delete(ctx, redis.ItineraryKey(in.Id))
 	if err != nil {
-		tx.Rollback()
-		i.Logger.Error(fmt.Sprintf("error with editing itineraries' destnation: %s", err))
-		return nil, err
+		i.Logger.ErrorContext(ctx,
+			fmt.Sprintf("error with invalidating itinerary cache: %s", err))
 	}
 
 	return &pb.ResponseEditItineraries{
@@ -115,20 +111,22 @@ func (i *Itineraries) EditItineraries(ctx context.Context, in *pb.RequestEditIti
 	}, nil
 }
 
-func (i *Itineraries) GetAllItineraries(ctx context.Context, in *pb.RequestGetAllItineraries) (
+func (i *Itineraries) GetAllItineraries(ctx context.Context,
+	in *pb.RequestGetAllItineraries) (
 	*pb.ResponseGetAllItineraries, error) {
-	itineraties, err := i.ItinerariesRepo.GetAllItineraries(in)
+	itineraties, err := i.ItinerariesRepo.GetAllItineraries(ctx, in)
 	if err != nil {
-		i.Logger.Error(fmt.Sprintf("error with getting itineraries: %s", err))
+		i.Logger.ErrorContext(ctx,
+			fmt.Sprintf("error with getting itineraries: %s", err))
 		return nil, err
 	}
 
 	resp := pb.ResponseGetAllItineraries{}
 	for _, val := range *itineraties {
-		auther, err := i.UserClient.GetAuthorInfo(ctx,
-			&pbUser.RequestGetAuthorInfo{Id: val.AutherId})
+		auther, err := authorInfo(ctx, i.UserClient, val.AutherId)
 		if err != nil {
-			i.Logger.Error(fmt.Sprintf("error with getting author info: %s", err))
+			i.Logger.ErrorContext(ctx,
+				fmt.Sprintf("error with getting author info: %s", err))
 			return nil, err
 		}
 		itiner := pb.Itinerary{
@@ -148,9 +146,10 @@ func (i *Itineraries) GetAllItineraries(ctx context.Context, in *pb.RequestGetAl
 		resp.Itineraries = append(resp.Itineraries, &itiner)
 	}
 
-	countOfItiner, err := i.ItinerariesRepo.FindNumberOfItineraries()
+	countOfItiner, err := i.ItinerariesRepo.FindNumberOfItineraries(ctx)
 	if err != nil {
-		i.Logger.Error(fmt.Sprintf("error with getting total itineraries count: %s", err))
+		i.Logger.ErrorContext(ctx,
+			fmt.Sprintf("error with getting total itineraries count: %s", err))
 		return nil, err
 	}
 	resp.Total = int64(countOfItiner)
@@ -160,19 +159,30 @@ func (i *Itineraries) GetAllItineraries(ctx context.Context, in *pb.RequestGetAl
 	return &resp, nil
 }
 
-func (i *Itineraries) GetItineraryFullInfo(ctx context.Context, in *pb.RequestGetItineraryFullInfo) (
+func (i *Itineraries) GetItineraryFullInfo(ctx context.Context,
+	in *pb.RequestGetItineraryFullInfo) (
+	*pb.ResponseGetItineraryFullInfo, error) {
+	return redis.ReadThrough(ctx, i.Cache, redis.ItineraryKey(in.Id),
+		func() (*pb.ResponseGetItineraryFullInfo, error) {
+			return i.getItineraryFullInfo(ctx, in)
+		})
+}
+
+func (i *Itineraries) getItineraryFullInfo(ctx context.Context,
+	in *pb.RequestGetItineraryFullInfo) (
 	*pb.ResponseGetItineraryFullInfo, error) {
 
-	itineraries, err := i.ItinerariesRepo.GetItinerariesFullInfo(in.Id)
+	itineraries, err := i.ItinerariesRepo.GetItinerariesFullInfo(ctx, in.Id)
 	if err != nil {
-		i.Logger.Error(fmt.Sprintf("error with getting full info itineraries: %s", err))
+		i.Logger.ErrorContext(ctx,
+			fmt.Sprintf("error with getting full info itineraries: %s", err))
 		return nil, err
 	}
 
-	auther, err := i.UserClient.GetAuthorInfo(ctx,
-		&pbUser.RequestGetAuthorInfo{Id: itineraries.AutherId})
+	auther, err := authorInfo(ctx, i.UserClient, itineraries.AutherId)
 	if err != nil {
-		i.Logger.Error(fmt.Sprintf("error with getting author info: %s", err))
+		i.Logger.ErrorContext(ctx,
+			fmt.Sprintf("error with getting author info: %s", err))
 		return nil, err
 	}
 
@@ -192,30 +202,147 @@ func (i *Itineraries) GetItineraryFullInfo(ctx context.Context, in *pb.RequestGe
 		UpdatedAt:     itineraries.UpdatedAt,
 	}
 
-	destinations, err := i.ItinerariesRepo.GetItinerariesDestinations(resp.Id)
+	destinations, err := i.ItinerariesRepo.GetItinerariesDestinations(ctx, resp.Id)
 	if err != nil {
-		i.Logger.Error(fmt.Sprintf("error with getting itineraries's destination : %s", err))
+		i.Logger.ErrorContext(ctx,
+			fmt.Sprintf("error with getting itineraries's destination : %s", err))
 		return nil, err
 	}
 	resp.Destinations = *destinations
 	return &resp, nil
 }
 
-func (i *Itineraries) WriteCommentToItinerary(ctx context.Context, in *pb.RequestWriteCommentToItinerary) (
+// ExportItinerary renders the itinerary as an iCalendar, a Markdown or HTML
+// document or GPX waypoints, see requestExportItinerary.
+func (i *Itineraries) ExportItinerary(ctx context.Context,
+	in *pb.RequestExportItinerary) (*pb.ResponseExportItinerary, error) {
+	it, err := i.GetItineraryFullInfo(ctx, &pb.RequestGetItineraryFullInfo{Id: in.Id})
+	if err != nil {
+		return nil, err
+	}
+
+	resp := pb.ResponseExportItinerary{}
+	switch in.Format {
+	case "", "ics":
+		resp.Filename, resp.ContentType = in.Id+".ics", "text/calendar; charset=utf-8"
+		resp.Data, err = itinerary.ICS(it, time.Now())
+	case "markdown":
+		resp.Filename, resp.ContentType = in.Id+".md", "text/markdown; charset=utf-8"
+		resp.Data, err = itinerary.Markdown(it)
+	case "html":
+		resp.Filename, resp.ContentType = in.Id+".html", "text/html; charset=utf-8"
+		resp.Data, err = itinerary.HTML(it)
+	case "gpx":
+		resp.Filename, resp.ContentType = in.Id+".gpx", "application/gpx+xml"
+		resp.Data, err = itinerary.GPX(it)
+	default:
+		return nil, fmt.Errorf("error: unknown format: %s", in.Format)
+	}
+	if errors.Is(err, itinerary.ErrNoCoordinates) {
+		return nil, fmt.Errorf("error: %s", err)
+	}
+	if err != nil {
+		i.Logger.ErrorContext(ctx,
+			fmt.Sprintf("error with exporting itinerary %s: %s", in.Id, err))
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// ImportItinerary creates an itinerary of the author from an iCalendar or JSON
+// file, see requestImportItinerary. The events and destinations it cannot
+// import are skipped and reported as warnings.
+func (i *Itineraries) ImportItinerary(ctx context.Context,
+	in *pb.RequestImportItinerary) (*pb.ResponseImportItinerary, error) {
+
+	// checking user exists
+	valid, err := i.UserClient.ValidateUser(ctx, &pbUser.RequestGetProfile{Id: in.AuthorId})
+	if err != nil || !valid.Success {
+		i.Logger.ErrorContext(ctx, fmt.Sprintf("error with validating user: %s", err))
+		return nil, fmt.Errorf("error: invalid userID: %s", err)
+	}
+
+	format := in.Format
+	if format == "" {
+		format = "json"
+		if strings.HasSuffix(strings.ToLower(in.Filename), ".ics") ||
+			itinerary.IsICS(in.Data) {
+			format = "ics"
+		}
+	}
+
+	var req *pb.RequestCreateItineraries
+	warnings := []itinerary.Warning{}
+	switch format {
+	case "ics":
+		req, warnings, err = itinerary.ParseICS(in.Data)
+		if err == nil && req.Title == "" && in.Filename != "" {
+			req.Title = strings.TrimSuffix(path.Base(in.Filename), path.Ext(in.Filename))
+		}
+	case "json":
+		req, err = itinerary.ParseJSON(in.Data)
+	default:
+		return nil, fmt.Errorf("error: unknown format: %s", format)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error: invalid %s file: %s", format, err)
+	}
+
+	invalid, err := itinerary.Validate(req)
+	if err != nil {
+		return nil, fmt.Errorf("error: %s", err)
+	}
+	warnings = append(warnings, invalid...)
+	req.AutherId = in.AuthorId
+
+	id, err := i.ItinerariesRepo.CreateItinerary(ctx, req)
+	if err != nil {
+		i.Logger.ErrorContext(ctx,
+			fmt.Sprintf("error with importing itinerary: %s", err))
+		return nil, err
+	}
+
+	resp := pb.ResponseImportItinerary{
+		Id:           id,
+		Title:        req.Title,
+		StartDate:    req.StartDate,
+		EndDate:      req.EndDate,
+		Destinations: int64(len(req.Destinations)),
+	}
+	for _, des := range req.Destinations {
+		resp.Activities += int64(len(des.Activities))
+	}
+	for _, warn := range warnings {
+		resp.Warnings = append(resp.Warnings, &pb.ImportWarning{
+			Item:    warn.Item,
+			Message: warn.Message,
+		})
+	}
+	return &resp, nil
+}
+
+func (i *Itineraries) WriteCommentToItinerary(ctx context.Context,
+	in *pb.RequestWriteCommentToItinerary) (
 	*pb.ResponseWriteCommentToItinerary, error) {
 
 	// checking user exists
 	valid, err := i.UserClient.ValidateUser(ctx, &pbUser.RequestGetProfile{Id: in.AuthorId})
 	if err != nil || !valid.Success {
-		i.Logger.Error(fmt.Sprintf("error with validating user: %s", err))
+		i.Logger.ErrorContext(ctx, fmt.Sprintf("error with validating user: %s", err))
 		return nil, fmt.Errorf("error: invalid userID: %s", err)
 	}
 
-	id, err := i.ItinerariesRepo.WriteCommentToItinerary(in)
+	id, err := i.ItinerariesRepo.WriteCommentToItinerary(ctx, in)
 	if err != nil {
-		i.Logger.Error(fmt.Sprintf("error with writing comment: %s", err))
+		i.Logger.ErrorContext(ctx, fmt.Sprintf("error with writing comment: %s", err))
 		return nil, err
 	}
+
+	err = i.Cache.Delete(ctx, redis.ItineraryKey(in.ItineraryId))
+	if err != nil {
+		i.Logger.ErrorContext(ctx,
+			fmt.Sprintf("error with invalidating itinerary cache: %s", err))
+	}
 	return &pb.ResponseWriteCommentToItinerary{
 		Id:          id,
 		Content:     in.Content,
@@ -225,13 +352,21 @@ func (i *Itineraries) WriteCommentToItinerary(ctx context.Context, in *pb.Reques
 	}, nil
 }
 
-func (i *Itineraries) CreateDestination(ctx context.Context, in *pb.RequestCreateDestination) (
+func (i *Itineraries) CreateDestination(ctx context.Context,
+	in *pb.RequestCreateDestination) (
 	*pb.ResponseCreateDestination, error) {
-	id, err := i.ItinerariesRepo.CreateDestination(in)
+	id, err := i.ItinerariesRepo.CreateDestination(ctx, in)
 	if err != nil {
-		i.Logger.Error(fmt.Sprintf("error with creating destinations: %s", err))
+		i.Logger.ErrorContext(ctx,
+			fmt.Sprintf("error with creating destinations: %s", err))
 		return nil, err
 	}
+
+	err = i.Redis.InvalidateTopDestinations(ctx)
+	if err != nil {
+		i.Logger.ErrorContext(ctx,
+			fmt.Sprintf("error with invalidating top destinations cache: %s", err))
+	}
 	return &pb.ResponseCreateDestination{
 		Id:                id,
 		Name:              in.Name,
@@ -246,77 +381,113 @@ func (i *Itineraries) CreateDestination(ctx context.Context, in *pb.RequestCreat
 	}, nil
 }
 
-func (i *Itineraries) UpdateTopDestinations(ctx context.Context, in *pb.RequestGetDestinations) (
+func (i *Itineraries) UpdateTopDestinations(ctx context.Context,
+	in *pb.RequestGetDestinations) (
 	*pb.ResponseGetDestinations, error) {
 
-	destinations, err := i.ItinerariesRepo.GetTopDestinations(in)
+	destinations, err := i.ItinerariesRepo.GetTopDestinations(ctx, in)
 	if err != nil {
-		i.Logger.Error(fmt.Sprintf("error with getting top destinations: %s", err))
+		i.Logger.ErrorContext(ctx,
+			fmt.Sprintf("error with getting top destinations: %s", err))
 		return nil, err
 	}
-	err = i.Redis.SetTopDestinations(ctx, destinations)
-	return destinations, err
+	destinations.Page = in.Page
+	destinations.Limit = in.Limit
+
+	err = i.Redis.SetTopDestinations(ctx, in, destinations)
+	if err != nil {
+		i.Logger.ErrorContext(ctx,
+			fmt.Sprintf("error with caching top destinations: %s", err))
+	}
+	return destinations, nil
 }
 
-func (i *Itineraries) GetDestinations(ctx context.Context, in *pb.RequestGetDestinations) (
+func (i *Itineraries) GetDestinations(ctx context.Context,
+	in *pb.RequestGetDestinations) (
 	*pb.ResponseGetDestinations, error) {
 
-	destinations, err := i.Redis.GetTopDestinations(ctx)
-	if err == rd.Nil {
-		destinations, err := i.UpdateTopDestinations(ctx, in)
-		if err != nil {
-			i.Logger.Error(fmt.Sprintf("error with updating destinations in redis: %s", err))
-			return nil, err
-		}
-		if len(destinations.Destinations) >= int(in.Limit) {
-			destinations.Destinations = destinations.Destinations[:in.Limit]
-		}
+	destinations, err := i.Redis.GetTopDestinations(ctx, in)
+	metrics.CacheResult("top_destinations", err, redis.ErrCacheMiss)
+	if err == nil {
 		return destinations, nil
 	}
+	if err != redis.ErrCacheMiss {
+		i.Logger.ErrorContext(ctx,
+			fmt.Sprintf("error with getting destinations from redis: %s", err))
+		return i.UpdateTopDestinations(ctx, in)
+	}
+
+	token, err := i.Redis.LockTopDestinations(ctx, in)
 	if err != nil {
-		i.Logger.Error(fmt.Sprintf("error with getting destinations: %s", err))
-		return nil, err
+		i.Logger.ErrorContext(ctx,
+			fmt.Sprintf("error with locking destinations in redis: %s", err))
+		return i.UpdateTopDestinations(ctx, in)
+	}
+	if token != "" {
+		defer i.Redis.UnlockTopDestinations(ctx, in, token)
+		return i.UpdateTopDestinations(ctx, in)
 	}
 
-	if len(destinations.Destinations) < int(in.Limit) {
-		destinations, err = i.UpdateTopDestinations(ctx, in)
-		if err != nil {
-			i.Logger.Error(fmt.Sprintf("error with updating destinations in redis: %s", err))
-			return nil, err
+	// another request is already refreshing this page, wait for its result
+	// instead of hitting postgres with the same query
+	for n := 0; n < destinationsLockRetries; n++ {
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(destinationsLockRetryDelay):
+		}
+
+		destinations, err = i.Redis.GetTopDestinations(ctx, in)
+		if err == nil {
+			return destinations, nil
 		}
 	}
-	return destinations, nil
+	return i.UpdateTopDestinations(ctx, in)
 }
 
 // func (i *Itineraries) GetDestinationsAllInfo(ctx context.Context, in *pb.RequestGetDestinationsAllInfo) (*pb.ResponseGetDestinationsAllInfo, error)
 // func (i *Itineraries) GetMessages(ctx context.Context, in *pb.RequestGetMessages) (*pb.ResponseGetMessages, error)
 // func (i *Itineraries) GetUserStatistic(ctx context.Context, in *pb.RequestGetUserStatistic) (*pb.ResponseGetUserStatistic, error)
 
-// func (i *Itineraries) DeleteItineraries(ctx context.Context, in *pb.RequestDeleteItineraries) (
-// 	*pb.ResponseDeleteItineraries, error) {
-// 		tx, err := i.ItinerariesRepo.DB.Begin()
-// 		if err != nil {
-// 			i.Logger.Error(fmt.Sprintf("error with creating transaction: %s", err))
-// 			return nil, fmt.Errorf("error with creating transaction: %s", err)
-
-// 		}
-// 		defer tx.Commit()
-
-// 		err = postgres.EditItineraries(tx, in)
-// 		if err != nil {
-// 			tx.Rollback()
-// 			i.Logger.Error(fmt.Sprintf("error with editing itineraries table: %s", err))
-// 			return nil, err
-// 		}
-
-// 		err = postgres.EditItinerariesDestinations(tx, in.Destinations)
-// 		if err != nil {
-// 			tx.Rollback()
-// 			i.Logger.Error(fmt.Sprintf("error with editing itineraries' destnation: %s", err))
-// 			return nil, err
-// 		}
-
-// 		return &pb.ResponseDeleteItineraries{
-// 			Message: "Itinerary was deleted successfully",
-// 		}, nil
-// }
+func (i *Itineraries) DeleteItineraries(ctx context.Context,
+	in *pb.RequestDeleteItineraries) (*pb.ResponseDeleteItineraries, error) {
+	err := i.ItinerariesRepo.DeleteItinerary(ctx, in.Id)
+	if err != nil {
+		i.Logger.ErrorContext(ctx,
+			fmt.Sprintf("error with deleting itinerary: %s", err))
+		return nil, err
+	}
+
+	err = i.Cache.Delete(ctx, redis.ItineraryKey(in.Id))
+	if err != nil {
+		i.Logger.ErrorContext(ctx,
+			fmt.Sprintf("error with invalidating itinerary cache: %s", err))
+	}
+
+	return &pb.ResponseDeleteItineraries{
+		Message: "Itinerary was deleted successfully",
+	}, nil
+}
+
+// RestoreItinerary undeletes one of the author's itineraries deleted within
+// the grace period.
+func (i *Itineraries) RestoreItinerary(ctx context.Context,
+	in *pb.RequestRestoreItinerary) (*pb.ResponseRestoreItinerary, error) {
+	err := i.ItinerariesRepo.RestoreItinerary(ctx, in.Id, in.AuthorId,
+		time.Now().Add(-i.GracePeriod))
+	if err != nil {
+		i.Logger.ErrorContext(ctx,
+			fmt.Sprintf("error with restoring itinerary: %s", err))
+		return nil, err
+	}
+
+	err = i.Cache.Delete(ctx, redis.ItineraryKey(in.Id))
+	if err != nil {
+		i.Logger.ErrorContext(ctx,
+			fmt.Sprintf("error with invalidating itinerary cache: %s", err))
+	}
+
+	return &pb.ResponseRestoreItinerary{
+		Message: "Itinerary was restored successfully",
+	}, nil
+}
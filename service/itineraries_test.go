@@ -0,0 +1,330 @@
+package service
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+	pb "travel/genproto/itineraries"
+	"travel/storage/redis"
+)
+
+func NewItinerariesServiceWithCache(cache redis.Cache) (*Itineraries, *fakeItinerariesRepo) {
+	repo := newFakeItinerariesRepo()
+	return NewItinerariesService(newTestLogger(), repo, newFakeUsers(testAuthor),
+		cache, testGracePeriod), repo
+}
+
+func TestCreateItineraries(t *testing.T) {
+	i, _ := NewItinerariesServiceWithCache(redis.NewMemoryCache())
+	ctx := context.Background()
+
+	resp, err := i.CreateItineraries(ctx, &pb.RequestCreateItineraries{
+		AutherId:  testAuthor.Id,
+		Title:     "Uzbekistan",
+		StartDate: "2024-07-16",
+		EndDate:   "2024-07-20",
+		Destinations: []*pb.Destination{{
+			Name:       "Tashkent",
+			StartDate:  "2024-07-16",
+			EndDate:    "2024-07-17",
+			Activities: []string{"swimming"},
+		}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	full, err := i.GetItineraryFullInfo(ctx, &pb.RequestGetItineraryFullInfo{Id: resp.Id})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(full.Destinations) != 1 || len(full.Destinations[0].Activities) != 1 {
+		t.Errorf("unexpected destinations: %v", full.Destinations)
+	}
+}
+
+func TestEditItinerariesInvalidatesCache(t *testing.T) {
+	i, repo := NewItinerariesServiceWithCache(redis.NewMemoryCache())
+	ctx := context.Background()
+	id, _ := repo.CreateItinerary(ctx, &pb.RequestCreateItineraries{
+		AutherId: testAuthor.Id,
+		Title:    "Samarkand",
+	})
+
+	if _, err := i.GetItineraryFullInfo(ctx, &pb.RequestGetItineraryFullInfo{Id: id}); err != nil {
+		t.Fatal(err)
+	}
+	_, err := i.EditItineraries(ctx, &pb.RequestEditItineraries{
+		Id:       id,
+		AuthorId: testAuthor.Id,
+		Title:    "Bukhara",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	full, err := i.GetItineraryFullInfo(ctx, &pb.RequestGetItineraryFullInfo{Id: id})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if full.Title != "Bukhara" {
+		t.Errorf("expected edited title, got %s", full.Title)
+	}
+}
+
+func TestGetDestinations(t *testing.T) {
+	i, repo := NewItinerariesServiceWithCache(redis.NewMemoryCache())
+	ctx := context.Background()
+	for _, name := range []string{"Makka", "Tashkent", "Bali"} {
+		repo.CreateDestination(ctx, &pb.RequestCreateDestination{Name: name})
+	}
+
+	for n := 0; n < 2; n++ {
+		resp, err := i.GetDestinations(ctx, &pb.RequestGetDestinations{Limit: 2})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(resp.Destinations) != 2 {
+			t.Fatalf("expected 2 destinations, got %d", len(resp.Destinations))
+		}
+	}
+	if repo.topCalls != 1 {
+		t.Errorf("expected 1 postgres query, got %d", repo.topCalls)
+	}
+
+	resp, err := i.GetDestinations(ctx, &pb.RequestGetDestinations{Page: 1, Limit: 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(resp.Destinations) != 1 || resp.Destinations[0].Name != "Bali" {
+		t.Errorf("expected second page to be queried separately, got %v", resp.Destinations)
+	}
+}
+
+func TestCreateDestinationInvalidatesCache(t *testing.T) {
+	i, repo := NewItinerariesServiceWithCache(redis.NewMemoryCache())
+	ctx := context.Background()
+	repo.CreateDestination(ctx, &pb.RequestCreateDestination{Name: "Makka"})
+	i.GetDestinations(ctx, &pb.RequestGetDestinations{Limit: 2})
+
+	_, err := i.CreateDestination(ctx, &pb.RequestCreateDestination{Name: "Bali"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp, err := i.GetDestinations(ctx, &pb.RequestGetDestinations{Limit: 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(resp.Destinations) != 2 || repo.topCalls != 2 {
+		t.Errorf("expected the new destination to be listed, got %v", resp.Destinations)
+	}
+}
+
+func TestGetDestinationsCacheDisabled(t *testing.T) {
+	i, repo := NewItinerariesServiceWithCache(redis.NoopCache{})
+	ctx := context.Background()
+	repo.CreateDestination(ctx, &pb.RequestCreateDestination{Name: "Makka"})
+
+	for n := 0; n < 2; n++ {
+		if _, err := i.GetDestinations(ctx,
+			&pb.RequestGetDestinations{Limit: 2}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if repo.topCalls != 2 {
+		t.Errorf("expected every call to hit postgres, got %d", repo.topCalls)
+	}
+}
+
+func TestDeleteAndRestoreItinerary(t *testing.T) {
+	i, repo := NewItinerariesServiceWithCache(redis.NewMemoryCache())
+	ctx := context.Background()
+	id, _ := repo.CreateItinerary(ctx, &pb.RequestCreateItineraries{
+		AutherId:  testAuthor.Id,
+		Title:     "Uzbekistan",
+		StartDate: "2024-07-16",
+		EndDate:   "2024-07-20",
+	})
+
+	if _, err := i.GetItineraryFullInfo(ctx, &pb.RequestGetItineraryFullInfo{Id: id}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := i.DeleteItineraries(ctx, &pb.RequestDeleteItineraries{Id: id}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := i.GetItineraryFullInfo(ctx, &pb.RequestGetItineraryFullInfo{Id: id}); err == nil {
+		t.Error("expected deleted itinerary not to be served from cache")
+	}
+
+	_, err := i.RestoreItinerary(ctx, &pb.RequestRestoreItinerary{Id: id,
+		AuthorId: testAuthor.Id})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := i.GetItineraryFullInfo(ctx, &pb.RequestGetItineraryFullInfo{Id: id}); err != nil {
+		t.Errorf("expected the itinerary to be restored, got %v", err)
+	}
+
+	i.DeleteItineraries(ctx, &pb.RequestDeleteItineraries{Id: id})
+	repo.deleted[id] = time.Now().Add(-2 * testGracePeriod)
+	_, err = i.RestoreItinerary(ctx, &pb.RequestRestoreItinerary{Id: id,
+		AuthorId: testAuthor.Id})
+	if err == nil {
+		t.Error("expected restoring after the grace period to fail")
+	}
+}
+
+func TestExportItinerary(t *testing.T) {
+	i, _ := NewItinerariesServiceWithCache(redis.NewMemoryCache())
+	ctx := context.Background()
+	lat, lon := 39.6542, 66.9597
+	created, err := i.CreateItineraries(ctx, &pb.RequestCreateItineraries{
+		AutherId:  testAuthor.Id,
+		Title:     "Uzbekistan",
+		StartDate: "2024-07-16",
+		EndDate:   "2024-07-20",
+		Destinations: []*pb.Destination{{
+			Name:       "Tashkent",
+			StartDate:  "2024-07-16",
+			EndDate:    "2024-07-17",
+			Activities: []string{"swimming"},
+		}, {
+			Name:      "Samarkand",
+			StartDate: "2024-07-18",
+			EndDate:   "2024-07-20",
+			Latitude:  &lat,
+			Longitude: &lon,
+		}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	resp, err := i.ExportItinerary(ctx, &pb.RequestExportItinerary{Id: created.Id})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.Filename != created.Id+".ics" ||
+		strings.Count(string(resp.Data), "BEGIN:VEVENT") != 5 {
+		t.Errorf("expected a calendar with 5 events, got %s:\n%s", resp.Filename, resp.Data)
+	}
+
+	for _, format := range []string{"markdown", "html", "gpx"} {
+		resp, err := i.ExportItinerary(ctx, &pb.RequestExportItinerary{
+			Id:     created.Id,
+			Format: format,
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !strings.Contains(string(resp.Data), "Samarkand") {
+			t.Errorf("expected the destinations in the %s export:\n%s", format, resp.Data)
+		}
+	}
+
+	if _, err := i.ExportItinerary(ctx, &pb.RequestExportItinerary{
+		Id:     created.Id,
+		Format: "pdf",
+	}); err == nil {
+		t.Error("expected unknown format to fail")
+	}
+}
+
+func TestExportItineraryWithoutCoordinates(t *testing.T) {
+	i, repo := NewItinerariesServiceWithCache(redis.NewMemoryCache())
+	ctx := context.Background()
+	id, _ := repo.CreateItinerary(ctx, &pb.RequestCreateItineraries{
+		AutherId: testAuthor.Id,
+		Title:    "Samarkand",
+	})
+
+	_, err := i.ExportItinerary(ctx, &pb.RequestExportItinerary{Id: id, Format: "gpx"})
+	if err == nil || !strings.Contains(err.Error(), "coordinates") {
+		t.Errorf("expected the missing coordinates to be reported, got %v", err)
+	}
+}
+
+func TestImportItinerary(t *testing.T) {
+	i, _ := NewItinerariesServiceWithCache(redis.NewMemoryCache())
+	ctx := context.Background()
+	data := strings.Join([]string{
+		"BEGIN:VCALENDAR",
+		"BEGIN:VEVENT",
+		"SUMMARY:Tashkent",
+		"DTSTART;VALUE=DATE:20240716",
+		"DTEND;VALUE=DATE:20240718",
+		"DESCRIPTION:swimming",
+		"END:VEVENT",
+		"BEGIN:VEVENT",
+		"SUMMARY:Samarkand",
+		"DTSTART;VALUE=DATE:20240720",
+		"DTEND;VALUE=DATE:20240719",
+		"END:VEVENT",
+		"END:VCALENDAR",
+	}, "\r\n")
+
+	resp, err := i.ImportItinerary(ctx, &pb.RequestImportItinerary{
+		AuthorId: testAuthor.Id,
+		Filename: "summer.ics",
+		Data:     []byte(data),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.Title != "summer" || resp.Destinations != 1 || resp.Activities != 1 ||
+		len(resp.Warnings) != 1 {
+		t.Errorf("unexpected import: %v", resp)
+	}
+
+	full, err := i.GetItineraryFullInfo(ctx, &pb.RequestGetItineraryFullInfo{Id: resp.Id})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if full.StartDate != "2024-07-16" || full.EndDate != "2024-07-17" ||
+		len(full.Destinations) != 1 || full.Destinations[0].Name != "Tashkent" {
+		t.Errorf("unexpected itinerary: %v", full)
+	}
+}
+
+func TestImportItineraryJSON(t *testing.T) {
+	i, _ := NewItinerariesServiceWithCache(redis.NewMemoryCache())
+	ctx := context.Background()
+
+	resp, err := i.ImportItinerary(ctx, &pb.RequestImportItinerary{
+		AuthorId: testAuthor.Id,
+		Data: []byte(`{
+			"title": "Uzbekistan",
+			"auther_id": "someone else",
+			"destinations": [{
+				"name": "Samarkand",
+				"start_date": "2024-07-18",
+				"end_date": "2024-07-20",
+				"activities": ["Registan"],
+				"latitude": 39.65,
+				"longitude": 66.96
+			}]
+		}`),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.Destinations != 1 || resp.StartDate != "2024-07-18" || len(resp.Warnings) != 0 {
+		t.Errorf("unexpected import: %v", resp)
+	}
+	full, _ := i.GetItineraryFullInfo(ctx, &pb.RequestGetItineraryFullInfo{Id: resp.Id})
+	if full.Author.Id != testAuthor.Id || full.Destinations[0].Latitude == nil {
+		t.Errorf("expected the itinerary of the author with coordinates, got %v", full)
+	}
+
+	for _, in := range []*pb.RequestImportItinerary{
+		{AuthorId: testAuthor.Id, Data: []byte(`{"title": `)},
+		{AuthorId: testAuthor.Id, Data: []byte(`{"title": "Uzbekistan"}`)},
+		{AuthorId: testAuthor.Id, Format: "csv", Data: []byte(`title`)},
+		{AuthorId: "unknown", Data: []byte(`{"title": "Uzbekistan"}`)},
+	} {
+		if _, err := i.ImportItinerary(ctx, in); err == nil {
+			t.Errorf("expected importing %s to fail", in.Data)
+		}
+	}
+}
@@ -0,0 +1,164 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	pb "travel/genproto/stories"
+	"travel/models"
+	"travel/pkg/textdiff"
+)
+
+// ListStoryRevisions lists the revisions of the story. Revisions keep what the
+// author edited away, so only the author can read or restore them.
+func (s *Stories) ListStoryRevisions(ctx context.Context,
+	in *pb.RequestListStoryRevisions) (*pb.ResponseListStoryRevisions, error) {
+	if _, err := s.storyStatus(ctx, in.StoryId, in.AuthorId); err != nil {
+		return nil, err
+	}
+	revisions, err := s.StoriesRepo.GetStoryRevisions(ctx, in.StoryId, in.Page,
+		in.Limit)
+	if err != nil {
+		s.Logger.ErrorContext(ctx,
+			fmt.Sprintf("error with getting story revisions: %s", err))
+		return nil, err
+	}
+
+	resp := pb.ResponseListStoryRevisions{}
+	for _, val := range *revisions {
+		resp.Revisions = append(resp.Revisions, &pb.StoryRevisionForList{
+			Revision:  int32(val.Revision),
+			Title:     val.Title,
+			CreatedAt: val.CreatedAt,
+		})
+	}
+
+	total, err := s.StoriesRepo.CountStoryRevisions(ctx, in.StoryId)
+	if err != nil {
+		s.Logger.ErrorContext(ctx,
+			fmt.Sprintf("error with getting total revisions count: %s", err))
+		return nil, err
+	}
+	resp.Total = int64(total)
+	resp.Page = in.Page
+	resp.Limit = in.Limit
+
+	return &resp, nil
+}
+
+func (s *Stories) GetStoryRevision(ctx context.Context,
+	in *pb.RequestGetStoryRevision) (*pb.ResponseGetStoryRevision, error) {
+	if _, err := s.storyStatus(ctx, in.StoryId, in.AuthorId); err != nil {
+		return nil, err
+	}
+	revision, err := s.StoriesRepo.GetStoryRevision(ctx, in.StoryId,
+		int(in.Revision))
+	if err != nil {
+		s.Logger.ErrorContext(ctx,
+			fmt.Sprintf("error with getting story revision: %s", err))
+		return nil, err
+	}
+
+	return &pb.ResponseGetStoryRevision{Revision: &pb.StoryRevision{
+		StoryId:   revision.StoryId,
+		Revision:  int32(revision.Revision),
+		Title:     revision.Title,
+		Content:   revision.Content,
+		Location:  revision.Location,
+		Tags:      revision.Tags,
+		CreatedAt: revision.CreatedAt,
+		Images:    toPbImages(revision.Images),
+	}}, nil
+}
+
+// RestoreStoryRevision edits the story back to the revision, images included.
+// The state it replaces is saved as a revision too, so restoring can be undone.
+func (s *Stories) RestoreStoryRevision(ctx context.Context,
+	in *pb.RequestRestoreStoryRevision) (*pb.ResponseRestoreStoryRevision, error) {
+	if _, err := s.storyStatus(ctx, in.StoryId, in.AuthorId); err != nil {
+		return nil, err
+	}
+	revision, err := s.StoriesRepo.GetStoryRevision(ctx, in.StoryId,
+		int(in.Revision))
+	if err != nil {
+		s.Logger.ErrorContext(ctx,
+			fmt.Sprintf("error with getting story revision: %s", err))
+		return nil, err
+	}
+
+	ids := []string{}
+	for _, img := range revision.Images {
+		ids = append(ids, img.Id)
+	}
+	story, err := s.editStory(ctx, &pb.RequestEditStory{
+		Id:       in.StoryId,
+		Title:    revision.Title,
+		Content:  revision.Content,
+		Location: revision.Location,
+		Tags:     revision.Tags,
+	}, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.ResponseRestoreStoryRevision{
+		Id:        story.Id,
+		Title:     story.Title,
+		Content:   story.Content,
+		Location:  story.Location,
+		Tags:      story.Tags,
+		UpdatedAt: story.UpdatedAt,
+		Images:    story.Images,
+	}, nil
+}
+
+// DiffStoryRevisions returns a unified diff between two revisions of the
+// story, revision 0 being its current version.
+func (s *Stories) DiffStoryRevisions(ctx context.Context,
+	in *pb.RequestDiffStoryRevisions) (*pb.ResponseDiffStoryRevisions, error) {
+	if _, err := s.storyStatus(ctx, in.StoryId, in.AuthorId); err != nil {
+		return nil, err
+	}
+	from, err := s.getRevision(ctx, in.StoryId, int(in.FromRevision))
+	if err != nil {
+		return nil, err
+	}
+	to, err := s.getRevision(ctx, in.StoryId, int(in.ToRevision))
+	if err != nil {
+		return nil, err
+	}
+
+	diff := textdiff.Unified(revisionName(from), revisionName(to),
+		revisionText(from), revisionText(to), 3)
+	return &pb.ResponseDiffStoryRevisions{Diff: diff}, nil
+}
+
+func (s *Stories) getRevision(ctx context.Context, storyId string, revision int) (
+	*models.StoryRevision, error) {
+	var res *models.StoryRevision
+	var err error
+	if revision == 0 {
+		res, err = s.StoriesRepo.GetCurrentRevision(ctx, storyId)
+	} else {
+		res, err = s.StoriesRepo.GetStoryRevision(ctx, storyId, revision)
+	}
+	if err != nil {
+		s.Logger.ErrorContext(ctx,
+			fmt.Sprintf("error with getting story revision %d: %s", revision, err))
+		return nil, err
+	}
+	return res, nil
+}
+
+func revisionName(revision *models.StoryRevision) string {
+	if revision.Revision == 0 {
+		return "current"
+	}
+	return fmt.Sprintf("revision %d", revision.Revision)
+}
+
+// revisionText renders the fields of the revision as one text to diff.
+func revisionText(revision *models.StoryRevision) string {
+	return fmt.Sprintf("title: %s\nlocation: %s\ntags: %s\n\n%s\n", revision.Title,
+		revision.Location, strings.Join(revision.Tags, ", "), revision.Content)
+}
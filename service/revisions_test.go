@@ -0,0 +1,135 @@
+package service
+
+import (
+	"context"
+	"strings"
+	"testing"
+	pb "travel/genproto/stories"
+)
+
+func TestStoryRevisions(t *testing.T) {
+	s, _, _ := NewStoriesService()
+	ctx := context.Background()
+	created, err := s.CreateStory(ctx, &pb.RequestCreateStory{
+		AuthorId: testAuthor.Id,
+		Title:    "Bali",
+		Content:  "Beaches\nTemples\nFood",
+		Tags:     []string{"beach"},
+		Images:   []string{testImage(t)},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = s.EditStory(ctx, &pb.RequestEditStory{
+		Id:      created.Id,
+		Title:   "Bali",
+		Content: "Beaches\nRice fields\nFood",
+		Tags:    []string{"beach"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = s.EditStory(ctx, &pb.RequestEditStory{
+		Id:      created.Id,
+		Title:   "Bali",
+		Content: "Overwritten",
+		Tags:    []string{"beach"},
+		Images:  []string{testImage(t)},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	list, err := s.ListStoryRevisions(ctx, &pb.RequestListStoryRevisions{
+		AuthorId: testAuthor.Id,
+		StoryId:  created.Id,
+		Limit:    10,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if list.Total != 2 || len(list.Revisions) != 2 || list.Revisions[0].Revision != 2 {
+		t.Fatalf("expected 2 revisions, the latest first, got %v", list)
+	}
+
+	revision, err := s.GetStoryRevision(ctx, &pb.RequestGetStoryRevision{
+		AuthorId: testAuthor.Id,
+		StoryId:  created.Id,
+		Revision: 1,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if revision.Revision.Content != "Beaches\nTemples\nFood" {
+		t.Errorf("expected the original content, got %q", revision.Revision.Content)
+	}
+
+	diff, err := s.DiffStoryRevisions(ctx, &pb.RequestDiffStoryRevisions{
+		AuthorId:     testAuthor.Id,
+		StoryId:      created.Id,
+		FromRevision: 1,
+		ToRevision:   2,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(diff.Diff, "--- revision 1\n+++ revision 2\n") ||
+		!strings.Contains(diff.Diff, "-Temples\n+Rice fields\n") {
+		t.Errorf("unexpected diff:\n%s", diff.Diff)
+	}
+
+	restored, err := s.RestoreStoryRevision(ctx, &pb.RequestRestoreStoryRevision{
+		AuthorId: testAuthor.Id,
+		StoryId:  created.Id,
+		Revision: 2,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	story, _ := s.GetStoryFullInfo(ctx, &pb.RequestGetStoryFullInfo{Id: created.Id})
+	if restored.Content != "Beaches\nRice fields\nFood" || story.Content != restored.Content ||
+		len(story.Tags) != 1 || len(story.Images) != 1 ||
+		story.Images[0].Id != created.Images[0].Id {
+		t.Errorf("expected revision 2 to be restored, got %v", story)
+	}
+
+	diff, err = s.DiffStoryRevisions(ctx, &pb.RequestDiffStoryRevisions{
+		AuthorId:     testAuthor.Id,
+		StoryId:      created.Id,
+		FromRevision: 3,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(diff.Diff, "+++ current\n") ||
+		!strings.Contains(diff.Diff, "-Overwritten\n") {
+		t.Errorf("expected the overwritten content to be kept as revision 3, got:\n%s",
+			diff.Diff)
+	}
+
+	_, err = s.RestoreStoryRevision(ctx, &pb.RequestRestoreStoryRevision{
+		AuthorId: testAuthor.Id,
+		StoryId:  created.Id,
+		Revision: 9,
+	})
+	if err == nil {
+		t.Error("expected a missing revision to fail")
+	}
+
+	_, err = s.ListStoryRevisions(ctx, &pb.RequestListStoryRevisions{
+		AuthorId: "other",
+		StoryId:  created.Id,
+		Limit:    10,
+	})
+	if err == nil {
+		t.Error("expected other authors not to read the revisions")
+	}
+	_, err = s.RestoreStoryRevision(ctx, &pb.RequestRestoreStoryRevision{
+		AuthorId: "other",
+		StoryId:  created.Id,
+		Revision: 1,
+	})
+	if err == nil {
+		t.Error("expected other authors not to restore a revision")
+	}
+}
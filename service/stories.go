@@ -2,47 +2,119 @@ package service
 
 import (
 	"context"
-	"database/sql"
 	"fmt"
 	"log/slog"
 	"time"
 	pb "travel/genproto/stories"
 	pbUser "travel/genproto/users"
-	"travel/pkg/connections"
-	"travel/pkg/logger"
-	"travel/storage/postgres"
+	"travel/models"
+	"travel/pkg/media"
+	"travel/storage"
+	"travel/storage/redis"
 )
 
 type Stories struct {
 	pb.UnimplementedStoriesServer
 	Logger      *slog.Logger
-	StoriesRepo *postgres.StoriesRepo
+	StoriesRepo storage.StoriesStorage
 	UserClient  pbUser.UsersClient
+	Cache       *redis.DetailsRedisClient
+	Media       *media.Media
+	// how long after deleting a story its author can restore it
+	GracePeriod time.Duration
 }
 
-func NewContentService(db *sql.DB) *Stories {
-	storiesRepo := postgres.NewStoriesRepo(db)
-	Logger := logger.NewLogger()
-	userClient := connections.NewUserClient()
+func NewContentService(logger *slog.Logger, repo storage.StoriesStorage,
+	userClient pbUser.UsersClient, cache redis.Cache, m *media.Media,
+	gracePeriod time.Duration) *Stories {
 	return &Stories{
-		Logger:      Logger,
-		StoriesRepo: storiesRepo,
+		Logger:      logger,
+		StoriesRepo: repo,
 		UserClient:  userClient,
+		Cache:       redis.NewDetailsRedisClient(cache),
+		Media:       m,
+		GracePeriod: gracePeriod,
 	}
 }
 
+// saveImages validates all the uploaded images before storing any of them, so
+// an invalid one rejects the request without leaving blobs behind.
+func (s *Stories) saveImages(ctx context.Context, images []string) (
+	[]models.Image, error) {
+	uploads := []*media.Upload{}
+	for n, data := range images {
+		upload, err := s.Media.Decode(data)
+		if err != nil {
+			return nil, fmt.Errorf("error: invalid image %d: %s", n+1, err)
+		}
+		uploads = append(uploads, upload)
+	}
+
+	res := []models.Image{}
+	for _, upload := range uploads {
+		img, err := s.Media.Save(ctx, "stories", upload)
+		if err != nil {
+			s.deleteImages(ctx, res)
+			return nil, err
+		}
+		res = append(res, *img)
+	}
+	return res, nil
+}
+
+func (s *Stories) deleteImages(ctx context.Context, images []models.Image) {
+	err := s.Media.Delete(ctx, images)
+	if err != nil {
+		s.Logger.ErrorContext(ctx, fmt.Sprintf("error with deleting images: %s", err))
+	}
+}
+
+func toPbImage(img models.Image) *pb.StoryImage {
+	return &pb.StoryImage{
+		Id:           img.Id,
+		Url:          img.Url,
+		ThumbnailUrl: img.ThumbnailUrl,
+		ContentType:  img.ContentType,
+		Width:        int32(img.Width),
+		Height:       int32(img.Height),
+	}
+}
+
+func toPbImages(images []models.Image) []*pb.StoryImage {
+	res := []*pb.StoryImage{}
+	for _, img := range images {
+		res = append(res, toPbImage(img))
+	}
+	return res
+}
+
 func (s *Stories) CreateStory(ctx context.Context, in *pb.RequestCreateStory) (
 	*pb.ResponseCreateStory, error) {
 	// checking user exists
 	valid, err := s.UserClient.ValidateUser(ctx, &pbUser.RequestGetProfile{Id: in.AuthorId})
 	if err != nil || !valid.Success {
-		s.Logger.Error(fmt.Sprintf("error with validating user: %s", err))
+		s.Logger.ErrorContext(ctx, fmt.Sprintf("error with validating user: %s", err))
 		return nil, fmt.Errorf("error: invalid userID: %s", err)
 	}
 
-	id, err := s.StoriesRepo.CreateStory(in)
+	images, err := s.saveImages(ctx, in.Images)
 	if err != nil {
-		s.Logger.Error(fmt.Sprintf("error with creating story: %s", err))
+		s.Logger.ErrorContext(ctx, fmt.Sprintf("error with saving images: %s", err))
+		return nil, err
+	}
+
+	id, err := s.StoriesRepo.CreateStory(ctx, in)
+	if err != nil {
+		s.Logger.ErrorContext(ctx, fmt.Sprintf("error with creating story: %s", err))
+		s.deleteImages(ctx, images)
+		return nil, err
+	}
+
+	err = s.StoriesRepo.CreateStoryImages(ctx, id, images)
+	if err != nil {
+		s.Logger.ErrorContext(ctx,
+			fmt.Sprintf("error with creating story images: %s", err))
+		s.deleteImages(ctx, images)
 		return nil, err
 	}
 
@@ -54,11 +126,13 @@ func (s *Stories) CreateStory(ctx context.Context, in *pb.RequestCreateStory) (
 		Tags:      in.Tags,
 		AuthorId:  in.AuthorId,
 		CreatedAt: time.Now().String(),
+		Images:    toPbImages(images),
 	}
 
-	err = s.StoriesRepo.CreateStoryTags(id, &in.Tags)
+	err = s.StoriesRepo.CreateStoryTags(ctx, id, &in.Tags)
 	if err != nil {
-		s.Logger.Error(fmt.Sprintf("error with creating story tags: %s", err))
+		s.Logger.ErrorContext(ctx,
+			fmt.Sprintf("error with creating story tags: %s", err))
 		return nil, err
 	}
 	return &resp, nil
@@ -66,24 +140,39 @@ func (s *Stories) CreateStory(ctx context.Context, in *pb.RequestCreateStory) (
 
 func (s *Stories) EditStory(ctx context.Context, in *pb.RequestEditStory) (
 	*pb.ResponseEditStory, error) {
+	return s.editStory(ctx, in, nil)
+}
 
-	authorId, err := s.StoriesRepo.EditStory(in)
-	if err != nil {
-		s.Logger.Error(fmt.Sprintf("error with editing story tags: %s", err))
-		return nil, err
+// editStory saves the uploaded images, then edits the story, its tags and its
+// images in one transaction. Restore, when not nil, is the ids of the images
+// the story gets back instead. The blobs of the images the edit deleted are
+// removed once it is committed.
+func (s *Stories) editStory(ctx context.Context, in *pb.RequestEditStory,
+	restore []string) (*pb.ResponseEditStory, error) {
+	replace := len(in.Images) > 0
+	if replace && (len(in.AddImages) > 0 || len(in.RemoveImageIds) > 0) {
+		return nil, fmt.Errorf(
+			"error: images can't be combined with add_images or remove_image_ids")
 	}
 
-	err = s.StoriesRepo.DeleteStoryTags(in.Id)
+	images, err := s.saveImages(ctx, append(in.Images, in.AddImages...))
 	if err != nil {
-		s.Logger.Error(fmt.Sprintf("error with deleting story tags: %s", err))
+		s.Logger.ErrorContext(ctx, fmt.Sprintf("error with saving images: %s", err))
 		return nil, err
 	}
 
-	err = s.StoriesRepo.CreateStoryTags(in.Id, &in.Tags)
+	edited, err := s.StoriesRepo.EditStory(ctx, in, &models.StoryImagesEdit{
+		Added:     images,
+		Replace:   replace,
+		RemoveIds: in.RemoveImageIds,
+		Restore:   restore,
+	})
 	if err != nil {
-		s.Logger.Error(fmt.Sprintf("error with creating story tags: %s", err))
+		s.Logger.ErrorContext(ctx, fmt.Sprintf("error with editing story: %s", err))
+		s.deleteImages(ctx, images)
 		return nil, err
 	}
+	s.deleteImages(ctx, edited.Deleted)
 
 	resp := pb.ResponseEditStory{
 		Id:        in.Id,
@@ -91,24 +180,30 @@ func (s *Stories) EditStory(ctx context.Context, in *pb.RequestEditStory) (
 		Content:   in.Content,
 		Location:  in.Location,
 		Tags:      in.Tags,
-		AuthorId:  authorId,
+		AuthorId:  edited.AuthorId,
 		UpdatedAt: time.Now().String(),
+		Images:    toPbImages(edited.Images),
+	}
+
+	err = s.Cache.Delete(ctx, redis.StoryKey(in.Id))
+	if err != nil {
+		s.Logger.ErrorContext(ctx,
+			fmt.Sprintf("error with invalidating story cache: %s", err))
 	}
 	return &resp, nil
 }
 
 func (s *Stories) GetStories(ctx context.Context, in *pb.RequestGetStories) (
 	*pb.ResponseGetStories, error) {
-	stories, err := s.StoriesRepo.GetStories(in)
+	stories, err := s.StoriesRepo.GetStories(ctx, in)
 	if err != nil {
-		s.Logger.Error(fmt.Sprintf("error with getting stories: %s", err))
+		s.Logger.ErrorContext(ctx, fmt.Sprintf("error with getting stories: %s", err))
 		return nil, err
 	}
 
 	resp := pb.ResponseGetStories{}
 	for _, val := range *stories {
-		auther, err := s.UserClient.GetAuthorInfo(ctx,
-			&pbUser.RequestGetAuthorInfo{Id: val.AuthorId})
+		auther, err := authorInfo(ctx, s.UserClient, val.AuthorId)
 		if err != nil {
 			return nil, err
 		}
@@ -124,13 +219,17 @@ func (s *Stories) GetStories(ctx context.Context, in *pb.RequestGetStories) (
 			CommentsCount: int64(val.CommentsCount),
 			CreatedAt:     val.CreatedAt,
 		}
+		if val.CoverImage != nil {
+			story.CoverImage = toPbImage(*val.CoverImage)
+		}
 
 		resp.Stories = append(resp.Stories, &story)
 	}
 
-	countOfStories, err := s.StoriesRepo.FindNumberOfStories()
+	countOfStories, err := s.StoriesRepo.FindNumberOfStories(ctx)
 	if err != nil {
-		s.Logger.Error(fmt.Sprintf("error with getting total stories count: %s", err))
+		s.Logger.ErrorContext(ctx,
+			fmt.Sprintf("error with getting total stories count: %s", err))
 		return nil, err
 	}
 	resp.Total = int64(countOfStories)
@@ -140,15 +239,24 @@ func (s *Stories) GetStories(ctx context.Context, in *pb.RequestGetStories) (
 	return &resp, nil
 }
 
-func (s *Stories) GetStoryFullInfo(ctx context.Context, in *pb.RequestGetStoryFullInfo) (
+func (s *Stories) GetStoryFullInfo(ctx context.Context,
+	in *pb.RequestGetStoryFullInfo) (
+	*pb.ResponseGetStoryFullInfo, error) {
+	return redis.ReadThrough(ctx, s.Cache, redis.StoryKey(in.Id),
+		func() (*pb.ResponseGetStoryFullInfo, error) {
+			return s.getStoryFullInfo(ctx, in)
+		})
+}
+
+func (s *Stories) getStoryFullInfo(ctx context.Context,
+	in *pb.RequestGetStoryFullInfo) (
 	*pb.ResponseGetStoryFullInfo, error) {
-	story, err := s.StoriesRepo.GetStoryFullInfo(in.Id)
+	story, err := s.StoriesRepo.GetStoryFullInfo(ctx, in.Id)
 	if err != nil {
 		return nil, err
 	}
 
-	auther, err := s.UserClient.GetAuthorInfo(ctx,
-		&pbUser.RequestGetAuthorInfo{Id: story.AuthorId})
+	auther, err := authorInfo(ctx, s.UserClient, story.AuthorId)
 	if err != nil {
 		return nil, err
 	}
@@ -169,21 +277,49 @@ func (s *Stories) GetStoryFullInfo(ctx context.Context, in *pb.RequestGetStoryFu
 		UpdatedAt:     story.UpdatedAt,
 	}
 
-	tags, err := s.StoriesRepo.GetStoryTags(resp.Id)
+	tags, err := s.StoriesRepo.GetStoryTags(ctx, resp.Id)
 	if err != nil {
 		return nil, err
 	}
 	resp.Tags = *tags
 
+	resp.Images = toPbImages(story.Images)
+
 	return &resp, nil
 }
 
 func (s *Stories) DeleteStory(ctx context.Context, in *pb.RequestDeleteStory) (
 	*pb.ResponseDeleteStory, error) {
-	err := s.StoriesRepo.DeleteStory(in.StoryId)
+	err := s.StoriesRepo.DeleteStory(ctx, in.StoryId)
 	if err != nil {
 		return nil, err
 	}
 
+	err = s.Cache.Delete(ctx, redis.StoryKey(in.StoryId))
+	if err != nil {
+		s.Logger.ErrorContext(ctx,
+			fmt.Sprintf("error with invalidating story cache: %s", err))
+	}
+
 	return &pb.ResponseDeleteStory{Message: "Story was deleted Successfully"}, nil
 }
+
+// RestoreStory undeletes one of the author's stories deleted within the grace
+// period.
+func (s *Stories) RestoreStory(ctx context.Context, in *pb.RequestRestoreStory) (
+	*pb.ResponseRestoreStory, error) {
+	err := s.StoriesRepo.RestoreStory(ctx, in.StoryId, in.AuthorId,
+		time.Now().Add(-s.GracePeriod))
+	if err != nil {
+		s.Logger.ErrorContext(ctx, fmt.Sprintf("error with restoring story: %s", err))
+		return nil, err
+	}
+
+	err = s.Cache.Delete(ctx, redis.StoryKey(in.StoryId))
+	if err != nil {
+		s.Logger.ErrorContext(ctx,
+			fmt.Sprintf("error with invalidating story cache: %s", err))
+	}
+
+	return &pb.ResponseRestoreStory{Message: "Story was restored successfully"}, nil
+}
@@ -0,0 +1,300 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"errors"
+	"image"
+	"image/png"
+	"strings"
+	"testing"
+	"time"
+	pb "travel/genproto/stories"
+	pbUser "travel/genproto/users"
+	"travel/storage/redis"
+)
+
+var testAuthor = &pbUser.ResponseGetAuthorInfo{
+	Id:       "ff9ae172-18f9-4f81-98ff-5db600ce05a7",
+	Username: "saidakbar",
+	FullName: "Saidakbar Pardaboyev",
+}
+
+func NewStoriesService() (*Stories, *fakeStoriesRepo, *fakeUsers) {
+	repo := newFakeStoriesRepo()
+	users := newFakeUsers(testAuthor)
+	images, _ := newTestMedia()
+	return NewContentService(newTestLogger(), repo, users,
+		redis.NewMemoryCache(), images, testGracePeriod), repo, users
+}
+
+func TestCreateStory(t *testing.T) {
+	s, _, _ := NewStoriesService()
+	ctx := context.Background()
+
+	resp, err := s.CreateStory(ctx, &pb.RequestCreateStory{
+		AuthorId: testAuthor.Id,
+		Title:    "Unforgettable Journey to Bali",
+		Tags:     []string{"beach", "food"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	story, err := s.GetStoryFullInfo(ctx, &pb.RequestGetStoryFullInfo{Id: resp.Id})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if story.Author.Username != testAuthor.Username || len(story.Tags) != 2 {
+		t.Errorf("unexpected story: %v", story)
+	}
+}
+
+func TestCreateStoryInvalidUser(t *testing.T) {
+	s, _, _ := NewStoriesService()
+	_, err := s.CreateStory(context.Background(), &pb.RequestCreateStory{
+		AuthorId: "unknown",
+		Title:    "Go Home",
+	})
+	if err == nil {
+		t.Error("expected invalid user error")
+	}
+}
+
+func testImage(t *testing.T) string {
+	t.Helper()
+	buf := bytes.Buffer{}
+	if err := png.Encode(&buf, image.NewRGBA(image.Rect(0, 0, 64, 48))); err != nil {
+		t.Fatal(err)
+	}
+	return base64.StdEncoding.EncodeToString(buf.Bytes())
+}
+
+func TestCreateStoryImages(t *testing.T) {
+	s, _, _ := NewStoriesService()
+	store := s.Media.Store.(*fakeStore)
+	ctx := context.Background()
+
+	resp, err := s.CreateStory(ctx, &pb.RequestCreateStory{
+		AuthorId: testAuthor.Id,
+		Title:    "Unforgettable Journey to Bali",
+		Images:   []string{testImage(t), testImage(t)},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	story, err := s.GetStoryFullInfo(ctx, &pb.RequestGetStoryFullInfo{Id: resp.Id})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(story.Images) != 2 || len(store.blobs) != 4 {
+		t.Fatalf("expected 2 images with thumbnails, got %v and %d blobs",
+			story.Images, len(store.blobs))
+	}
+	img := story.Images[0]
+	if !strings.HasPrefix(img.Url, "http://media.test/stories/") ||
+		img.ThumbnailUrl == "" || img.Width != 64 || img.ContentType != "image/png" {
+		t.Errorf("unexpected image: %v", img)
+	}
+
+	_, err = s.EditStory(ctx, &pb.RequestEditStory{
+		Id:     resp.Id,
+		Title:  "Unforgettable Journey to Bali",
+		Images: []string{testImage(t)},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// the replaced images are kept for the revision saved by the edit
+	story, _ = s.GetStoryFullInfo(ctx, &pb.RequestGetStoryFullInfo{Id: resp.Id})
+	if len(story.Images) != 1 || len(store.blobs) != 6 {
+		t.Errorf("expected the edit to replace the images, got %v and %d blobs",
+			story.Images, len(store.blobs))
+	}
+}
+
+func TestEditStoryImages(t *testing.T) {
+	s, _, _ := NewStoriesService()
+	store := s.Media.Store.(*fakeStore)
+	ctx := context.Background()
+
+	created, err := s.CreateStory(ctx, &pb.RequestCreateStory{
+		AuthorId: testAuthor.Id,
+		Title:    "Go Home",
+		Images:   []string{testImage(t), testImage(t)},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	first, second := created.Images[0], created.Images[1]
+
+	edited, err := s.EditStory(ctx, &pb.RequestEditStory{
+		Id:             created.Id,
+		Title:          "Go Home",
+		AddImages:      []string{testImage(t)},
+		RemoveImageIds: []string{first.Id},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(edited.Images) != 2 || edited.Images[0].Id != second.Id ||
+		len(store.blobs) != 6 {
+		t.Errorf("expected the first image to be replaced, got %v and %d blobs",
+			edited.Images, len(store.blobs))
+	}
+
+	// editing other fields keeps the images
+	_, err = s.EditStory(ctx, &pb.RequestEditStory{Id: created.Id, Title: "Home"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	stories, err := s.GetStories(ctx, &pb.RequestGetStories{Limit: 10})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cover := stories.Stories[0].CoverImage; cover == nil || cover.Id != second.Id {
+		t.Errorf("expected the remaining first image as cover, got %v", cover)
+	}
+
+	_, err = s.EditStory(ctx, &pb.RequestEditStory{
+		Id:             created.Id,
+		Title:          "Away",
+		AddImages:      []string{testImage(t)},
+		RemoveImageIds: []string{first.Id},
+	})
+	if err == nil || len(store.blobs) != 6 {
+		t.Errorf("expected removing a missing image to fail without new blobs, got %v", err)
+	}
+	story, _ := s.GetStoryFullInfo(ctx, &pb.RequestGetStoryFullInfo{Id: created.Id})
+	if story.Title != "Home" || len(story.Images) != 2 {
+		t.Errorf("expected the failed edit to change nothing, got %v", story)
+	}
+}
+
+func TestCreateStoryInvalidImage(t *testing.T) {
+	s, repo, _ := NewStoriesService()
+	_, err := s.CreateStory(context.Background(), &pb.RequestCreateStory{
+		AuthorId: testAuthor.Id,
+		Title:    "Unforgettable Journey to Bali",
+		Images:   []string{testImage(t), "base64_encoded_image2"},
+	})
+	if err == nil || !strings.Contains(err.Error(), "invalid image 2") {
+		t.Errorf("expected the second image to be rejected, got %v", err)
+	}
+	if len(repo.stories) != 0 || len(s.Media.Store.(*fakeStore).blobs) != 0 {
+		t.Error("expected nothing to be stored")
+	}
+}
+
+func TestGetStoryFullInfoCached(t *testing.T) {
+	s, repo, users := NewStoriesService()
+	ctx := context.Background()
+	id, _ := repo.CreateStory(ctx, &pb.RequestCreateStory{
+		AuthorId: testAuthor.Id,
+		Title:    "Go Home",
+	})
+
+	for i := 0; i < 3; i++ {
+		if _, err := s.GetStoryFullInfo(ctx, &pb.RequestGetStoryFullInfo{Id: id}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if users.calls != 1 {
+		t.Errorf("expected 1 users service call, got %d", users.calls)
+	}
+
+	_, err := s.EditStory(ctx, &pb.RequestEditStory{Id: id, Title: "Sleeping well"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	story, err := s.GetStoryFullInfo(ctx, &pb.RequestGetStoryFullInfo{Id: id})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if story.Title != "Sleeping well" {
+		t.Errorf("expected edit to invalidate cache, got %s", story.Title)
+	}
+}
+
+func TestGetStories(t *testing.T) {
+	s, repo, _ := NewStoriesService()
+	ctx := context.Background()
+	for _, title := range []string{"one", "two", "three"} {
+		repo.CreateStory(ctx, &pb.RequestCreateStory{AuthorId: testAuthor.Id, Title: title})
+	}
+
+	resp, err := s.GetStories(ctx, &pb.RequestGetStories{
+		Page:  0,
+		Limit: 2,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(resp.Stories) != 2 || resp.Total != 3 {
+		t.Errorf("expected 2 of 3 stories, got %d of %d", len(resp.Stories), resp.Total)
+	}
+}
+
+func TestGetStoriesCanceled(t *testing.T) {
+	s, _, users := NewStoriesService()
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err := s.GetStories(ctx, &pb.RequestGetStories{Limit: 10})
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("expected the RPC context to reach the repo, got %v", err)
+	}
+	if users.calls != 0 {
+		t.Errorf("expected no users service calls, got %d", users.calls)
+	}
+}
+
+func TestDeleteStory(t *testing.T) {
+	s, repo, _ := NewStoriesService()
+	ctx := context.Background()
+	id, _ := repo.CreateStory(ctx, &pb.RequestCreateStory{AuthorId: testAuthor.Id})
+
+	if _, err := s.GetStoryFullInfo(ctx, &pb.RequestGetStoryFullInfo{Id: id}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.DeleteStory(ctx, &pb.RequestDeleteStory{StoryId: id}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.GetStoryFullInfo(ctx, &pb.RequestGetStoryFullInfo{Id: id}); err == nil {
+		t.Error("expected deleted story not to be served from cache")
+	}
+}
+
+func TestRestoreStory(t *testing.T) {
+	s, repo, _ := NewStoriesService()
+	ctx := context.Background()
+	id, _ := repo.CreateStory(ctx, &pb.RequestCreateStory{
+		AuthorId: testAuthor.Id,
+		Title:    "Go Home",
+	})
+	if _, err := s.DeleteStory(ctx, &pb.RequestDeleteStory{StoryId: id}); err != nil {
+		t.Fatal(err)
+	}
+
+	_, err := s.RestoreStory(ctx, &pb.RequestRestoreStory{StoryId: id, AuthorId: "other"})
+	if err == nil {
+		t.Error("expected restoring another author's story to fail")
+	}
+	_, err = s.RestoreStory(ctx, &pb.RequestRestoreStory{StoryId: id,
+		AuthorId: testAuthor.Id})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.GetStoryFullInfo(ctx, &pb.RequestGetStoryFullInfo{Id: id}); err != nil {
+		t.Errorf("expected the story to be restored, got %v", err)
+	}
+
+	s.DeleteStory(ctx, &pb.RequestDeleteStory{StoryId: id})
+	repo.stories[id].deletedAt = time.Now().Add(-2 * testGracePeriod)
+	_, err = s.RestoreStory(ctx, &pb.RequestRestoreStory{StoryId: id,
+		AuthorId: testAuthor.Id})
+	if err == nil {
+		t.Error("expected restoring after the grace period to fail")
+	}
+}
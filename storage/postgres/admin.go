@@ -0,0 +1,412 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"log/slog"
+	"time"
+	"travel/models"
+
+	"github.com/lib/pq"
+)
+
+type AdminRepo struct {
+	Logger  *slog.Logger
+	DB      *sql.DB
+	Timeout time.Duration
+}
+
+func NewAdminRepo(db *sql.DB, logger *slog.Logger,
+	timeout time.Duration) *AdminRepo {
+	return &AdminRepo{
+		Logger:  logger,
+		DB:      db,
+		Timeout: timeout,
+	}
+}
+
+const deletedQuery = `
+	select
+		id, kind, title, author_id, deleted_at
+	from (
+		select
+			id, 'story' as kind, title, author_id, deleted_at
+		from
+			stories
+		where
+			deleted_at is not null
+		union all
+		select
+			id, 'itinerary' as kind, title, author_id, deleted_at
+		from
+			itineraries
+		where
+			deleted_at is not null
+	) deleted
+	where
+		$1 = '' or kind = $1
+`
+
+// ListDeleted lists the soft-deleted stories and itineraries, the latest
+// deleted first. An empty kind lists both.
+func (a *AdminRepo) ListDeleted(ctx context.Context, kind string, page,
+	limit int32) (*[]models.DeletedItem, error) {
+	ctx, cancel := withTimeout(ctx, a.Timeout)
+	defer cancel()
+
+	query := deletedQuery + `
+		order by
+			deleted_at desc, id
+		limit $2
+		offset $3
+	`
+
+	rows, err := a.DB.QueryContext(ctx, query, kind, limit, limit*page)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	items := []models.DeletedItem{}
+	for rows.Next() {
+		item := models.DeletedItem{}
+		err := rows.Scan(&item.Id, &item.Kind, &item.Title, &item.AuthorId,
+			&item.DeletedAt)
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, item)
+	}
+	return &items, rows.Err()
+}
+
+func (a *AdminRepo) CountDeleted(ctx context.Context, kind string) (int, error) {
+	ctx, cancel := withTimeout(ctx, a.Timeout)
+	defer cancel()
+
+	query := `select count(*) from (` + deletedQuery + `) items`
+
+	count := 0
+	err := a.DB.QueryRowContext(ctx, query, kind).Scan(&count)
+	return count, err
+}
+
+// PurgeStories permanently deletes up to limit stories deleted before cutoff,
+// with their likes, comments, tags, revisions and images. It returns the
+// deleted images, whose blobs are left to the caller.
+func (a *AdminRepo) PurgeStories(ctx context.Context, cutoff time.Time,
+	limit int) (*models.PurgeResult, error) {
+	ctx, cancel := withTimeout(ctx, a.Timeout)
+	defer cancel()
+
+	tx, err := a.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	ids, err := purgeIds(ctx, tx, "stories", cutoff, limit)
+	if err != nil || len(ids) == 0 {
+		return &models.PurgeResult{}, err
+	}
+
+	err = execAll(ctx, tx, pq.Array(ids),
+		`delete from likes where story_id = any($1::uuid[])`,
+		`delete from comments where story_id = any($1::uuid[])`,
+		`delete from story_tags where story_id = any($1::uuid[])`,
+		`delete from story_revisions where story_id = any($1::uuid[])`)
+	if err != nil {
+		return nil, err
+	}
+
+	query := `
+		delete from
+			story_images
+		where
+			story_id = any($1::uuid[])
+		returning
+			id, key, url, thumbnail_key, thumbnail_url, content_type, width, height
+	`
+	rows, err := tx.QueryContext(ctx, query, pq.Array(ids))
+	if err != nil {
+		return nil, err
+	}
+	images, err := scanImages(rows)
+	rows.Close()
+	if err != nil {
+		return nil, err
+	}
+
+	err = execAll(ctx, tx, pq.Array(ids),
+		`delete from stories where id = any($1::uuid[])`)
+	if err != nil {
+		return nil, err
+	}
+	return &models.PurgeResult{Stories: len(ids), Images: images}, tx.Commit()
+}
+
+// PurgeItineraries permanently deletes up to limit itineraries deleted before
+// cutoff, with their destinations, activities and comments. The destinations
+// and activities of the other itineraries deleted before cutoff on their own
+// are deleted too.
+func (a *AdminRepo) PurgeItineraries(ctx context.Context, cutoff time.Time,
+	limit int) (*models.PurgeResult, error) {
+	ctx, cancel := withTimeout(ctx, a.Timeout)
+	defer cancel()
+
+	tx, err := a.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	err = execAll(ctx, tx, cutoff,
+		`delete from itinerary_activities a
+		using itinerary_destinations d, itineraries i
+		where a.destination_id = d.id and d.itinerary_id = i.id and
+			i.deleted_at is null and (a.deleted_at < $1 or d.deleted_at < $1)`,
+		`delete from itinerary_destinations d
+		using itineraries i
+		where d.itinerary_id = i.id and i.deleted_at is null and d.deleted_at < $1`)
+	if err != nil {
+		return nil, err
+	}
+
+	ids, err := purgeIds(ctx, tx, "itineraries", cutoff, limit)
+	if err != nil {
+		return nil, err
+	}
+	if len(ids) == 0 {
+		return &models.PurgeResult{}, tx.Commit()
+	}
+
+	err = execAll(ctx, tx, pq.Array(ids),
+		`delete from itinerary_activities where destination_id in (
+			select id from itinerary_destinations where itinerary_id = any($1::uuid[]))`,
+		`delete from itinerary_destinations where itinerary_id = any($1::uuid[])`,
+		`delete from commentsForItinerary where itinerary_id = any($1::uuid[])`,
+		`delete from itineraries where id = any($1::uuid[])`)
+	if err != nil {
+		return nil, err
+	}
+	return &models.PurgeResult{Itineraries: len(ids)}, tx.Commit()
+}
+
+// PurgeComments permanently deletes the comments, on both stories and
+// itineraries, deleted before cutoff.
+func (a *AdminRepo) PurgeComments(ctx context.Context, cutoff time.Time) (
+	*models.PurgeResult, error) {
+	ctx, cancel := withTimeout(ctx, a.Timeout)
+	defer cancel()
+
+	res := models.PurgeResult{}
+	for _, query := range []string{
+		`delete from comments where deleted_at < $1`,
+		`delete from commentsForItinerary where deleted_at < $1`,
+	} {
+		deleted, err := a.DB.ExecContext(ctx, query, cutoff)
+		if err != nil {
+			return nil, err
+		}
+		num, _ := deleted.RowsAffected()
+		res.Comments += int(num)
+	}
+	return &res, nil
+}
+
+// purgeIds locks up to limit rows of table deleted before cutoff, skipping the
+// ones another purge holds.
+func purgeIds(ctx context.Context, tx *sql.Tx, table string, cutoff time.Time,
+	limit int) ([]string, error) {
+	query := `
+		select
+			id
+		from
+			` + table + `
+		where
+			deleted_at < $1
+		order by
+			deleted_at
+		limit $2
+		for update skip locked
+	`
+
+	return queryIds(ctx, tx, query, cutoff, limit)
+}
+
+func execAll(ctx context.Context, tx *sql.Tx, arg any, queries ...string) error {
+	for _, query := range queries {
+		if _, err := tx.ExecContext(ctx, query, arg); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// PurgeUserContent deletes the stories, itineraries, comments and messages of
+// the user, or keeps them under models.DeletedUserId when anonymizing, in one
+// transaction. Their likes are removed either way. The counters of the other
+// users' stories and itineraries they touched are recounted.
+func (a *AdminRepo) PurgeUserContent(ctx context.Context, userId, mode string,
+	now time.Time) (*models.UserContentResult, error) {
+	ctx, cancel := withTimeout(ctx, a.Timeout)
+	defer cancel()
+
+	tx, err := a.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	// anonymizing also covers the content already deleted, as it is kept
+	// until purged
+	storiesQuery := `
+		update stories set author_id = $2
+		where author_id = $1
+		returning id`
+	itinerariesQuery := `
+		update itineraries set author_id = $2
+		where author_id = $1
+		returning id`
+	commentsQuery := `
+		update comments set author_id = $2
+		where author_id = $1
+		returning story_id`
+	itineraryCommentsQuery := `
+		update commentsForItinerary set author_id = $2
+		where author_id = $1
+		returning itinerary_id`
+	messagesQuery := `
+		update messages set sender_id = $2
+		where sender_id = $1`
+	arg := any(models.DeletedUserId)
+	if mode == models.PurgeDelete {
+		storiesQuery = `
+			update stories set deleted_at = $2
+			where author_id = $1 and deleted_at is null
+			returning id`
+		itinerariesQuery = `
+			update itineraries set deleted_at = $2
+			where author_id = $1 and deleted_at is null
+			returning id`
+		commentsQuery = `
+			update comments set deleted_at = $2
+			where author_id = $1 and deleted_at is null
+			returning story_id`
+		itineraryCommentsQuery = `
+			update commentsForItinerary set deleted_at = $2
+			where author_id = $1 and deleted_at is null
+			returning itinerary_id`
+		messagesQuery = `
+			update messages set deleted_at = $2
+			where sender_id = $1 and deleted_at is null`
+		arg = now
+	}
+
+	res := models.UserContentResult{}
+	stories, err := queryIds(ctx, tx, storiesQuery, userId, arg)
+	if err != nil {
+		return nil, err
+	}
+	itineraries, err := queryIds(ctx, tx, itinerariesQuery, userId, arg)
+	if err != nil {
+		return nil, err
+	}
+	commented, err := queryIds(ctx, tx, commentsQuery, userId, arg)
+	if err != nil {
+		return nil, err
+	}
+	commentedItineraries, err := queryIds(ctx, tx, itineraryCommentsQuery, userId, arg)
+	if err != nil {
+		return nil, err
+	}
+	liked, err := queryIds(ctx, tx, `
+		delete from likes
+		where user_id = $1
+		returning story_id`, userId)
+	if err != nil {
+		return nil, err
+	}
+
+	messages, err := tx.ExecContext(ctx, messagesQuery, userId, arg)
+	if err != nil {
+		return nil, err
+	}
+	num, _ := messages.RowsAffected()
+	res.Messages = int(num)
+
+	updated, err := queryIds(ctx, tx, `
+		update
+			stories s
+		set
+			likes_count = (
+				select count(*) from likes l where l.story_id = s.id),
+			comments_count = (
+				select count(*) from comments c
+				where c.story_id = s.id and c.deleted_at is null)
+		where
+			s.id = any($1::uuid[]) and
+			s.author_id <> $2
+		returning
+			s.id`, pq.Array(unique(append(commented, liked...))), userId)
+	if err != nil {
+		return nil, err
+	}
+	updatedItineraries, err := queryIds(ctx, tx, `
+		update
+			itineraries i
+		set
+			comments_count = (
+				select count(*) from commentsForItinerary c
+				where c.itinerary_id = i.id and c.deleted_at is null)
+		where
+			i.id = any($1::uuid[]) and
+			i.author_id <> $2
+		returning
+			i.id`, pq.Array(unique(commentedItineraries)), userId)
+	if err != nil {
+		return nil, err
+	}
+
+	res.Stories = len(stories)
+	res.Itineraries = len(itineraries)
+	res.Comments = len(commented)
+	res.ItineraryComments = len(commentedItineraries)
+	res.Likes = len(liked)
+	res.UpdatedStories = len(updated)
+	res.UpdatedItineraries = len(updatedItineraries)
+	res.StoryIds = append(stories, updated...)
+	res.ItineraryIds = append(itineraries, updatedItineraries...)
+	return &res, tx.Commit()
+}
+
+func queryIds(ctx context.Context, tx *sql.Tx, query string, args ...any) (
+	[]string, error) {
+	rows, err := tx.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	ids := []string{}
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+	return ids, rows.Err()
+}
+
+func unique(ids []string) []string {
+	seen := map[string]bool{}
+	res := []string{}
+	for _, id := range ids {
+		if !seen[id] {
+			seen[id] = true
+			res = append(res, id)
+		}
+	}
+	return res
+}
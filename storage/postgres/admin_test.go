@@ -0,0 +1,219 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+	pbInter "travel/genproto/interactions"
+	pbItiner "travel/genproto/itineraries"
+	pb "travel/genproto/stories"
+	"travel/models"
+
+	"github.com/google/uuid"
+)
+
+func NewAdminTestRepo(t *testing.T) *AdminRepo {
+	return NewAdminRepo(NewTestDB(t), testLogger, 0)
+}
+
+// deleteAt soft deletes the row of table with id at the given time.
+func deleteAt(t *testing.T, db *sql.DB, table, id string, at time.Time) {
+	t.Helper()
+	_, err := db.Exec(`update `+table+` set deleted_at = $1 where id = $2`, at, id)
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestListDeleted(t *testing.T) {
+	ctx := context.Background()
+	repo := NewAdminTestRepo(t)
+	storyId := seedStory(t, repo.DB)
+	seedStory(t, repo.DB)
+	itineraryId, _ := seedItinerary(t, repo.DB)
+	deleteAt(t, repo.DB, "stories", storyId, time.Now().Add(-time.Hour))
+	deleteAt(t, repo.DB, "itineraries", itineraryId, time.Now())
+
+	items, err := repo.ListDeleted(ctx, "", 0, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(*items) != 2 || (*items)[0].Id != itineraryId ||
+		(*items)[1].Kind != models.KindStory || (*items)[1].AuthorId != testAuthorId {
+		t.Errorf("expected the itinerary then the story, got %v", *items)
+	}
+
+	count, err := repo.CountDeleted(ctx, models.KindStory)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if count != 1 {
+		t.Errorf("expected 1 deleted story, got %d", count)
+	}
+}
+
+func TestPurge(t *testing.T) {
+	ctx := context.Background()
+	repo := NewAdminTestRepo(t)
+	stories := NewStoriesRepo(repo.DB, testLogger, 0)
+	cutoff := time.Now().Add(-24 * time.Hour)
+
+	oldId, keptId, liveId := seedStory(t, repo.DB), seedStory(t, repo.DB),
+		seedStory(t, repo.DB)
+	for _, id := range []string{oldId, keptId, liveId} {
+		err := stories.CreateStoryImages(ctx, id, []models.Image{{
+			Key: "stories/" + id + ".png", ThumbnailKey: "stories/" + id + "_thumb.png",
+			ContentType: "image/png", Width: 1, Height: 1,
+		}})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := stories.CreateStoryTags(ctx, id, &[]string{"beach"}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	interactions := NewInterationsRepo(repo.DB, testLogger, 0)
+	_, err := interactions.CreateComment(ctx, &pbInter.RequestCreateComment{
+		StoryId: oldId, AuthorId: testUserId, Content: "Nice",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	interactions.CreateLike(ctx, &pbInter.RequestLikeStory{StoryId: oldId, UserId: testUserId})
+	_, err = stories.EditStory(ctx, &pb.RequestEditStory{Id: oldId, Title: "Edited"}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	deleteAt(t, repo.DB, "stories", oldId, cutoff.Add(-time.Hour))
+	deleteAt(t, repo.DB, "stories", keptId, cutoff.Add(time.Hour))
+
+	itineraryId, _ := seedItinerary(t, repo.DB)
+	_, err = NewItinerariesRepo(repo.DB, testLogger, 0).WriteCommentToItinerary(ctx,
+		&pbItiner.RequestWriteCommentToItinerary{
+			ItineraryId: itineraryId, AuthorId: testUserId, Content: "Nice",
+		})
+	if err != nil {
+		t.Fatal(err)
+	}
+	deleteAt(t, repo.DB, "itineraries", itineraryId, cutoff.Add(-time.Hour))
+
+	// the destinations and activities of a live itinerary deleted on their own
+	_, des := seedItinerary(t, repo.DB)
+	deleteAt(t, repo.DB, "itinerary_destinations", des.Id, cutoff.Add(-time.Hour))
+	_, keptDes := seedItinerary(t, repo.DB)
+	deleteAt(t, repo.DB, "itinerary_activities", keptDes.Activities[0].Id,
+		cutoff.Add(-time.Hour))
+	deleteAt(t, repo.DB, "itinerary_activities", keptDes.Activities[1].Id,
+		cutoff.Add(time.Hour))
+
+	res, err := repo.PurgeStories(ctx, cutoff, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.Stories != 1 || len(res.Images) != 1 ||
+		res.Images[0].Key != "stories/"+oldId+".png" {
+		t.Errorf("expected the old story with its image to be purged, got %v", res)
+	}
+	res, err = repo.PurgeItineraries(ctx, cutoff, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.Itineraries != 1 {
+		t.Errorf("expected the itinerary to be purged, got %v", res)
+	}
+
+	for table, want := range map[string]int{
+		"stories":                2,
+		"story_images":           2,
+		"story_tags":             2,
+		"story_revisions":        0,
+		"comments":               0,
+		"likes":                  0,
+		"itineraries":            2,
+		"itinerary_destinations": 1,
+		"itinerary_activities":   1,
+		"commentsForItinerary":   0,
+	} {
+		count := 0
+		if err := repo.DB.QueryRow(`select count(*) from ` + table).Scan(&count); err != nil {
+			t.Fatal(err)
+		}
+		if count != want {
+			t.Errorf("expected %d rows in %s, got %d", want, table, count)
+		}
+	}
+}
+
+func TestPurgeUserContent(t *testing.T) {
+	ctx := context.Background()
+	repo := NewAdminTestRepo(t)
+	interactions := NewInterationsRepo(repo.DB, testLogger, 0)
+	stories := NewStoriesRepo(repo.DB, testLogger, 0)
+	otherId := uuid.NewString()
+
+	own := seedStory(t, repo.DB)
+	other, err := stories.CreateStory(ctx, &pb.RequestCreateStory{
+		AuthorId: otherId, Title: "Go Home", Content: "...",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	itineraryId, _ := seedItinerary(t, repo.DB)
+	for _, userId := range []string{testAuthorId, testUserId} {
+		interactions.LikeStory(ctx, other)
+		interactions.CreateLike(ctx, &pbInter.RequestLikeStory{StoryId: other, UserId: userId})
+		_, err := interactions.CreateComment(ctx, &pbInter.RequestCreateComment{
+			StoryId: other, AuthorId: userId, Content: "Nice",
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	_, err = repo.DB.Exec(`insert into messages (sender_id, recipient_id, content)
+		values ($1, $2, 'Hi')`, testAuthorId, otherId)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	res, err := repo.PurgeUserContent(ctx, testAuthorId, models.PurgeAnonymize, time.Now())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.Stories != 1 || res.StoryIds[0] != own || res.Itineraries != 1 ||
+		res.ItineraryIds[0] != itineraryId || res.Comments != 1 || res.Likes != 1 ||
+		res.Messages != 1 || res.UpdatedStories != 1 {
+		t.Errorf("unexpected purge result: %+v", res)
+	}
+
+	info, err := stories.GetStoryFullInfo(ctx, other)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.LikesCount != 1 || info.CommentsCount != 2 {
+		t.Errorf("expected 1 like and 2 comments, got %d and %d", info.LikesCount,
+			info.CommentsCount)
+	}
+	for table, want := range map[string]int{
+		"stories where author_id = $1 and deleted_at is null":     1,
+		"itineraries where author_id = $1 and deleted_at is null": 1,
+		"comments where author_id = $1":                           1,
+		"messages where sender_id = $1 and deleted_at is null":    1,
+	} {
+		count := 0
+		err = repo.DB.QueryRow(`select count(*) from `+table,
+			models.DeletedUserId).Scan(&count)
+		if err != nil || count != want {
+			t.Errorf("expected %d anonymized rows in %s, got %d: %v", want, table,
+				count, err)
+		}
+	}
+
+	res, err = repo.PurgeUserContent(ctx, testAuthorId, models.PurgeDelete, time.Now())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.Stories+res.Itineraries+res.Comments+res.Likes+res.Messages != 0 {
+		t.Errorf("expected purging again to affect nothing, got %+v", res)
+	}
+}
@@ -0,0 +1,129 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+	"testing"
+	pbInter "travel/genproto/interactions"
+)
+
+// The benchmarks run each query on the current schema with and without the
+// indexes of the indexes migration it uses, and log the query plan, so the
+// difference shows up in -v output:
+//
+//	go test ./storage/postgres -run '^$' -bench . -v
+var indexBenchmarks = []struct {
+	name    string
+	indexes bool
+}{
+	{name: "without_indexes", indexes: false},
+	{name: "with_indexes", indexes: true},
+}
+
+// benchIndexes are the indexes of 000002_add_indexes_and_constraints the
+// benchmarked queries use. They are dropped by name rather than by rolling
+// back migrations, which would also revert the later ones the repos need.
+var benchIndexes = []string{
+	"comments_story_id_idx",
+	"itinerary_destinations_itinerary_id_idx",
+	"itinerary_activities_destination_id_idx",
+}
+
+// NewBenchDB returns a schema with 1000 stories, 20 comments each, and 1000
+// itineraries with 3 destinations of 3 activities each, without benchIndexes
+// unless indexes is set.
+func NewBenchDB(b *testing.B, indexes bool) *sql.DB {
+	db := NewTestDB(b)
+	if !indexes {
+		for _, index := range benchIndexes {
+			if _, err := db.Exec(`drop index ` + index); err != nil {
+				b.Fatal(err)
+			}
+		}
+	}
+
+	seed := []string{`
+		insert into stories (id, title, content, author_id)
+		select gen_random_uuid(), 'story ' || n, 'content', gen_random_uuid()
+		from generate_series(1, 1000) n`, `
+		insert into comments (content, author_id, story_id)
+		select 'comment', gen_random_uuid(), s.id
+		from stories s, generate_series(1, 20)`, `
+		insert into itineraries (title, start_date, end_date, author_id)
+		select 'itinerary ' || n, '2024-07-01', '2024-07-10', gen_random_uuid()
+		from generate_series(1, 1000) n`, `
+		insert into itinerary_destinations (itinerary_id, name, start_date, end_date)
+		select i.id, 'destination', '2024-07-01', '2024-07-03'
+		from itineraries i, generate_series(1, 3)`, `
+		insert into itinerary_activities (destination_id, activity)
+		select d.id, 'activity'
+		from itinerary_destinations d, generate_series(1, 3)`,
+		`analyze`,
+	}
+	for _, query := range seed {
+		if _, err := db.Exec(query); err != nil {
+			b.Fatal(err)
+		}
+	}
+	return db
+}
+
+func logPlan(b *testing.B, db *sql.DB, query string, args ...interface{}) {
+	rows, err := db.Query("explain "+query, args...)
+	if err != nil {
+		b.Fatal(err)
+	}
+	defer rows.Close()
+
+	plan := []string{}
+	for rows.Next() {
+		var line string
+		if err := rows.Scan(&line); err != nil {
+			b.Fatal(err)
+		}
+		plan = append(plan, line)
+	}
+	b.Log("\n" + strings.Join(plan, "\n"))
+}
+
+func BenchmarkGetComments(b *testing.B) {
+	ctx := context.Background()
+	for _, bc := range indexBenchmarks {
+		b.Run(bc.name, func(b *testing.B) {
+			repo := NewInterationsRepo(NewBenchDB(b, bc.indexes), testLogger, 0)
+			var storyId string
+			repo.DB.QueryRow("select id from stories limit 1").Scan(&storyId)
+			logPlan(b, repo.DB, `select id from comments
+				where story_id = $1 and deleted_at is null`, storyId)
+
+			req := pbInter.RequestGetComments{StoryId: storyId, Limit: 10}
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				if _, err := repo.GetComments(ctx, &req); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkGetItinerariesDestinations(b *testing.B) {
+	ctx := context.Background()
+	for _, bc := range indexBenchmarks {
+		b.Run(bc.name, func(b *testing.B) {
+			repo := NewItinerariesRepo(NewBenchDB(b, bc.indexes), testLogger, 0)
+			var itineraryId string
+			repo.DB.QueryRow("select id from itineraries limit 1").Scan(&itineraryId)
+			logPlan(b, repo.DB, `select id from itinerary_destinations
+				where itinerary_id = $1 and deleted_at is null`, itineraryId)
+
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				if _, err := repo.GetItinerariesDestinations(ctx, itineraryId); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,207 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"travel/models"
+)
+
+// The content of a user for exporting it, including the deleted content not
+// purged yet, the oldest first.
+
+func (a *AdminRepo) GetUserStories(ctx context.Context, userId string) (
+	*[]models.UserStory, error) {
+	ctx, cancel := withTimeout(ctx, a.Timeout)
+	defer cancel()
+
+	query := `
+		select
+			id, title, content, coalesce(location, ''), status, publish_at,
+			created_at, updated_at, deleted_at
+		from
+			stories
+		where
+			author_id = $1
+		order by
+			created_at, id
+	`
+
+	rows, err := a.DB.QueryContext(ctx, query, userId)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	stories := []models.UserStory{}
+	for rows.Next() {
+		story := models.UserStory{}
+		var publishAt, deletedAt sql.NullString
+		err := rows.Scan(&story.Id, &story.Title, &story.Content, &story.Location,
+			&story.Status, &publishAt, &story.CreatedAt, &story.UpdatedAt, &deletedAt)
+		if err != nil {
+			return nil, err
+		}
+		story.PublishAt = publishAt.String
+		story.DeletedAt = deletedAt.String
+		stories = append(stories, story)
+	}
+	return &stories, rows.Err()
+}
+
+func (a *AdminRepo) GetUserItineraries(ctx context.Context, userId string) (
+	*[]models.UserItinerary, error) {
+	ctx, cancel := withTimeout(ctx, a.Timeout)
+	defer cancel()
+
+	query := `
+		select
+			id, title, coalesce(description, ''), start_date, end_date,
+			created_at, updated_at, deleted_at
+		from
+			itineraries
+		where
+			author_id = $1
+		order by
+			created_at, id
+	`
+
+	rows, err := a.DB.QueryContext(ctx, query, userId)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	itineraries := []models.UserItinerary{}
+	for rows.Next() {
+		itinerary := models.UserItinerary{}
+		var deletedAt sql.NullString
+		err := rows.Scan(&itinerary.Id, &itinerary.Title, &itinerary.Description,
+			&itinerary.StartDate, &itinerary.EndDate, &itinerary.CreatedAt,
+			&itinerary.UpdatedAt, &deletedAt)
+		if err != nil {
+			return nil, err
+		}
+		itinerary.DeletedAt = deletedAt.String
+		itineraries = append(itineraries, itinerary)
+	}
+	return &itineraries, rows.Err()
+}
+
+// GetUserComments returns the comments of the user on both stories and
+// itineraries.
+func (a *AdminRepo) GetUserComments(ctx context.Context, userId string) (
+	*[]models.UserComment, error) {
+	ctx, cancel := withTimeout(ctx, a.Timeout)
+	defer cancel()
+
+	query := `
+		select
+			id, coalesce(story_id::text, ''), '' as itinerary_id, content,
+			created_at, deleted_at
+		from
+			comments
+		where
+			author_id = $1
+		union all
+		select
+			id, '', coalesce(itinerary_id::text, ''), content,
+			created_at, deleted_at
+		from
+			commentsForItinerary
+		where
+			author_id = $1
+		order by
+			created_at, id
+	`
+
+	rows, err := a.DB.QueryContext(ctx, query, userId)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	comments := []models.UserComment{}
+	for rows.Next() {
+		comment := models.UserComment{}
+		var deletedAt sql.NullString
+		err := rows.Scan(&comment.Id, &comment.StoryId, &comment.ItineraryId,
+			&comment.Content, &comment.CreatedAt, &deletedAt)
+		if err != nil {
+			return nil, err
+		}
+		comment.DeletedAt = deletedAt.String
+		comments = append(comments, comment)
+	}
+	return &comments, rows.Err()
+}
+
+func (a *AdminRepo) GetUserLikes(ctx context.Context, userId string) (
+	*[]models.Like, error) {
+	ctx, cancel := withTimeout(ctx, a.Timeout)
+	defer cancel()
+
+	query := `
+		select
+			story_id, created_at
+		from
+			likes
+		where
+			user_id = $1
+		order by
+			created_at, story_id
+	`
+
+	rows, err := a.DB.QueryContext(ctx, query, userId)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	likes := []models.Like{}
+	for rows.Next() {
+		like := models.Like{}
+		if err := rows.Scan(&like.StoryId, &like.CreatedAt); err != nil {
+			return nil, err
+		}
+		likes = append(likes, like)
+	}
+	return &likes, rows.Err()
+}
+
+// GetUserMessages returns the messages the user sent.
+func (a *AdminRepo) GetUserMessages(ctx context.Context, userId string) (
+	*[]models.Message, error) {
+	ctx, cancel := withTimeout(ctx, a.Timeout)
+	defer cancel()
+
+	query := `
+		select
+			id, coalesce(recipient_id::text, ''), content, created_at, deleted_at
+		from
+			messages
+		where
+			sender_id = $1
+		order by
+			created_at, id
+	`
+
+	rows, err := a.DB.QueryContext(ctx, query, userId)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	messages := []models.Message{}
+	for rows.Next() {
+		message := models.Message{}
+		var deletedAt sql.NullString
+		err := rows.Scan(&message.Id, &message.RecipientId, &message.Content,
+			&message.CreatedAt, &deletedAt)
+		if err != nil {
+			return nil, err
+		}
+		message.DeletedAt = deletedAt.String
+		messages = append(messages, message)
+	}
+	return &messages, rows.Err()
+}
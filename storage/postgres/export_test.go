@@ -0,0 +1,78 @@
+package postgres
+
+import (
+	"context"
+	"testing"
+	"time"
+	pbInter "travel/genproto/interactions"
+	pbItiner "travel/genproto/itineraries"
+
+	"github.com/google/uuid"
+)
+
+func TestGetUserContent(t *testing.T) {
+	ctx := context.Background()
+	repo := NewAdminTestRepo(t)
+	interactions := NewInterationsRepo(repo.DB, testLogger, 0)
+	itineraries := NewItinerariesRepo(repo.DB, testLogger, 0)
+	otherId := uuid.NewString()
+
+	live, deleted := seedStory(t, repo.DB), seedStory(t, repo.DB)
+	deleteAt(t, repo.DB, "stories", deleted, time.Now())
+	itineraryId, _ := seedItinerary(t, repo.DB)
+	interactions.CreateLike(ctx, &pbInter.RequestLikeStory{StoryId: live, UserId: testAuthorId})
+	_, err := interactions.CreateComment(ctx, &pbInter.RequestCreateComment{
+		StoryId: live, AuthorId: testAuthorId, Content: "Nice",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = itineraries.WriteCommentToItinerary(ctx, &pbItiner.RequestWriteCommentToItinerary{
+		ItineraryId: itineraryId, AuthorId: testAuthorId, Content: "Nice",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = repo.DB.Exec(`insert into messages (sender_id, recipient_id, content)
+		values ($1, $2, 'Hi'), ($2, $1, 'Hello')`, testAuthorId, otherId)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	stories, err := repo.GetUserStories(ctx, testAuthorId)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(*stories) != 2 || (*stories)[0].Id != live || (*stories)[1].DeletedAt == "" {
+		t.Errorf("expected the live and the deleted story, got %v", *stories)
+	}
+	its, err := repo.GetUserItineraries(ctx, testAuthorId)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(*its) != 1 || (*its)[0].Id != itineraryId {
+		t.Errorf("expected the itinerary, got %v", *its)
+	}
+	comments, err := repo.GetUserComments(ctx, testAuthorId)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(*comments) != 2 || (*comments)[0].StoryId != live ||
+		(*comments)[1].ItineraryId != itineraryId {
+		t.Errorf("expected the story and the itinerary comment, got %v", *comments)
+	}
+	likes, err := repo.GetUserLikes(ctx, testAuthorId)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(*likes) != 1 || (*likes)[0].StoryId != live {
+		t.Errorf("expected the like, got %v", *likes)
+	}
+	messages, err := repo.GetUserMessages(ctx, testAuthorId)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(*messages) != 1 || (*messages)[0].RecipientId != otherId {
+		t.Errorf("expected only the sent message, got %v", *messages)
+	}
+}
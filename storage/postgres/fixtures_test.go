@@ -0,0 +1,83 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"io"
+	"log/slog"
+	"testing"
+	pbItiner "travel/genproto/itineraries"
+	pb "travel/genproto/stories"
+
+	"github.com/google/uuid"
+)
+
+var (
+	testAuthorId = uuid.NewString()
+	testUserId   = uuid.NewString()
+	testLogger   = slog.New(slog.NewTextHandler(io.Discard, nil))
+)
+
+func seedStory(t *testing.T, db *sql.DB) string {
+	t.Helper()
+	ctx := context.Background()
+	id, err := NewStoriesRepo(db, testLogger, 0).CreateStory(ctx, &pb.RequestCreateStory{
+		AuthorId: testAuthorId,
+		Title:    "Unforgettable Journey to Bali",
+		Content:  "My amazing experience exploring Bali's beaches and culture...",
+		Location: "Bali, Indonesia",
+		Images:   []string{"bali.jpg"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return id
+}
+
+// seedItinerary creates an itinerary with one destination and two activities.
+func seedItinerary(t *testing.T, db *sql.DB) (string, *pbItiner.DestinationEdit) {
+	t.Helper()
+	ctx := context.Background()
+	repo := NewItinerariesRepo(db, testLogger, 0)
+	req := pbItiner.RequestCreateItineraries{
+		AutherId:    testAuthorId,
+		Title:       "Uzbekistan",
+		Description: "Silk road cities",
+		StartDate:   "2024-07-16",
+		EndDate:     "2024-07-20",
+		Destinations: []*pbItiner.Destination{{
+			Name:       "Tashkent",
+			StartDate:  "2024-07-16",
+			EndDate:    "2024-07-17",
+			Activities: []string{"swimming", "doing sport"},
+		}},
+	}
+
+	id, err := repo.CreateItinerary(ctx, &req)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	des, err := repo.GetItinerariesDestinations(ctx, id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return id, (*des)[0]
+}
+
+func seedDestinations(t *testing.T, db *sql.DB, names ...string) {
+	t.Helper()
+	ctx := context.Background()
+	repo := NewItinerariesRepo(db, testLogger, 0)
+	for n, name := range names {
+		_, err := repo.CreateDestination(ctx, &pbItiner.RequestCreateDestination{
+			Name:            name,
+			Country:         "Uzbekistan",
+			Currency:        "UZS",
+			PopularityScore: float32(len(names) - n),
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+}
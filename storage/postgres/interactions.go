@@ -1,32 +1,45 @@
 package postgres
 
 import (
+	"context"
 	"database/sql"
 	"fmt"
 	"log/slog"
+	"time"
 	pb "travel/genproto/interactions"
 	"travel/models"
-	"travel/pkg/logger"
 
 	"github.com/google/uuid"
 	_ "github.com/lib/pq"
 )
 
 type InterationsRepo struct {
-	Logger *slog.Logger
-	DB     *sql.DB
+	Logger  *slog.Logger
+	DB      *sql.DB
+	Timeout time.Duration
 }
 
-func NewInterationsRepo(db *sql.DB) *InterationsRepo {
-	logger := logger.NewLogger()
+func NewInterationsRepo(db *sql.DB, logger *slog.Logger,
+	timeout time.Duration) *InterationsRepo {
 	return &InterationsRepo{
-		Logger: logger,
-		DB:     db,
+		Logger:  logger,
+		DB:      db,
+		Timeout: timeout,
 	}
 }
 
-func (i *InterationsRepo) CreateComment(req *pb.RequestCreateComment) (
-	string, error) {
+// CreateComment adds the comment and counts it in the story's comments_count
+// in one transaction.
+func (i *InterationsRepo) CreateComment(ctx context.Context,
+	req *pb.RequestCreateComment) (string, error) {
+	ctx, cancel := withTimeout(ctx, i.Timeout)
+	defer cancel()
+
+	tx, err := i.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return "", fmt.Errorf("error with creating transaction: %s", err)
+	}
+	defer tx.Rollback()
 
 	query := `
 		insert into comments(
@@ -37,12 +50,36 @@ func (i *InterationsRepo) CreateComment(req *pb.RequestCreateComment) (
 	`
 
 	newId := uuid.NewString()
-	_, err := i.DB.Exec(query, newId, req.Content, req.AuthorId, req.StoryId)
-	return newId, err
+	_, err = tx.ExecContext(ctx, query, newId, req.Content, req.AuthorId,
+		req.StoryId)
+	if err != nil {
+		return "", err
+	}
+
+	count := `
+		update
+			stories
+		set
+			comments_count = comments_count + 1
+		where
+			id = $1 and
+			deleted_at is null and
+			status = 'published'
+	`
+	res, err := tx.ExecContext(ctx, count, req.StoryId)
+	if err != nil {
+		return "", err
+	}
+	if num, _ := res.RowsAffected(); num <= 0 {
+		return "", fmt.Errorf("story not found with the id")
+	}
+	return newId, tx.Commit()
 }
 
-func (i *InterationsRepo) GetComments(req *pb.RequestGetComments) (
-	*[]models.Comment, error) {
+func (i *InterationsRepo) GetComments(ctx context.Context,
+	req *pb.RequestGetComments) (*[]models.Comment, error) {
+	ctx, cancel := withTimeout(ctx, i.Timeout)
+	defer cancel()
 
 	query := `
 		select 
@@ -56,11 +93,12 @@ func (i *InterationsRepo) GetComments(req *pb.RequestGetComments) (
 		offset $3
 	`
 
-	rows, err := i.DB.Query(query, req.StoryId, req.Limit,
+	rows, err := i.DB.QueryContext(ctx, query, req.StoryId, req.Limit,
 		req.Limit*req.Page)
 	if err != nil {
 		return nil, err
 	}
+	defer rows.Close()
 	comments := []models.Comment{}
 	for rows.Next() {
 		comment := models.Comment{}
@@ -75,8 +113,10 @@ func (i *InterationsRepo) GetComments(req *pb.RequestGetComments) (
 	return &comments, nil
 }
 
-func (i *InterationsRepo) CountComments(storyId string) (
+func (i *InterationsRepo) CountComments(ctx context.Context, storyId string) (
 	int, error) {
+	ctx, cancel := withTimeout(ctx, i.Timeout)
+	defer cancel()
 
 	query := `
 		select 
@@ -89,11 +129,13 @@ func (i *InterationsRepo) CountComments(storyId string) (
 	`
 
 	res := 0
-	err := i.DB.QueryRow(query, storyId).Scan(&res)
+	err := i.DB.QueryRowContext(ctx, query, storyId).Scan(&res)
 	return res, err
 }
 
-func (i *InterationsRepo) LikeStory(storyId string) error {
+func (i *InterationsRepo) LikeStory(ctx context.Context, storyId string) error {
+	ctx, cancel := withTimeout(ctx, i.Timeout)
+	defer cancel()
 
 	query := `
 		update
@@ -102,10 +144,11 @@ func (i *InterationsRepo) LikeStory(storyId string) error {
 			likes_count = likes_count + 1
 		where
 			id = $1 and 
-			deleted_at is null
+			deleted_at is null and
+			status = 'published'
 	`
 
-	res, err := i.DB.Exec(query, storyId)
+	res, err := i.DB.ExecContext(ctx, query, storyId)
 	if err != nil {
 		return err
 	}
@@ -115,7 +158,10 @@ func (i *InterationsRepo) LikeStory(storyId string) error {
 	return nil
 }
 
-func (i *InterationsRepo) CreateLike(req *pb.RequestLikeStory) error {
+func (i *InterationsRepo) CreateLike(ctx context.Context,
+	req *pb.RequestLikeStory) error {
+	ctx, cancel := withTimeout(ctx, i.Timeout)
+	defer cancel()
 
 	query := `
 		insert into likes (
@@ -125,6 +171,6 @@ func (i *InterationsRepo) CreateLike(req *pb.RequestLikeStory) error {
 		)
 	`
 
-	_, err := i.DB.Exec(query, req.UserId, req.StoryId)
+	_, err := i.DB.ExecContext(ctx, query, req.UserId, req.StoryId)
 	return err
 }
@@ -1,66 +1,107 @@
 package postgres
 
 import (
-	"log"
+	"context"
 	"testing"
 	pb "travel/genproto/interactions"
+	pbStory "travel/genproto/stories"
 )
 
-func NewIntRepo() *InterationsRepo {
-	db, err := ConnectDB()
-	if err != nil {
-		log.Panic(err)
-	}
-	return NewInterationsRepo(db)
+func NewIntRepo(t *testing.T) *InterationsRepo {
+	return NewInterationsRepo(NewTestDB(t), testLogger, 0)
 }
 
 func TestCreateComment(t *testing.T) {
+	ctx := context.Background()
+	repo := NewIntRepo(t)
 	req := pb.RequestCreateComment{
-		StoryId:  "24c22836-26fa-486d-b660-262e123a1a5c",
-		AuthorId: "5960639a-383e-4437-9f1a-9657f9f99964",
+		StoryId:  seedStory(t, repo.DB),
+		AuthorId: testUserId,
 		Content:  "I have never seen story like this",
 	}
-	_, err := NewIntRepo().CreateComment(&req)
+	_, err := repo.CreateComment(ctx, &req)
 	if err != nil {
-		t.Error(err)
+		t.Fatal(err)
+	}
+	count := 0
+	repo.DB.QueryRow(`select comments_count from stories where id = $1`,
+		req.StoryId).Scan(&count)
+	if count != 1 {
+		t.Errorf("expected the comment to be counted, got %d", count)
+	}
+
+	req.StoryId = "00000000-0000-0000-0000-000000000001"
+	if _, err := repo.CreateComment(ctx, &req); err == nil {
+		t.Error("expected commenting on a missing story to fail")
+	}
+
+	draft, err := NewStoriesRepo(repo.DB, testLogger, 0).CreateDraft(ctx,
+		&pbStory.RequestCreateStory{AuthorId: testAuthorId, Title: "Draft"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	req.StoryId = draft
+	if _, err := repo.CreateComment(ctx, &req); err == nil {
+		t.Error("expected commenting on a draft to fail")
 	}
 }
 
 func TestGetComments(t *testing.T) {
+	ctx := context.Background()
+	repo := NewIntRepo(t)
+	storyId := seedStory(t, repo.DB)
+	repo.CreateComment(ctx, &pb.RequestCreateComment{
+		StoryId:  storyId,
+		AuthorId: testUserId,
+		Content:  "I have never seen story like this",
+	})
+
 	req := pb.RequestGetComments{
-		StoryId: "24c22836-26fa-486d-b660-262e123a1a5c",
+		StoryId: storyId,
 		Page:    0,
 		Limit:   10,
 	}
 
-	_, err := NewIntRepo().GetComments(&req)
+	comments, err := repo.GetComments(ctx, &req)
 	if err != nil {
 		t.Error(err)
 	}
+	if len(*comments) != 1 {
+		t.Errorf("expected 1 comment, got %d", len(*comments))
+	}
 }
 
 func TestCountComments(t *testing.T) {
-	_, err := NewIntRepo().CountComments("24c22836-26fa-486d-b660-262e123a1a5c")
+	ctx := context.Background()
+	repo := NewIntRepo(t)
+	_, err := repo.CountComments(ctx, seedStory(t, repo.DB))
 	if err != nil {
 		t.Error(err)
 	}
 }
 
 func TestLikeStory(t *testing.T) {
-	err := NewIntRepo().LikeStory("24c22836-26fa-486d-b660-262e123a1a5c")
+	ctx := context.Background()
+	repo := NewIntRepo(t)
+	err := repo.LikeStory(ctx, seedStory(t, repo.DB))
 	if err != nil {
 		t.Error(err)
 	}
 }
 
 func TestCreateLike(t *testing.T) {
+	ctx := context.Background()
+	repo := NewIntRepo(t)
 	req := pb.RequestLikeStory{
-		UserId:  "9446b610-2ee7-46b4-98a1-ff905b016d2b",
-		StoryId: "a9a0e266-ef7b-459e-aa28-ee091fc3eafb",
+		UserId:  testUserId,
+		StoryId: seedStory(t, repo.DB),
 	}
 
-	err := NewIntRepo().CreateLike(&req)
+	err := repo.CreateLike(ctx, &req)
 	if err != nil {
 		t.Error(err)
 	}
+	if err := repo.CreateLike(ctx, &req); err == nil {
+		t.Error("expected second like of the same user to fail")
+	}
 }
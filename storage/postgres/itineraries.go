@@ -1,65 +1,92 @@
 package postgres
 
 import (
+	"context"
 	"database/sql"
 	"fmt"
 	"log/slog"
 	"time"
 	pb "travel/genproto/itineraries"
 	"travel/models"
-	"travel/pkg/logger"
 
 	"github.com/google/uuid"
 )
 
 type ItinerariesRepo struct {
-	Logger *slog.Logger
-	DB     *sql.DB
+	Logger  *slog.Logger
+	DB      *sql.DB
+	Timeout time.Duration
 }
 
-func NewItinerariesRepo(db *sql.DB) *ItinerariesRepo {
-	logger := logger.NewLogger()
+func NewItinerariesRepo(db *sql.DB, logger *slog.Logger,
+	timeout time.Duration) *ItinerariesRepo {
 	return &ItinerariesRepo{
-		Logger: logger,
-		DB:     db,
+		Logger:  logger,
+		DB:      db,
+		Timeout: timeout,
 	}
 }
 
-func (i *ItinerariesRepo) CreateItineraries(req *pb.RequestCreateItineraries) (
-	string, error) {
+// CreateItinerary creates the itinerary with its destinations and activities
+// in one transaction, so a failed create leaves nothing behind.
+func (i *ItinerariesRepo) CreateItinerary(ctx context.Context,
+	req *pb.RequestCreateItineraries) (string, error) {
+	ctx, cancel := withTimeout(ctx, i.Timeout)
+	defer cancel()
+
+	tx, err := i.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return "", fmt.Errorf("error with creating transaction: %s", err)
+	}
+
+	newId, err := InsertItineraries(ctx, tx, req)
+	if err != nil {
+		tx.Rollback()
+		return "", err
+	}
+
+	err = InsertItinerariesDestinations(ctx, tx, newId, req.Destinations)
+	if err != nil {
+		tx.Rollback()
+		return "", err
+	}
+	return newId, tx.Commit()
+}
+
+func InsertItineraries(ctx context.Context, tx *sql.Tx,
+	req *pb.RequestCreateItineraries) (string, error) {
 
 	query := `
 		insert into itineraries(
 			id, title, description, start_date, end_date, author_id
 		) values (
-			$1, $2, $3, $4, $5, $6 
-		)
-	`
+			$1, $2, $3, $4, $5, $6
+		)`
 
 	newId := uuid.NewString()
-	_, err := i.DB.Exec(query, newId, req.Title, req.Description,
+	_, err := tx.ExecContext(ctx, query, newId, req.Title, req.Description,
 		req.StartDate, req.EndDate, req.AutherId)
 	return newId, err
 }
 
-func (i *ItinerariesRepo) CreateItinerariesDestinations(itineraryId string,
-	destinations []*pb.Destination) error {
+func InsertItinerariesDestinations(ctx context.Context, tx *sql.Tx,
+	itineraryId string, destinations []*pb.Destination) error {
 
 	query := `
 		insert into itinerary_destinations(
-			id, itinerary_id, name, start_date, end_date
+			id, itinerary_id, name, start_date, end_date, latitude, longitude
 		) values (
-			$1, $2, $3, $4, $5
+			$1, $2, $3, $4, $5, $6, $7
 		)`
 
 	for _, des := range destinations {
 		newId := uuid.NewString()
-		_, err := i.DB.Exec(query, newId, itineraryId, des.Name, des.StartDate,
-			des.EndDate)
+		_, err := tx.ExecContext(ctx, query, newId, itineraryId, des.Name,
+			des.StartDate, des.EndDate, des.Latitude, des.Longitude)
 		if err != nil {
 			return err
 		}
-		err = i.CreateActivities(newId, &des.Activities)
+		err = InsertActivities(ctx, tx, newId, &des.Activities)
 		if err != nil {
 			return err
 		}
@@ -67,7 +94,8 @@ func (i *ItinerariesRepo) CreateItinerariesDestinations(itineraryId string,
 	return nil
 }
 
-func (i *ItinerariesRepo) CreateActivities(desId string, req *[]string) error {
+func InsertActivities(ctx context.Context, tx *sql.Tx, desId string,
+	activities *[]string) error {
 
 	query := `
 		insert into itinerary_activities(
@@ -76,9 +104,8 @@ func (i *ItinerariesRepo) CreateActivities(desId string, req *[]string) error {
 			$1, $2, $3
 		)`
 
-	for _, activity := range *req {
-		newId := uuid.NewString()
-		_, err := i.DB.Exec(query, newId, desId, activity)
+	for _, activity := range *activities {
+		_, err := tx.ExecContext(ctx, query, uuid.NewString(), desId, activity)
 		if err != nil {
 			return err
 		}
@@ -86,7 +113,8 @@ func (i *ItinerariesRepo) CreateActivities(desId string, req *[]string) error {
 	return nil
 }
 
-func EditItineraries(tx *sql.Tx, req *pb.RequestEditItineraries) error {
+func EditItineraries(ctx context.Context, tx *sql.Tx,
+	req *pb.RequestEditItineraries) error {
 
 	query := `
 		update
@@ -101,7 +129,7 @@ func EditItineraries(tx *sql.Tx, req *pb.RequestEditItineraries) error {
 			id = $6 and
 			deleted_at is null`
 
-	res, err := tx.Exec(query, req.Title, req.Description, req.StartDate,
+	res, err := tx.ExecContext(ctx, query, req.Title, req.Description, req.StartDate,
 		req.EndDate, time.Now(), req.Id)
 	if err != nil {
 		return err
@@ -112,7 +140,7 @@ func EditItineraries(tx *sql.Tx, req *pb.RequestEditItineraries) error {
 	return nil
 }
 
-func EditItinerariesDestinations(tx *sql.Tx,
+func EditItinerariesDestinations(ctx context.Context, tx *sql.Tx,
 	destinations []*pb.DestinationEdit) error {
 
 	query := `
@@ -121,21 +149,23 @@ func EditItinerariesDestinations(tx *sql.Tx,
 		set
 			name = $1,
 			start_date = $2, 
-			end_date = $3
+			end_date = $3,
+			latitude = $4,
+			longitude = $5
 		where
-			id = $4 and
+			id = $6 and
 			deleted_at is null`
 
 	for _, des := range destinations {
-		res, err := tx.Exec(query, des.Name, des.StartDate,
-			des.EndDate, des.Id)
+		res, err := tx.ExecContext(ctx, query, des.Name, des.StartDate,
+			des.EndDate, des.Latitude, des.Longitude, des.Id)
 		if err != nil {
 			return err
 		}
 		if num, _ := res.RowsAffected(); num <= 0 {
 			return fmt.Errorf("destination not found with the id: %s", des.Id)
 		}
-		err = EditActivities(tx, &des.Activities)
+		err = EditActivities(ctx, tx, &des.Activities)
 		if err != nil {
 			return err
 		}
@@ -143,7 +173,8 @@ func EditItinerariesDestinations(tx *sql.Tx,
 	return nil
 }
 
-func EditActivities(tx *sql.Tx, activities *[]*pb.Activity) error {
+func EditActivities(ctx context.Context, tx *sql.Tx,
+	activities *[]*pb.Activity) error {
 
 	query := `
 		update
@@ -155,7 +186,7 @@ func EditActivities(tx *sql.Tx, activities *[]*pb.Activity) error {
 			deleted_at is null`
 
 	for _, act := range *activities {
-		res, err := tx.Exec(query, act.Activity, act.Id)
+		res, err := tx.ExecContext(ctx, query, act.Activity, act.Id)
 		if err != nil {
 			return err
 		}
@@ -166,8 +197,88 @@ func EditActivities(tx *sql.Tx, activities *[]*pb.Activity) error {
 	return nil
 }
 
-func (i *ItinerariesRepo) GetAllItineraries(req *pb.RequestGetAllItineraries) (
-	*[]models.Itinerary, error) {
+// UpdateItinerary edits the itinerary with its destinations and activities in
+// one transaction.
+func (i *ItinerariesRepo) UpdateItinerary(ctx context.Context,
+	req *pb.RequestEditItineraries) error {
+	ctx, cancel := withTimeout(ctx, i.Timeout)
+	defer cancel()
+
+	tx, err := i.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("error with creating transaction: %s", err)
+	}
+
+	err = EditItineraries(ctx, tx, req)
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	err = EditItinerariesDestinations(ctx, tx, req.Destinations)
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+	return tx.Commit()
+}
+
+func (i *ItinerariesRepo) DeleteItinerary(ctx context.Context, id string) error {
+	ctx, cancel := withTimeout(ctx, i.Timeout)
+	defer cancel()
+
+	query := `
+		update
+			itineraries
+		set
+			deleted_at = $1
+		where
+			id = $2 and
+			deleted_at is null
+	`
+
+	res, err := i.DB.ExecContext(ctx, query, time.Now(), id)
+	if err != nil {
+		return err
+	}
+	if num, _ := res.RowsAffected(); num <= 0 {
+		return fmt.Errorf("itinerary not found with the id: %s", id)
+	}
+	return nil
+}
+
+// RestoreItinerary undeletes the itinerary of authorId if it was deleted since
+// the given time.
+func (i *ItinerariesRepo) RestoreItinerary(ctx context.Context, id,
+	authorId string, since time.Time) error {
+	ctx, cancel := withTimeout(ctx, i.Timeout)
+	defer cancel()
+
+	query := `
+		update
+			itineraries
+		set
+			deleted_at = null
+		where
+			id = $1 and
+			author_id = $2 and
+			deleted_at >= $3
+	`
+
+	res, err := i.DB.ExecContext(ctx, query, id, authorId, since)
+	if err != nil {
+		return err
+	}
+	if num, _ := res.RowsAffected(); num <= 0 {
+		return fmt.Errorf("deleted itinerary not found with the id: %s", id)
+	}
+	return nil
+}
+
+func (i *ItinerariesRepo) GetAllItineraries(ctx context.Context,
+	req *pb.RequestGetAllItineraries) (*[]models.Itinerary, error) {
+	ctx, cancel := withTimeout(ctx, i.Timeout)
+	defer cancel()
 
 	query := `
 		select
@@ -181,10 +292,11 @@ func (i *ItinerariesRepo) GetAllItineraries(req *pb.RequestGetAllItineraries) (
 		offset $2
 	`
 
-	rows, err := i.DB.Query(query, req.Limit, req.Limit*req.Page)
+	rows, err := i.DB.QueryContext(ctx, query, req.Limit, req.Limit*req.Page)
 	if err != nil {
 		return nil, err
 	}
+	defer rows.Close()
 
 	itineraries := []models.Itinerary{}
 	for rows.Next() {
@@ -200,7 +312,10 @@ func (i *ItinerariesRepo) GetAllItineraries(req *pb.RequestGetAllItineraries) (
 	return &itineraries, nil
 }
 
-func (i *ItinerariesRepo) FindNumberOfItineraries() (int, error) {
+func (i *ItinerariesRepo) FindNumberOfItineraries(ctx context.Context) (
+	int, error) {
+	ctx, cancel := withTimeout(ctx, i.Timeout)
+	defer cancel()
 
 	query := `
 		select
@@ -212,12 +327,14 @@ func (i *ItinerariesRepo) FindNumberOfItineraries() (int, error) {
 	`
 
 	count := 0
-	err := i.DB.QueryRow(query).Scan(&count)
+	err := i.DB.QueryRowContext(ctx, query).Scan(&count)
 	return count, err
 }
 
-func (i *ItinerariesRepo) GetItinerariesFullInfo(id string) (
-	*models.ItineraryFullInfo, error) {
+func (i *ItinerariesRepo) GetItinerariesFullInfo(ctx context.Context,
+	id string) (*models.ItineraryFullInfo, error) {
+	ctx, cancel := withTimeout(ctx, i.Timeout)
+	defer cancel()
 
 	query := `
 		select
@@ -230,19 +347,21 @@ func (i *ItinerariesRepo) GetItinerariesFullInfo(id string) (
 			deleted_at is null`
 
 	resp := models.ItineraryFullInfo{}
-	err := i.DB.QueryRow(query, id).Scan(&resp.Id, &resp.Title,
+	err := i.DB.QueryRowContext(ctx, query, id).Scan(&resp.Id, &resp.Title,
 		&resp.Description, &resp.StartDate, &resp.EndDate,
 		&resp.AutherId, &resp.LikesCount, &resp.CommentsCount,
 		&resp.CreatedAt, &resp.UpdatedAt)
 	return &resp, err
 }
 
-func (i *ItinerariesRepo) GetItinerariesDestinations(id string) (*[]*pb.DestinationEdit,
-	error) {
+func (i *ItinerariesRepo) GetItinerariesDestinations(ctx context.Context,
+	id string) (*[]*pb.DestinationEdit, error) {
+	ctx, cancel := withTimeout(ctx, i.Timeout)
+	defer cancel()
 
 	query := `
 		select
-			id, name, start_date, end_date
+			id, name, start_date, end_date, latitude, longitude
 		from
 			itinerary_destinations
 		where
@@ -250,19 +369,25 @@ func (i *ItinerariesRepo) GetItinerariesDestinations(id string) (*[]*pb.Destinat
 			deleted_at is null`
 
 	resp := []*pb.DestinationEdit{}
-	rows, err := i.DB.Query(query, id)
+	rows, err := i.DB.QueryContext(ctx, query, id)
 	if err != nil {
 		return nil, err
 	}
+	defer rows.Close()
 
 	for rows.Next() {
 		des := pb.DestinationEdit{}
-		err = rows.Scan(&des.Id, &des.Name, &des.StartDate, &des.EndDate)
+		var latitude, longitude sql.NullFloat64
+		err = rows.Scan(&des.Id, &des.Name, &des.StartDate, &des.EndDate,
+			&latitude, &longitude)
 		if err != nil {
 			return nil, err
 		}
+		if latitude.Valid && longitude.Valid {
+			des.Latitude, des.Longitude = &latitude.Float64, &longitude.Float64
+		}
 
-		activities, err := i.GetDestinationActivities(des.Id)
+		activities, err := i.GetDestinationActivities(ctx, des.Id)
 		if err != nil {
 			return nil, err
 		}
@@ -273,8 +398,10 @@ func (i *ItinerariesRepo) GetItinerariesDestinations(id string) (*[]*pb.Destinat
 	return &resp, nil
 }
 
-func (i *ItinerariesRepo) GetDestinationActivities(desId string) (
-	*[]*pb.Activity, error) {
+func (i *ItinerariesRepo) GetDestinationActivities(ctx context.Context,
+	desId string) (*[]*pb.Activity, error) {
+	ctx, cancel := withTimeout(ctx, i.Timeout)
+	defer cancel()
 
 	query := `
 		select
@@ -286,10 +413,11 @@ func (i *ItinerariesRepo) GetDestinationActivities(desId string) (
 			deleted_at is null`
 
 	activities := []*pb.Activity{}
-	rows, err := i.DB.Query(query, desId)
+	rows, err := i.DB.QueryContext(ctx, query, desId)
 	if err != nil {
 		return nil, err
 	}
+	defer rows.Close()
 
 	for rows.Next() {
 		activity := pb.Activity{}
@@ -302,8 +430,18 @@ func (i *ItinerariesRepo) GetDestinationActivities(desId string) (
 	return &activities, nil
 }
 
-func (i *ItinerariesRepo) WriteCommentToItinerary(req *pb.RequestWriteCommentToItinerary) (
-	string, error) {
+// WriteCommentToItinerary adds the comment and counts it in the itinerary's
+// comments_count in one transaction.
+func (i *ItinerariesRepo) WriteCommentToItinerary(ctx context.Context,
+	req *pb.RequestWriteCommentToItinerary) (string, error) {
+	ctx, cancel := withTimeout(ctx, i.Timeout)
+	defer cancel()
+
+	tx, err := i.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return "", fmt.Errorf("error with creating transaction: %s", err)
+	}
+	defer tx.Rollback()
 
 	query := `
 		insert into commentsForItinerary(
@@ -313,12 +451,36 @@ func (i *ItinerariesRepo) WriteCommentToItinerary(req *pb.RequestWriteCommentToI
 		)`
 
 	newId := uuid.NewString()
-	_, err := i.DB.Exec(query, newId, req.Content, req.AuthorId, req.ItineraryId)
-	return newId, err
+	_, err = tx.ExecContext(ctx, query, newId, req.Content, req.AuthorId,
+		req.ItineraryId)
+	if err != nil {
+		return "", err
+	}
+
+	count := `
+		update
+			itineraries
+		set
+			comments_count = comments_count + 1
+		where
+			id = $1 and
+			deleted_at is null`
+
+	res, err := tx.ExecContext(ctx, count, req.ItineraryId)
+	if err != nil {
+		return "", err
+	}
+	if num, _ := res.RowsAffected(); num <= 0 {
+		return "", fmt.Errorf("itinerary not found with the id: %s", req.ItineraryId)
+	}
+	return newId, tx.Commit()
 }
 
-func (i *ItinerariesRepo) CreateDestination(req *pb.RequestCreateDestination) (
-	string, error) {
+func (i *ItinerariesRepo) CreateDestination(ctx context.Context,
+	req *pb.RequestCreateDestination) (string, error) {
+	ctx, cancel := withTimeout(ctx, i.Timeout)
+	defer cancel()
+
 	query := `
 		insert into destinations(
 			id, name, country, description, best_time_to_visit, 
@@ -328,14 +490,16 @@ func (i *ItinerariesRepo) CreateDestination(req *pb.RequestCreateDestination) (
 		)`
 
 	newId := uuid.NewString()
-	_, err := i.DB.Exec(query, newId, req.Name, req.Country, req.Description,
-		req.BestTimeToVisit, req.AverageCostPerDay, req.Currency,
+	_, err := i.DB.ExecContext(ctx, query, newId, req.Name, req.Country,
+		req.Description, req.BestTimeToVisit, req.AverageCostPerDay, req.Currency,
 		req.Language, req.PopularityScore)
 	return newId, err
 }
 
-func (i *ItinerariesRepo) GetTopDestinations(req *pb.RequestGetDestinations) (
-	*pb.ResponseGetDestinations, error) {
+func (i *ItinerariesRepo) GetTopDestinations(ctx context.Context,
+	req *pb.RequestGetDestinations) (*pb.ResponseGetDestinations, error) {
+	ctx, cancel := withTimeout(ctx, i.Timeout)
+	defer cancel()
 
 	query := `
 		select
@@ -348,10 +512,11 @@ func (i *ItinerariesRepo) GetTopDestinations(req *pb.RequestGetDestinations) (
 		offset $2
 	`
 
-	rows, err := i.DB.Query(query, req.Limit, req.Limit*req.Page)
+	rows, err := i.DB.QueryContext(ctx, query, req.Limit, req.Limit*req.Page)
 	if err != nil {
 		return nil, err
 	}
+	defer rows.Close()
 	res := pb.ResponseGetDestinations{}
 	for rows.Next() {
 		des := pb.DestionationInfo{}
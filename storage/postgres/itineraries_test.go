@@ -1,64 +1,22 @@
 package postgres
 
 import (
-	"log"
+	"context"
 	"testing"
+	"time"
 	pb "travel/genproto/itineraries"
 )
 
-func NewItinarRepo() *ItinerariesRepo {
-	db, err := ConnectDB()
-	if err != nil {
-		log.Panic(err)
-	}
-	return NewItinerariesRepo(db)
-}
-
-func TestCreateItineraries(t *testing.T) {
-	req := pb.RequestCreateItineraries{
-		AutherId:    "030c9cdc-c410-4e94-a5f6-4152fd4eafcb",
-		Title:       "dfghjk",
-		Description: "dsfhgfs dgh fghf hgh f",
-		StartDate:   "2024-07-16",
-		EndDate:     "2024-07-16",
-		Destinations: []*pb.Destination{{
-			Name:       "Tashkent",
-			StartDate:  "2024-07-16",
-			EndDate:    "2024-07-16",
-			Activities: []string{"swimming"},
-		}}}
-	_, err := NewItinarRepo().CreateItineraries(&req)
-	if err != nil {
-		t.Error(err)
-	}
-}
-
-func TestCreateItinerariesDestinations(t *testing.T) {
-	des := []*pb.Destination{{
-		Name:       "Tashkent",
-		StartDate:  "2024-07-16",
-		EndDate:    "2024-07-16",
-		Activities: []string{"swimming"}}}
-
-	err := NewItinarRepo().CreateItinerariesDestinations("b041bc66-3857-4720-a811-3d8a080a6343",
-		des)
-	if err != nil {
-		t.Error(err)
-	}
-}
-
-func TestCreateActivities(t *testing.T) {
-	desId := "1e9c7187-229e-42cc-957a-dd343d0c51f3"
-	ac := []string{"swimming", "doing sport"}
-	err := NewItinarRepo().CreateActivities(desId, &ac)
-	if err != nil {
-		t.Error(err)
-	}
+func NewItinarRepo(t *testing.T) *ItinerariesRepo {
+	return NewItinerariesRepo(NewTestDB(t), testLogger, 0)
 }
 
 func TestEditItineraries(t *testing.T) {
+	ctx := context.Background()
+	repo := NewItinarRepo(t)
+	id, _ := seedItinerary(t, repo.DB)
 	req := pb.RequestEditItineraries{
-		Id:           "00d47248-2563-4494-9561-d8c10749b8b6",
+		Id:           id,
 		Title:        "Saidakbar",
 		Description:  "Saidakbar Pradaboyev",
 		StartDate:    "2024-07-12",
@@ -66,13 +24,13 @@ func TestEditItineraries(t *testing.T) {
 		Destinations: []*pb.DestinationEdit{},
 	}
 
-	tx, err := NewItinarRepo().DB.Begin()
+	tx, err := repo.DB.Begin()
 	if err != nil {
-		t.Error(err)
+		t.Fatal(err)
 	}
 	defer tx.Commit()
 
-	err = EditItineraries(tx, &req)
+	err = EditItineraries(ctx, tx, &req)
 	if err != nil {
 		tx.Rollback()
 		t.Error(err)
@@ -80,80 +38,258 @@ func TestEditItineraries(t *testing.T) {
 }
 
 func TestEditItinerariesDestinations(t *testing.T) {
+	ctx := context.Background()
+	repo := NewItinarRepo(t)
+	_, des := seedItinerary(t, repo.DB)
 	req := []*pb.DestinationEdit{{
-		Id:        "ef63014f-094f-4331-8a7d-9003c0d5d26e",
+		Id:        des.Id,
 		Name:      "Jizzax",
 		StartDate: "2024-07-05",
 		EndDate:   "2024-07-30",
 		Activities: []*pb.Activity{{
-			Id:       "c9694101-61aa-414c-9834-2277cc654a7d",
+			Id:       des.Activities[0].Id,
 			Activity: "Learing engling",
 		}, {
-			Id:       "3b6f1610-4370-48af-898e-f472506f6a5f",
+			Id:       des.Activities[1].Id,
 			Activity: "Lering arab language",
 		}},
 	}}
 
-	tx, err := NewItinarRepo().DB.Begin()
+	tx, err := repo.DB.Begin()
 	if err != nil {
-		t.Error(err)
+		t.Fatal(err)
 	}
 	defer tx.Commit()
-	err = EditItinerariesDestinations(tx, req)
+	err = EditItinerariesDestinations(ctx, tx, req)
 	if err != nil {
 		tx.Rollback()
 		t.Error(err)
 	}
 }
 
+func TestUpdateItinerary(t *testing.T) {
+	ctx := context.Background()
+	repo := NewItinarRepo(t)
+	id, des := seedItinerary(t, repo.DB)
+	req := pb.RequestEditItineraries{
+		Id:        id,
+		Title:     "Samarkand",
+		StartDate: "2024-07-12",
+		EndDate:   "2024-07-16",
+		Destinations: []*pb.DestinationEdit{{
+			Id:        des.Id,
+			Name:      "Registan",
+			StartDate: "2024-07-12",
+			EndDate:   "2024-07-13",
+			Activities: []*pb.Activity{{
+				Id:       "00000000-0000-0000-0000-000000000000",
+				Activity: "missing",
+			}},
+		}},
+	}
+
+	if err := repo.UpdateItinerary(ctx, &req); err == nil {
+		t.Fatal("expected missing activity to fail the update")
+	}
+	full, err := repo.GetItinerariesFullInfo(ctx, id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if full.Title != "Uzbekistan" {
+		t.Errorf("expected failed update to be rolled back, got %s", full.Title)
+	}
+}
+
 func TestGetAllItineraries(t *testing.T) {
+	ctx := context.Background()
+	repo := NewItinarRepo(t)
+	seedItinerary(t, repo.DB)
 	req := pb.RequestGetAllItineraries{
 		Page:  0,
 		Limit: 10,
 	}
 
-	_, err := NewItinarRepo().GetAllItineraries(&req)
+	itineraries, err := repo.GetAllItineraries(ctx, &req)
 	if err != nil {
 		t.Error(err)
 	}
+	if len(*itineraries) != 1 {
+		t.Errorf("expected 1 itinerary, got %d", len(*itineraries))
+	}
 }
 
 func TestFindNumberOfItineraries(t *testing.T) {
-	_, err := NewItinarRepo().FindNumberOfItineraries()
+	ctx := context.Background()
+	_, err := NewItinarRepo(t).FindNumberOfItineraries(ctx)
 	if err != nil {
 		t.Error(err)
 	}
 }
 
 func TestGetItinerariesFullInfo(t *testing.T) {
-	_, err := NewItinarRepo().GetItinerariesFullInfo(
-		"35d06507-51e2-41cc-87eb-a0107f4f8217")
+	ctx := context.Background()
+	repo := NewItinarRepo(t)
+	id, _ := seedItinerary(t, repo.DB)
+	_, err := repo.GetItinerariesFullInfo(ctx, id)
 	if err != nil {
 		t.Error(err)
 	}
 }
 
 func TestGetItinerariesDestinations(t *testing.T) {
-	_, err := NewItinarRepo().GetItinerariesDestinations(
-		"35d06507-51e2-41cc-87eb-a0107f4f8217")
+	ctx := context.Background()
+	repo := NewItinarRepo(t)
+	id, _ := seedItinerary(t, repo.DB)
+	des, err := repo.GetItinerariesDestinations(ctx, id)
 	if err != nil {
 		t.Error(err)
 	}
+	if len(*des) != 1 || len((*des)[0].Activities) != 2 {
+		t.Errorf("unexpected destinations: %v", *des)
+	}
+}
+
+func TestCreateItinerary(t *testing.T) {
+	ctx := context.Background()
+	repo := NewItinarRepo(t)
+	req := pb.RequestCreateItineraries{
+		AutherId:  testAuthorId,
+		Title:     "Uzbekistan",
+		StartDate: "2024-07-16",
+		EndDate:   "2024-07-20",
+		Destinations: []*pb.Destination{{
+			Name:       "Tashkent",
+			StartDate:  "2024-07-16",
+			EndDate:    "2024-07-17",
+			Activities: []string{"swimming", "doing sport"},
+		}},
+	}
+
+	id, err := repo.CreateItinerary(ctx, &req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	des, err := repo.GetItinerariesDestinations(ctx, id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(*des) != 1 || len((*des)[0].Activities) != 2 {
+		t.Errorf("unexpected destinations: %v", *des)
+	}
+
+	// a destination ending before it starts fails the whole create
+	count, _ := repo.FindNumberOfItineraries(ctx)
+	req.Destinations = append(req.Destinations, &pb.Destination{
+		Name:      "Samarkand",
+		StartDate: "2024-07-20",
+		EndDate:   "2024-07-18",
+	})
+	if _, err := repo.CreateItinerary(ctx, &req); err == nil {
+		t.Fatal("expected the create to fail")
+	}
+	if after, _ := repo.FindNumberOfItineraries(ctx); after != count {
+		t.Errorf("expected nothing to be created, got %d itineraries", after)
+	}
+}
+
+func TestDestinationCoordinates(t *testing.T) {
+	ctx := context.Background()
+	repo := NewItinarRepo(t)
+	lat, lon := 39.6542, 66.9597
+	req := pb.RequestCreateItineraries{
+		AutherId:  testAuthorId,
+		Title:     "Uzbekistan",
+		StartDate: "2024-07-16",
+		EndDate:   "2024-07-20",
+		Destinations: []*pb.Destination{{
+			Name:      "Tashkent",
+			StartDate: "2024-07-16",
+			EndDate:   "2024-07-17",
+		}, {
+			Name:      "Samarkand",
+			StartDate: "2024-07-18",
+			EndDate:   "2024-07-20",
+			Latitude:  &lat,
+			Longitude: &lon,
+		}},
+	}
+	id, err := repo.CreateItinerary(ctx, &req)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	des, err := repo.GetItinerariesDestinations(ctx, id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	found := 0
+	for _, d := range *des {
+		if d.Latitude != nil && *d.Latitude == lat && *d.Longitude == lon {
+			found++
+		} else if d.Latitude != nil || d.Longitude != nil {
+			t.Errorf("expected no coordinates for %s", d.Name)
+		}
+	}
+	if found != 1 {
+		t.Errorf("expected the coordinates of Samarkand, got %v", *des)
+	}
+
+	req.Destinations[1].Longitude = nil
+	if _, err := repo.CreateItinerary(ctx, &req); err == nil {
+		t.Error("expected a latitude without longitude to be rejected")
+	}
+}
+
+func TestDeleteAndRestoreItinerary(t *testing.T) {
+	ctx := context.Background()
+	repo := NewItinarRepo(t)
+	id, _ := seedItinerary(t, repo.DB)
+	since := time.Now().Add(-time.Hour)
+
+	if err := repo.DeleteItinerary(ctx, id); err != nil {
+		t.Fatal(err)
+	}
+	if err := repo.DeleteItinerary(ctx, id); err == nil {
+		t.Error("expected deleted itinerary not to be found")
+	}
+	if _, err := repo.GetItinerariesFullInfo(ctx, id); err == nil {
+		t.Error("expected deleted itinerary not to be served")
+	}
+
+	if err := repo.RestoreItinerary(ctx, id, testUserId, since); err == nil {
+		t.Error("expected another author not to restore the itinerary")
+	}
+	if err := repo.RestoreItinerary(ctx, id, testAuthorId, since); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := repo.GetItinerariesFullInfo(ctx, id); err != nil {
+		t.Errorf("expected the itinerary to be restored, got %v", err)
+	}
 }
 
 func TestWriteCommentToItinerary(t *testing.T) {
+	ctx := context.Background()
+	repo := NewItinarRepo(t)
+	id, _ := seedItinerary(t, repo.DB)
 	req := pb.RequestWriteCommentToItinerary{
 		Content:     "It is so good journay",
-		AuthorId:    "030c9cdc-c410-4e94-a5f6-4152fd4eafcb",
-		ItineraryId: "00d47248-2563-4494-9561-d8c10749b8b6",
+		AuthorId:    testUserId,
+		ItineraryId: id,
 	}
-	_, err := NewItinarRepo().WriteCommentToItinerary(&req)
+	_, err := repo.WriteCommentToItinerary(ctx, &req)
 	if err != nil {
-		t.Error(err)
+		t.Fatal(err)
+	}
+	count := 0
+	repo.DB.QueryRow(`select comments_count from itineraries where id = $1`,
+		id).Scan(&count)
+	if count != 1 {
+		t.Errorf("expected the comment to be counted, got %d", count)
 	}
 }
 
 func TestCreateDestination(t *testing.T) {
+	ctx := context.Background()
 	res := pb.RequestCreateDestination{
 		Name:              "Makka",
 		Country:           "Misr",
@@ -165,55 +301,25 @@ func TestCreateDestination(t *testing.T) {
 		PopularityScore:   100,
 	}
 
-	_, err := NewItinarRepo().CreateDestination(&res)
+	_, err := NewItinarRepo(t).CreateDestination(ctx, &res)
 	if err != nil {
 		t.Error(err)
 	}
 }
 
 func TestGetTopDestinations(t *testing.T) {
+	ctx := context.Background()
+	repo := NewItinarRepo(t)
+	seedDestinations(t, repo.DB, "Makka", "Tashkent", "Bali")
 	req := pb.RequestGetDestinations{
-		Limit: 5,
+		Limit: 2,
 		Page:  0,
 	}
-	_, err := NewItinarRepo().GetTopDestinations(&req)
+	res, err := repo.GetTopDestinations(ctx, &req)
 	if err != nil {
 		t.Error(err)
 	}
+	if len(res.Destinations) != 2 || res.Destinations[0].Name != "Makka" {
+		t.Errorf("unexpected top destinations: %v", res.Destinations)
+	}
 }
-
-// func Test(t *testing.T) {
-
-// }
-
-// func Test(t *testing.T) {
-
-// }
-
-// func Test(t *testing.T) {
-
-// }
-
-// func Test(t *testing.T) {
-
-// }
-
-// func Test(t *testing.T) {
-
-// }
-
-// func Test(t *testing.T) {
-
-// }
-
-// func Test(t *testing.T) {
-
-// }
-
-// func Test(t *testing.T) {
-
-// }
-
-// func Test(t *testing.T) {
-
-// }
@@ -0,0 +1,134 @@
+package postgres
+
+import (
+	"database/sql"
+	"fmt"
+	"io"
+	"log"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"travel/db/migrations"
+
+	embeddedpostgres "github.com/fergusstrange/embedded-postgres"
+	"github.com/google/uuid"
+)
+
+// The tests run against a throwaway postgres: an embedded server started by
+// TestMain, or the server in TEST_DATABASE_URL (a key=value DSN) when it is
+// set. Every test gets its own schema with db/migrations applied.
+//
+// The embedded server downloads its binaries unless TEST_POSTGRES_CACHE names a
+// directory they were cached in. If it can't be started, offline for one, the
+// tests are skipped so the rest of the suite stays usable; CI sets
+// TEST_POSTGRES_REQUIRED=1 to fail instead, so a missing database never passes
+// as green there. A TEST_DATABASE_URL that can't be reached always fails.
+var (
+	testDSN   string
+	testDB    *sql.DB
+	testDBErr error
+)
+
+func TestMain(m *testing.M) {
+	stop, err := startTestDB()
+	if err != nil {
+		_, explicit := os.LookupEnv("TEST_DATABASE_URL")
+		if explicit || os.Getenv("TEST_POSTGRES_REQUIRED") == "1" {
+			log.Printf("error with starting test postgres: %s", err)
+			os.Exit(1)
+		}
+		testDBErr = err
+		log.Printf("postgres tests will be skipped: %s (set TEST_DATABASE_URL or "+
+			"TEST_POSTGRES_CACHE to run them)", err)
+	}
+
+	code := m.Run()
+	if stop != nil {
+		stop()
+	}
+	os.Exit(code)
+}
+
+func startTestDB() (func(), error) {
+	if dsn, ok := os.LookupEnv("TEST_DATABASE_URL"); ok {
+		testDSN = dsn
+		db, err := sql.Open("postgres", testDSN)
+		if err != nil {
+			return nil, err
+		}
+		testDB = db
+		return func() { db.Close() }, db.Ping()
+	}
+
+	port, err := freePort()
+	if err != nil {
+		return nil, err
+	}
+	dir, err := os.MkdirTemp("", "travel-postgres")
+	if err != nil {
+		return nil, err
+	}
+
+	cfg := embeddedpostgres.DefaultConfig().
+		Port(uint32(port)).
+		Database("travel_test").
+		RuntimePath(filepath.Join(dir, "runtime")).
+		Logger(io.Discard)
+	if cache := os.Getenv("TEST_POSTGRES_CACHE"); cache != "" {
+		cfg = cfg.CachePath(cache)
+	}
+	pg := embeddedpostgres.NewDatabase(cfg)
+	if err := pg.Start(); err != nil {
+		os.RemoveAll(dir)
+		return nil, fmt.Errorf("error with starting embedded postgres: %s", err)
+	}
+
+	testDSN = fmt.Sprintf("host=localhost port=%d user=postgres password=postgres "+
+		"dbname=travel_test sslmode=disable", port)
+	testDB, err = sql.Open("postgres", testDSN)
+	stop := func() {
+		testDB.Close()
+		pg.Stop()
+		os.RemoveAll(dir)
+	}
+	return stop, err
+}
+
+func freePort() (int, error) {
+	l, err := net.Listen("tcp", "localhost:0")
+	if err != nil {
+		return 0, err
+	}
+	defer l.Close()
+	return l.Addr().(*net.TCPAddr).Port, nil
+}
+
+// NewTestDB returns a connection to a fresh schema with all migrations
+// applied. The schema is dropped when the test finishes.
+func NewTestDB(t testing.TB) *sql.DB {
+	t.Helper()
+	if testDBErr != nil {
+		t.Skipf("postgres is not available: %s", testDBErr)
+	}
+
+	schema := "test_" + strings.ReplaceAll(uuid.NewString(), "-", "")
+	if _, err := testDB.Exec("create schema " + schema); err != nil {
+		t.Fatal(err)
+	}
+
+	db, err := sql.Open("postgres", testDSN+" search_path="+schema)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		db.Close()
+		testDB.Exec("drop schema " + schema + " cascade")
+	})
+
+	if err := migrations.Up(db); err != nil {
+		t.Fatalf("error with migrating test schema: %s", err)
+	}
+	return db
+}
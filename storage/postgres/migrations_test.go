@@ -0,0 +1,81 @@
+package postgres
+
+import (
+	"context"
+	"testing"
+	"travel/db/migrations"
+
+	"github.com/google/uuid"
+)
+
+func TestMigrationsDownUp(t *testing.T) {
+	db := NewTestDB(t)
+
+	status, err := migrations.GetStatus(db)
+	if err != nil {
+		t.Fatal(err)
+	}
+	last := status.Migrations[len(status.Migrations)-1]
+	if status.Version != last.Version || status.Dirty || !last.Applied {
+		t.Fatalf("expected all migrations to be applied, got %v", status)
+	}
+
+	if err := migrations.Down(db, len(status.Migrations)); err != nil {
+		t.Fatal(err)
+	}
+	if err := migrations.Up(db); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestConstraints(t *testing.T) {
+	db := NewTestDB(t)
+	storyId := seedStory(t, db)
+	itineraryId, des := seedItinerary(t, db)
+
+	bad := map[string]string{
+		"negative likes": `update stories set likes_count = -1 where id = '` +
+			storyId + `'`,
+		"negative comments": `update itineraries set comments_count = -1 where id = '` +
+			itineraryId + `'`,
+		"itinerary ends before start": `update itineraries set end_date = '2024-07-01'
+			where id = '` + itineraryId + `'`,
+		"destination ends before start": `update itinerary_destinations
+			set end_date = '2024-07-01' where id = '` + des.Id + `'`,
+		"story without author": `insert into stories (title, content) values ('a', 'b')`,
+	}
+	for name, query := range bad {
+		if _, err := db.Exec(query); err == nil {
+			t.Errorf("%s: expected check constraint violation", name)
+		}
+	}
+}
+
+func TestLegacyStoryImages(t *testing.T) {
+	ctx := context.Background()
+	db := NewTestDB(t)
+
+	// back to before story images moved out of stories.images
+	if err := migrations.Down(db, 2); err != nil {
+		t.Fatal(err)
+	}
+	id := uuid.NewString()
+	_, err := db.Exec(`insert into stories (id, title, content, author_id, images)
+		values ($1, 'Bali', 'beaches', $2, array['a.jpg', 'b.jpg'])`,
+		id, testAuthorId)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := migrations.Up(db); err != nil {
+		t.Fatal(err)
+	}
+
+	images, err := NewStoriesRepo(db, testLogger, 0).GetStoryImages(ctx, id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(*images) != 2 || (*images)[0].Url != "a.jpg" ||
+		(*images)[1].ThumbnailUrl != "b.jpg" {
+		t.Errorf("expected the legacy images to be kept, got %v", *images)
+	}
+}
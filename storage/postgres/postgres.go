@@ -1,20 +1,96 @@
 package postgres
 
 import (
+	"context"
 	"database/sql"
 	"fmt"
+	"log"
+	"time"
 	"travel/config"
 
+	"github.com/XSAM/otelsql"
 	_ "github.com/lib/pq"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
 )
 
-func ConnectDB() (*sql.DB, error) {
-	cfg := config.Load()
+const maxConnectBackoff = 30 * time.Second
 
-	conn := fmt.Sprintf(`host=%s port=%s user=%s dbname=%s password=%s 
-	sslmode=disable`, cfg.DB_HOST, cfg.DB_PORT, cfg.DB_USER, cfg.DB_NAME,
-		cfg.DB_PASSWORD)
+// ConnectDB opens the connection pool configured in config.Config and pings the
+// database until it answers, retrying with exponential backoff. Every query is
+// traced as a span of the request it runs for.
+func ConnectDB(cfg *config.Config) (*sql.DB, error) {
+	conn := fmt.Sprintf(`host=%s port=%s user=%s dbname=%s password=%s
+	sslmode=%s`, cfg.DB_HOST, cfg.DB_PORT, cfg.DB_USER, cfg.DB_NAME,
+		cfg.DB_PASSWORD, cfg.DB_SSL_MODE)
 
-	db, err := sql.Open("postgres", conn)
-	return db, err
+	db, err := otelsql.Open("postgres", conn, otelsql.WithAttributes(
+		semconv.DBSystemPostgreSQL, semconv.DBNamespace(cfg.DB_NAME)),
+		otelsql.WithSpanOptions(otelsql.SpanOptions{
+			OmitConnResetSession: true,
+			OmitRows:             true,
+		}))
+	if err != nil {
+		return nil, err
+	}
+	db.SetMaxOpenConns(cfg.DB_MAX_OPEN_CONNS)
+	db.SetMaxIdleConns(cfg.DB_MAX_IDLE_CONNS)
+	db.SetConnMaxLifetime(cfg.DB_CONN_MAX_LIFETIME)
+	db.SetConnMaxIdleTime(cfg.DB_CONN_MAX_IDLE_TIME)
+
+	err = retry(context.Background(), cfg.DB_CONNECT_RETRIES,
+		cfg.DB_CONNECT_BACKOFF, db.PingContext)
+	if err != nil {
+		db.Close()
+		return nil, fmt.Errorf("error with connecting to database: %s", err)
+	}
+	return db, nil
+}
+
+// withTimeout bounds a query by the per-query timeout of the repo, on top of
+// the deadline of the RPC it runs for. A zero timeout only inherits ctx.
+func withTimeout(ctx context.Context, timeout time.Duration) (context.Context,
+	context.CancelFunc) {
+	if timeout <= 0 {
+		return context.WithCancel(ctx)
+	}
+	return context.WithTimeout(ctx, timeout)
+}
+
+// retry calls fn up to attempts times, doubling the wait between the calls
+// starting from backoff. It returns the last error.
+func retry(ctx context.Context, attempts int, backoff time.Duration,
+	fn func(ctx context.Context) error) error {
+	var err error
+	for n := 1; ; n++ {
+		if err = fn(ctx); err == nil || n >= attempts {
+			return err
+		}
+		log.Printf("attempt %d of %d failed, retrying in %s: %s", n, attempts,
+			backoff, err)
+
+		select {
+		case <-ctx.Done():
+			return err
+		case <-time.After(backoff):
+		}
+		backoff = min(backoff*2, maxConnectBackoff)
+	}
+}
+
+// PoolStats returns the connection pool statistics of db as named gauges and
+// counters, in the form metrics exporters take them. The names of the counters
+// end with _total.
+func PoolStats(db *sql.DB) map[string]float64 {
+	stats := db.Stats()
+	return map[string]float64{
+		"max_open_connections":        float64(stats.MaxOpenConnections),
+		"open_connections":            float64(stats.OpenConnections),
+		"in_use":                      float64(stats.InUse),
+		"idle":                        float64(stats.Idle),
+		"waits_total":                 float64(stats.WaitCount),
+		"wait_duration_seconds_total": stats.WaitDuration.Seconds(),
+		"max_idle_closed_total":       float64(stats.MaxIdleClosed),
+		"max_idle_time_closed_total":  float64(stats.MaxIdleTimeClosed),
+		"max_lifetime_closed_total":   float64(stats.MaxLifetimeClosed),
+	}
 }
@@ -0,0 +1,126 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+	pb "travel/genproto/stories"
+)
+
+func TestRetry(t *testing.T) {
+	calls := 0
+	err := retry(context.Background(), 3, time.Millisecond,
+		func(ctx context.Context) error {
+			calls++
+			if calls < 2 {
+				return errors.New("connection refused")
+			}
+			return nil
+		})
+	if err != nil || calls != 2 {
+		t.Errorf("expected success on the second attempt, got %d calls: %v", calls, err)
+	}
+
+	calls = 0
+	err = retry(context.Background(), 3, time.Millisecond,
+		func(ctx context.Context) error {
+			calls++
+			return errors.New("connection refused")
+		})
+	if err == nil || calls != 3 {
+		t.Errorf("expected failure after 3 attempts, got %d calls: %v", calls, err)
+	}
+}
+
+func TestRetryCanceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	calls := 0
+	err := retry(ctx, 10, time.Hour, func(ctx context.Context) error {
+		calls++
+		cancel()
+		return errors.New("connection refused")
+	})
+	if err == nil || calls != 1 {
+		t.Errorf("expected retry to stop when canceled, got %d calls: %v", calls, err)
+	}
+}
+
+func TestPoolStats(t *testing.T) {
+	db, err := sql.Open("postgres", "host=localhost")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+	db.SetMaxOpenConns(7)
+
+	stats := PoolStats(db)
+	if stats["max_open_connections"] != 7 || stats["open_connections"] != 0 {
+		t.Errorf("unexpected pool stats: %v", stats)
+	}
+}
+
+func TestWithTimeout(t *testing.T) {
+	ctx, cancel := withTimeout(context.Background(), time.Second)
+	defer cancel()
+	if deadline, ok := ctx.Deadline(); !ok || time.Until(deadline) > time.Second {
+		t.Errorf("expected a deadline within a second, got %v %v", deadline, ok)
+	}
+
+	parent, cancelParent := context.WithTimeout(context.Background(), time.Millisecond)
+	defer cancelParent()
+	ctx, cancel = withTimeout(parent, time.Hour)
+	defer cancel()
+	if deadline, _ := ctx.Deadline(); time.Until(deadline) > time.Millisecond {
+		t.Errorf("expected the deadline of the RPC to win, got %v", deadline)
+	}
+
+	ctx, cancel = withTimeout(context.Background(), 0)
+	defer cancel()
+	if _, ok := ctx.Deadline(); ok {
+		t.Error("expected no deadline when the timeout is disabled")
+	}
+}
+
+func TestCanceledContext(t *testing.T) {
+	repo := NewRepo(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err := repo.GetStories(ctx, &pb.RequestGetStories{Limit: 10})
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("expected canceled error, got %v", err)
+	}
+	if err := repo.DeleteStory(ctx, seedStory(t, repo.DB)); !errors.Is(err,
+		context.Canceled) {
+		t.Errorf("expected canceled error, got %v", err)
+	}
+}
+
+func TestQueryTimeout(t *testing.T) {
+	repo := NewRepo(t)
+	ctx := context.Background()
+	storyId := seedStory(t, repo.DB)
+
+	// a transaction holding the row lock makes the edit wait until it times out
+	tx, err := repo.DB.BeginTx(ctx, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer tx.Rollback()
+	_, err = tx.Exec("select id from stories where id = $1 for update", storyId)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	repo.Timeout = 100 * time.Millisecond
+	start := time.Now()
+	_, err = repo.EditStory(ctx, &pb.RequestEditStory{Id: storyId, Title: "late"}, nil)
+	if err == nil {
+		t.Error("expected the edit to time out")
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("expected the query to be canceled after the timeout, took %s", elapsed)
+	}
+}
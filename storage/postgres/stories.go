@@ -1,51 +1,88 @@
 package postgres
 
 import (
+	"context"
 	"database/sql"
 	"fmt"
 	"log/slog"
 	"time"
 	pb "travel/genproto/stories"
 	"travel/models"
-	"travel/pkg/logger"
 
 	"github.com/google/uuid"
 	"github.com/lib/pq"
 )
 
 type StoriesRepo struct {
-	Logger *slog.Logger
-	DB     *sql.DB
+	Logger  *slog.Logger
+	DB      *sql.DB
+	Timeout time.Duration
 }
 
-func NewStoriesRepo(db *sql.DB) *StoriesRepo {
-	logger := logger.NewLogger()
+func NewStoriesRepo(db *sql.DB, logger *slog.Logger,
+	timeout time.Duration) *StoriesRepo {
 	return &StoriesRepo{
-		Logger: logger,
-		DB:     db,
+		Logger:  logger,
+		DB:      db,
+		Timeout: timeout,
 	}
 }
 
-func (s *StoriesRepo) CreateStory(story *pb.RequestCreateStory) (
-	string, error) {
+func (s *StoriesRepo) CreateStory(ctx context.Context,
+	story *pb.RequestCreateStory) (string, error) {
+	return s.createStory(ctx, story, models.StoryPublished)
+}
+
+// CreateDraft creates the story as a draft, not listed until it is published.
+func (s *StoriesRepo) CreateDraft(ctx context.Context,
+	story *pb.RequestCreateStory) (string, error) {
+	return s.createStory(ctx, story, models.StoryDraft)
+}
+
+func (s *StoriesRepo) createStory(ctx context.Context,
+	story *pb.RequestCreateStory, status string) (string, error) {
+	ctx, cancel := withTimeout(ctx, s.Timeout)
+	defer cancel()
 
 	query := `
 		insert into stories(
-			id, title, content, location, author_id, images
+			id, title, content, location, author_id, status, publish_at
 		) values (
-			$1, $2, $3, $4, $5, $6
+			$1, $2, $3, $4, $5, $6, $7
 		)
 	`
 
+	var publishAt *time.Time
+	if status == models.StoryPublished {
+		now := time.Now()
+		publishAt = &now
+	}
 	newId := uuid.NewString()
-	_, err := s.DB.Exec(query, newId, story.Title, story.Content, story.Location,
-		story.AuthorId, pq.Array(story.Images))
+	_, err := s.DB.ExecContext(ctx, query, newId, story.Title, story.Content,
+		story.Location, story.AuthorId, status, publishAt)
 
 	return newId, err
 }
 
-func (s *StoriesRepo) CreateStoryTags(storyId string, tags *[]string) error {
+func (s *StoriesRepo) CreateStoryTags(ctx context.Context, storyId string,
+	tags *[]string) error {
+	ctx, cancel := withTimeout(ctx, s.Timeout)
+	defer cancel()
+
+	tx, err := s.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if err := insertTags(ctx, tx, storyId, *tags); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
 
+func insertTags(ctx context.Context, tx *sql.Tx, storyId string,
+	tags []string) error {
 	query := `
 		insert into story_tags(
 			story_id, tag
@@ -54,8 +91,8 @@ func (s *StoriesRepo) CreateStoryTags(storyId string, tags *[]string) error {
 		)
 	`
 
-	for _, tag := range *tags {
-		res, err := s.DB.Exec(query, storyId, tag)
+	for _, tag := range tags {
+		res, err := tx.ExecContext(ctx, query, storyId, tag)
 		if err != nil {
 			return err
 		}
@@ -66,7 +103,64 @@ func (s *StoriesRepo) CreateStoryTags(storyId string, tags *[]string) error {
 	return nil
 }
 
-func (s *StoriesRepo) EditStory(story *pb.RequestEditStory) (string, error) {
+// EditStory saves the current state of the story as a new revision and
+// overwrites it, with its tags and, unless images is nil, its images, in one
+// transaction. Drafts and scheduled stories are autosaved, so their edits keep
+// no revisions.
+func (s *StoriesRepo) EditStory(ctx context.Context, story *pb.RequestEditStory,
+	images *models.StoryImagesEdit) (*models.EditedStory, error) {
+	ctx, cancel := withTimeout(ctx, s.Timeout)
+	defer cancel()
+
+	tx, err := s.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error with creating transaction: %s", err)
+	}
+	defer tx.Rollback()
+
+	// the lock orders concurrent edits, so each gets the next revision number
+	lock := `
+		select
+			status
+		from
+			stories
+		where
+			id = $1 and
+			deleted_at is null
+		for update
+	`
+	var status string
+	if err := tx.QueryRowContext(ctx, lock, story.Id).Scan(&status); err != nil {
+		return nil, err
+	}
+
+	revision := `
+		insert into story_revisions(
+			story_id, revision, title, content, location, tags, image_ids
+		)
+		select
+			s.id, 
+			coalesce((
+				select max(revision) from story_revisions where story_id = s.id
+			), 0) + 1,
+			s.title, s.content, s.location,
+			coalesce((
+				select array_agg(tag order by tag) from story_tags where story_id = s.id
+			), '{}'),
+			coalesce((
+				select array_agg(id order by position) from story_images
+				where story_id = s.id and removed_at is null
+			), '{}')
+		from
+			stories s
+		where
+			s.id = $1
+	`
+	if status != models.StoryDraft && status != models.StoryScheduled {
+		if _, err := tx.ExecContext(ctx, revision, story.Id); err != nil {
+			return nil, fmt.Errorf("error with saving story revision: %s", err)
+		}
+	}
 
 	query := `
 		update
@@ -75,19 +169,74 @@ func (s *StoriesRepo) EditStory(story *pb.RequestEditStory) (string, error) {
 			title = $1,
 			content = $2,
 			location = $3,
-			images = $4
+			updated_at = now()
 		where
-			id = $5 and 
+			id = $4 and 
 			deleted_at is null
 		returning author_id
 	`
-	var AuthorId string
-	err := s.DB.QueryRow(query, story.Title, story.Content, story.Location,
-		pq.Array(story.Images), story.Id).Scan(&AuthorId)
-	return AuthorId, err
+	res := models.EditedStory{Deleted: []models.Image{}}
+	err = tx.QueryRowContext(ctx, query, story.Title, story.Content, story.Location,
+		story.Id).Scan(&res.AuthorId)
+	if err != nil {
+		return nil, err
+	}
+
+	if images != nil {
+		res.Deleted, err = editImages(ctx, tx, story.Id, images)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if _, err := tx.ExecContext(ctx, `delete from story_tags where story_id = $1`,
+		story.Id); err != nil {
+		return nil, fmt.Errorf("error with deleting story tags: %s", err)
+	}
+	if err := insertTags(ctx, tx, story.Id, story.Tags); err != nil {
+		return nil, fmt.Errorf("error with creating story tags: %s", err)
+	}
+
+	rows, err := tx.QueryContext(ctx, storyImagesQuery, story.Id)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	res.Images, err = scanImages(rows)
+	if err != nil {
+		return nil, err
+	}
+	return &res, tx.Commit()
+}
+
+// editImages applies the change of the images of the story and returns the
+// images it deleted.
+func editImages(ctx context.Context, tx *sql.Tx, storyId string,
+	images *models.StoryImagesEdit) ([]models.Image, error) {
+	if images.Restore != nil {
+		return restoreImages(ctx, tx, storyId, images.Restore)
+	}
+
+	deleted := []models.Image{}
+	var err error
+	if images.Replace {
+		_, deleted, err = removeImages(ctx, tx, storyId, nil)
+	} else if len(images.RemoveIds) > 0 {
+		var removed int
+		removed, deleted, err = removeImages(ctx, tx, storyId, images.RemoveIds)
+		if err == nil && removed != len(images.RemoveIds) {
+			err = fmt.Errorf("image is not found with the id")
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	return deleted, insertImages(ctx, tx, storyId, images.Added)
 }
 
-func (s *StoriesRepo) DeleteStoryTags(storyId string) error {
+func (s *StoriesRepo) DeleteStoryTags(ctx context.Context, storyId string) error {
+	ctx, cancel := withTimeout(ctx, s.Timeout)
+	defer cancel()
 
 	query := `
 		delete from
@@ -96,46 +245,74 @@ func (s *StoriesRepo) DeleteStoryTags(storyId string) error {
 			story_id = $1
 	`
 
-	_, err := s.DB.Exec(query, storyId)
+	_, err := s.DB.ExecContext(ctx, query, storyId)
 	return err
 }
 
-func (s *StoriesRepo) GetStories(filter *pb.RequestGetStories) (
-	*[]models.Story, error) {
+func (s *StoriesRepo) GetStories(ctx context.Context,
+	filter *pb.RequestGetStories) (*[]models.Story, error) {
+	ctx, cancel := withTimeout(ctx, s.Timeout)
+	defer cancel()
 
+	// the first image of a story is its cover
 	query := `
 		select
-			id, title, author_id, location, likes_count, comments_count, 
-			created_at
+			s.id, s.title, s.author_id, s.location, s.likes_count, s.comments_count, 
+			s.created_at, coalesce(i.id::text, ''), coalesce(i.key, ''),
+			coalesce(i.url, ''), coalesce(i.thumbnail_key, ''),
+			coalesce(i.thumbnail_url, ''), coalesce(i.content_type, ''),
+			coalesce(i.width, 0), coalesce(i.height, 0)
 		from
-			stories
+			stories s
+		left join lateral (
+			select
+				*
+			from
+				story_images
+			where
+				story_id = s.id and
+				removed_at is null
+			order by
+				position
+			limit 1
+		) i on true
 		where
-			deleted_at is null
+			s.deleted_at is null and
+			s.status = 'published'
 		limit $1
 		offset $2
 	`
 
-	rows, err := s.DB.Query(query, filter.Limit, filter.Limit*filter.Page)
+	rows, err := s.DB.QueryContext(ctx, query, filter.Limit, filter.Limit*filter.Page)
 	if err != nil {
 		return nil, err
 	}
+	defer rows.Close()
 
 	stories := []models.Story{}
 	for rows.Next() {
 		var story models.Story
+		var cover models.Image
 		err := rows.Scan(&story.Id, &story.Title, &story.AuthorId,
 			&story.Location, &story.LikesCount, &story.CommentsCount,
-			&story.CreatedAt)
+			&story.CreatedAt, &cover.Id, &cover.Key, &cover.Url,
+			&cover.ThumbnailKey, &cover.ThumbnailUrl, &cover.ContentType,
+			&cover.Width, &cover.Height)
 		if err != nil {
 			return nil, err
 		}
+		if cover.Id != "" {
+			story.CoverImage = &cover
+		}
 		stories = append(stories, story)
 	}
 
 	return &stories, nil
 }
 
-func (s *StoriesRepo) FindNumberOfStories() (int, error) {
+func (s *StoriesRepo) FindNumberOfStories(ctx context.Context) (int, error) {
+	ctx, cancel := withTimeout(ctx, s.Timeout)
+	defer cancel()
 
 	query := `
 		select
@@ -143,16 +320,19 @@ func (s *StoriesRepo) FindNumberOfStories() (int, error) {
 		from
 			stories
 		where
-			deleted_at is null
+			deleted_at is null and
+			status = 'published'
 	`
 
 	count := 0
-	err := s.DB.QueryRow(query).Scan(&count)
+	err := s.DB.QueryRowContext(ctx, query).Scan(&count)
 	return count, err
 }
 
-func (s *StoriesRepo) GetStoryFullInfo(id string) (
+func (s *StoriesRepo) GetStoryFullInfo(ctx context.Context, id string) (
 	*models.StoryFullInfo, error) {
+	ctx, cancel := withTimeout(ctx, s.Timeout)
+	defer cancel()
 
 	query := `
 		select
@@ -162,17 +342,30 @@ func (s *StoriesRepo) GetStoryFullInfo(id string) (
 			stories
 		where
 			id = $1 and 
-			deleted_at is null
+			deleted_at is null and
+			status = 'published'
 	`
 
 	res := models.StoryFullInfo{}
-	err := s.DB.QueryRow(query, id).Scan(&res.Id, &res.Title, &res.Content,
+	err := s.DB.QueryRowContext(ctx, query, id).Scan(&res.Id, &res.Title, &res.Content,
 		&res.AuthorId, &res.Location, &res.LikesCount, &res.CommentsCount,
 		&res.CreatedAt, &res.UpdatedAt)
-	return &res, err
+	if err != nil {
+		return &res, err
+	}
+
+	images, err := s.GetStoryImages(ctx, id)
+	if err != nil {
+		return &res, err
+	}
+	res.Images = *images
+	return &res, nil
 }
 
-func (s *StoriesRepo) GetStoryTags(storyId string) (*[]string, error) {
+func (s *StoriesRepo) GetStoryTags(ctx context.Context, storyId string) (
+	*[]string, error) {
+	ctx, cancel := withTimeout(ctx, s.Timeout)
+	defer cancel()
 
 	query := `
 		select
@@ -184,11 +377,213 @@ func (s *StoriesRepo) GetStoryTags(storyId string) (*[]string, error) {
 	`
 
 	res := []string{}
-	err := s.DB.QueryRow(query, storyId).Scan(pq.Array(&res))
+	err := s.DB.QueryRowContext(ctx, query, storyId).Scan(pq.Array(&res))
 	return &res, err
 }
 
-func (s *StoriesRepo) DeleteStory(id string) error {
+// CreateStoryImages appends the images to the story and sets their ids.
+func (s *StoriesRepo) CreateStoryImages(ctx context.Context, storyId string,
+	images []models.Image) error {
+	ctx, cancel := withTimeout(ctx, s.Timeout)
+	defer cancel()
+
+	tx, err := s.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if err := insertImages(ctx, tx, storyId, images); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+func insertImages(ctx context.Context, tx *sql.Tx, storyId string,
+	images []models.Image) error {
+	query := `
+		insert into story_images(
+			story_id, key, url, thumbnail_key, thumbnail_url, content_type,
+			width, height, position
+		) values (
+			$1, $2, $3, $4, $5, $6, $7, $8, (
+				select
+					coalesce(max(position) + 1, 0)
+				from
+					story_images
+				where
+					story_id = $1
+			)
+		)
+		returning id
+	`
+
+	for n, img := range images {
+		err := tx.QueryRowContext(ctx, query, storyId, img.Key, img.Url,
+			img.ThumbnailKey, img.ThumbnailUrl, img.ContentType, img.Width,
+			img.Height).Scan(&images[n].Id)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+const storyImagesQuery = `
+	select
+		id, key, url, thumbnail_key, thumbnail_url, content_type, width, height
+	from
+		story_images
+	where
+		story_id = $1 and
+		removed_at is null
+	order by
+		position
+`
+
+func (s *StoriesRepo) GetStoryImages(ctx context.Context, storyId string) (
+	*[]models.Image, error) {
+	ctx, cancel := withTimeout(ctx, s.Timeout)
+	defer cancel()
+
+	rows, err := s.DB.QueryContext(ctx, storyImagesQuery, storyId)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	images, err := scanImages(rows)
+	return &images, err
+}
+
+// restoreImages makes the images with ids, in their order, the images of the
+// story, bringing back the detached ones. It returns the images deleted by
+// removing the others.
+func restoreImages(ctx context.Context, tx *sql.Tx, storyId string,
+	ids []string) ([]models.Image, error) {
+	others := `
+		select
+			id
+		from
+			story_images
+		where
+			story_id = $1 and
+			removed_at is null and
+			not id::text = any($2)
+	`
+	rows, err := tx.QueryContext(ctx, others, storyId, pq.Array(ids))
+	if err != nil {
+		return nil, err
+	}
+	otherIds := []string{}
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			rows.Close()
+			return nil, err
+		}
+		otherIds = append(otherIds, id)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	deleted := []models.Image{}
+	if len(otherIds) > 0 {
+		_, deleted, err = removeImages(ctx, tx, storyId, otherIds)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	query := `
+		update
+			story_images
+		set
+			removed_at = null,
+			position = array_position($2::text[], id::text) - 1
+		where
+			story_id = $1 and
+			id::text = any($2)
+	`
+	res, err := tx.ExecContext(ctx, query, storyId, pq.Array(ids))
+	if err != nil {
+		return nil, err
+	}
+	if count, _ := res.RowsAffected(); count != int64(len(ids)) {
+		return nil, fmt.Errorf("image is not found with the id")
+	}
+	return deleted, nil
+}
+
+// removeImages removes the images of the story with ids, or all of them when
+// ids is nil. The ones a revision refers to are only detached; the others are
+// deleted and returned, so their blobs can be removed.
+func removeImages(ctx context.Context, tx *sql.Tx, storyId string, ids []string) (
+	int, []models.Image, error) {
+	detach := `
+		update
+			story_images i
+		set
+			removed_at = now()
+		where
+			i.story_id = $1 and
+			i.removed_at is null and
+			($2::text[] is null or i.id::text = any($2)) and
+			exists (
+				select 1 from story_revisions r
+				where r.story_id = $1 and i.id = any(r.image_ids)
+			)
+	`
+	res, err := tx.ExecContext(ctx, detach, storyId, pq.Array(ids))
+	if err != nil {
+		return 0, nil, err
+	}
+	detached, err := res.RowsAffected()
+	if err != nil {
+		return 0, nil, err
+	}
+
+	query := `
+		delete from
+			story_images
+		where
+			story_id = $1 and
+			removed_at is null and
+			($2::text[] is null or id::text = any($2))
+		returning
+			id, key, url, thumbnail_key, thumbnail_url, content_type, width, height
+	`
+	rows, err := tx.QueryContext(ctx, query, storyId, pq.Array(ids))
+	if err != nil {
+		return 0, nil, err
+	}
+	defer rows.Close()
+	deleted, err := scanImages(rows)
+	if err != nil {
+		return 0, nil, err
+	}
+	return int(detached) + len(deleted), deleted, nil
+}
+
+func scanImages(rows *sql.Rows) ([]models.Image, error) {
+	images := []models.Image{}
+	for rows.Next() {
+		var img models.Image
+		err := rows.Scan(&img.Id, &img.Key, &img.Url, &img.ThumbnailKey, &img.ThumbnailUrl,
+			&img.ContentType, &img.Width, &img.Height)
+		if err != nil {
+			return nil, err
+		}
+		images = append(images, img)
+	}
+	return images, rows.Err()
+}
+
+func (s *StoriesRepo) DeleteStory(ctx context.Context, id string) error {
+	ctx, cancel := withTimeout(ctx, s.Timeout)
+	defer cancel()
 
 	query := `
 		update 
@@ -200,9 +595,387 @@ func (s *StoriesRepo) DeleteStory(id string) error {
 			deleted_at is null
 	`
 
-	res, err := s.DB.Exec(query, time.Now(), id)
+	res, err := s.DB.ExecContext(ctx, query, time.Now(), id)
+	if err != nil {
+		return err
+	}
 	if num, _ := res.RowsAffected(); num <= 0 {
 		return fmt.Errorf("story is not found with the id")
 	}
-	return err
+	return nil
+}
+
+// RestoreStory undeletes the story of authorId if it was deleted since the
+// given time.
+func (s *StoriesRepo) RestoreStory(ctx context.Context, id, authorId string,
+	since time.Time) error {
+	ctx, cancel := withTimeout(ctx, s.Timeout)
+	defer cancel()
+
+	query := `
+		update
+			stories
+		set
+			deleted_at = null
+		where
+			id = $1 and
+			author_id = $2 and
+			deleted_at >= $3
+	`
+
+	res, err := s.DB.ExecContext(ctx, query, id, authorId, since)
+	if err != nil {
+		return err
+	}
+	if num, _ := res.RowsAffected(); num <= 0 {
+		return fmt.Errorf("deleted story is not found with the id")
+	}
+	return nil
+}
+
+// GetStoryStatus returns the author and the status of the story.
+func (s *StoriesRepo) GetStoryStatus(ctx context.Context, id string) (
+	string, string, error) {
+	ctx, cancel := withTimeout(ctx, s.Timeout)
+	defer cancel()
+
+	query := `
+		select
+			author_id, status
+		from
+			stories
+		where
+			id = $1 and
+			deleted_at is null
+	`
+
+	var authorId, status string
+	err := s.DB.QueryRowContext(ctx, query, id).Scan(&authorId, &status)
+	return authorId, status, err
+}
+
+// GetDraft returns the story with id if it is a draft or scheduled.
+func (s *StoriesRepo) GetDraft(ctx context.Context, id string) (
+	*models.StoryFullInfo, error) {
+	ctx, cancel := withTimeout(ctx, s.Timeout)
+	defer cancel()
+
+	query := `
+		select
+			id, title, content, author_id, location, status, publish_at,
+			created_at, updated_at
+		from
+			stories
+		where
+			id = $1 and
+			status in ('draft', 'scheduled') and
+			deleted_at is null
+	`
+
+	return scanDraft(s.DB.QueryRowContext(ctx, query, id))
+}
+
+// GetDrafts returns the stories of the author not published yet, draft or
+// scheduled, the most recently updated first.
+func (s *StoriesRepo) GetDrafts(ctx context.Context, authorId string, page,
+	limit int32) (*[]models.StoryFullInfo, error) {
+	ctx, cancel := withTimeout(ctx, s.Timeout)
+	defer cancel()
+
+	query := `
+		select
+			id, title, content, author_id, location, status, publish_at,
+			created_at, updated_at
+		from
+			stories
+		where
+			author_id = $1 and
+			status in ('draft', 'scheduled') and
+			deleted_at is null
+		order by
+			updated_at desc
+		limit $2
+		offset $3
+	`
+
+	rows, err := s.DB.QueryContext(ctx, query, authorId, limit, limit*page)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	drafts := []models.StoryFullInfo{}
+	for rows.Next() {
+		draft, err := scanDraft(rows)
+		if err != nil {
+			return nil, err
+		}
+		drafts = append(drafts, *draft)
+	}
+	return &drafts, rows.Err()
+}
+
+func scanDraft(row interface{ Scan(...any) error }) (*models.StoryFullInfo, error) {
+	var draft models.StoryFullInfo
+	var publishAt sql.NullString
+	err := row.Scan(&draft.Id, &draft.Title, &draft.Content, &draft.AuthorId,
+		&draft.Location, &draft.Status, &publishAt, &draft.CreatedAt,
+		&draft.UpdatedAt)
+	draft.PublishAt = publishAt.String
+	return &draft, err
+}
+
+func (s *StoriesRepo) CountDrafts(ctx context.Context, authorId string) (int, error) {
+	ctx, cancel := withTimeout(ctx, s.Timeout)
+	defer cancel()
+
+	query := `
+		select
+			count(*)
+		from
+			stories
+		where
+			author_id = $1 and
+			status in ('draft', 'scheduled') and
+			deleted_at is null
+	`
+
+	count := 0
+	err := s.DB.QueryRowContext(ctx, query, authorId).Scan(&count)
+	return count, err
+}
+
+// PublishStory publishes the draft or scheduled story at publishAt: now when
+// the status is published, later when it is scheduled.
+func (s *StoriesRepo) PublishStory(ctx context.Context, id, status string,
+	publishAt time.Time) error {
+	ctx, cancel := withTimeout(ctx, s.Timeout)
+	defer cancel()
+
+	query := `
+		update
+			stories
+		set
+			status = $1,
+			publish_at = $2,
+			updated_at = now()
+		where
+			id = $3 and
+			status in ('draft', 'scheduled') and
+			deleted_at is null
+	`
+
+	res, err := s.DB.ExecContext(ctx, query, status, publishAt, id)
+	if err != nil {
+		return err
+	}
+	if num, _ := res.RowsAffected(); num <= 0 {
+		return fmt.Errorf("story is not a draft")
+	}
+	return nil
+}
+
+// ArchiveStory takes a published story down without deleting it.
+func (s *StoriesRepo) ArchiveStory(ctx context.Context, id string) error {
+	ctx, cancel := withTimeout(ctx, s.Timeout)
+	defer cancel()
+
+	query := `
+		update
+			stories
+		set
+			status = 'archived',
+			updated_at = now()
+		where
+			id = $1 and
+			status = 'published' and
+			deleted_at is null
+	`
+
+	res, err := s.DB.ExecContext(ctx, query, id)
+	if err != nil {
+		return err
+	}
+	if num, _ := res.RowsAffected(); num <= 0 {
+		return fmt.Errorf("story is not published")
+	}
+	return nil
+}
+
+// PublishDueStories publishes the scheduled stories due by now and returns
+// their ids. Concurrent schedulers publish each story once.
+func (s *StoriesRepo) PublishDueStories(ctx context.Context, now time.Time) (
+	*[]string, error) {
+	ctx, cancel := withTimeout(ctx, s.Timeout)
+	defer cancel()
+
+	query := `
+		update
+			stories
+		set
+			status = 'published',
+			updated_at = now()
+		where
+			status = 'scheduled' and
+			publish_at <= $1 and
+			deleted_at is null
+		returning id
+	`
+
+	rows, err := s.DB.QueryContext(ctx, query, now)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	ids := []string{}
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+	return &ids, rows.Err()
+}
+
+// GetStoryRevisions returns the revisions of the story, the latest first.
+func (s *StoriesRepo) GetStoryRevisions(ctx context.Context, storyId string, page,
+	limit int32) (*[]models.StoryRevision, error) {
+	ctx, cancel := withTimeout(ctx, s.Timeout)
+	defer cancel()
+
+	query := `
+		select
+			story_id, revision, title, content, coalesce(location, ''), tags,
+			created_at
+		from
+			story_revisions
+		where
+			story_id = $1
+		order by
+			revision desc
+		limit $2
+		offset $3
+	`
+
+	rows, err := s.DB.QueryContext(ctx, query, storyId, limit, limit*page)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	revisions := []models.StoryRevision{}
+	for rows.Next() {
+		revision, err := scanRevision(rows)
+		if err != nil {
+			return nil, err
+		}
+		revisions = append(revisions, *revision)
+	}
+	return &revisions, rows.Err()
+}
+
+func (s *StoriesRepo) CountStoryRevisions(ctx context.Context, storyId string) (
+	int, error) {
+	ctx, cancel := withTimeout(ctx, s.Timeout)
+	defer cancel()
+
+	query := `
+		select
+			count(*)
+		from
+			story_revisions
+		where
+			story_id = $1
+	`
+
+	count := 0
+	err := s.DB.QueryRowContext(ctx, query, storyId).Scan(&count)
+	return count, err
+}
+
+func (s *StoriesRepo) GetStoryRevision(ctx context.Context, storyId string,
+	revision int) (*models.StoryRevision, error) {
+	ctx, cancel := withTimeout(ctx, s.Timeout)
+	defer cancel()
+
+	query := `
+		select
+			story_id, revision, title, content, coalesce(location, ''), tags,
+			created_at
+		from
+			story_revisions
+		where
+			story_id = $1 and
+			revision = $2
+	`
+
+	res, err := scanRevision(s.DB.QueryRowContext(ctx, query, storyId, revision))
+	if err != nil {
+		return nil, err
+	}
+
+	images := `
+		select
+			i.id, i.key, i.url, i.thumbnail_key, i.thumbnail_url, i.content_type,
+			i.width, i.height
+		from
+			story_revisions r
+		join
+			story_images i on i.id = any(r.image_ids)
+		where
+			r.story_id = $1 and
+			r.revision = $2
+		order by
+			array_position(r.image_ids, i.id)
+	`
+	rows, err := s.DB.QueryContext(ctx, images, storyId, revision)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	res.Images, err = scanImages(rows)
+	return res, err
+}
+
+// GetCurrentRevision returns the current state of the story, whatever its
+// status, as revision 0.
+func (s *StoriesRepo) GetCurrentRevision(ctx context.Context, storyId string) (
+	*models.StoryRevision, error) {
+	ctx, cancel := withTimeout(ctx, s.Timeout)
+	defer cancel()
+
+	query := `
+		select
+			s.id, 0, s.title, s.content, coalesce(s.location, ''),
+			coalesce((
+				select array_agg(tag order by tag) from story_tags where story_id = s.id
+			), '{}'),
+			s.updated_at
+		from
+			stories s
+		where
+			s.id = $1 and
+			s.deleted_at is null
+	`
+
+	res, err := scanRevision(s.DB.QueryRowContext(ctx, query, storyId))
+	if err != nil {
+		return nil, err
+	}
+	images, err := s.GetStoryImages(ctx, storyId)
+	if err != nil {
+		return nil, err
+	}
+	res.Images = *images
+	return res, nil
+}
+
+func scanRevision(row interface{ Scan(...any) error }) (*models.StoryRevision, error) {
+	var revision models.StoryRevision
+	err := row.Scan(&revision.StoryId, &revision.Revision, &revision.Title,
+		&revision.Content, &revision.Location, pq.Array(&revision.Tags),
+		&revision.CreatedAt)
+	return &revision, err
 }
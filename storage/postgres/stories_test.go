@@ -1,23 +1,22 @@
 package postgres
 
 import (
-	"log"
+	"context"
 	"testing"
+	"time"
 	pb "travel/genproto/stories"
+	"travel/models"
 )
 
-func NewRepo() *StoriesRepo {
-	db, err := ConnectDB()
-	if err != nil {
-		log.Panic(err)
-	}
-	return NewStoriesRepo(db)
+func NewRepo(t *testing.T) *StoriesRepo {
+	return NewStoriesRepo(NewTestDB(t), testLogger, 0)
 }
 
 func TestCreateStory(t *testing.T) {
+	ctx := context.Background()
 
 	req := pb.RequestCreateStory{
-		AuthorId: "ff9ae172-18f9-4f81-98ff-5db600ce05a7",
+		AuthorId: testAuthorId,
 		Title:    "Go Home",
 		Content:  "About going home",
 		Location: "Uzbekistan",
@@ -25,26 +24,29 @@ func TestCreateStory(t *testing.T) {
 		Images:   []string{"go", "home"},
 	}
 
-	_, err := NewRepo().CreateStory(&req)
+	_, err := NewRepo(t).CreateStory(ctx, &req)
 	if err != nil {
 		t.Error(err)
 	}
 }
 
 func TestCreateStoryTags(t *testing.T) {
-
-	storyId := "cefbcf04-172e-4b01-88ed-763ab5848d45"
+	ctx := context.Background()
+	repo := NewRepo(t)
+	storyId := seedStory(t, repo.DB)
 	tags := []string{"gfc", "dfg"}
 
-	err := NewRepo().CreateStoryTags(storyId, &tags)
+	err := repo.CreateStoryTags(ctx, storyId, &tags)
 	if err != nil {
 		t.Error(err)
 	}
 }
 
 func TestEditStory(t *testing.T) {
+	ctx := context.Background()
+	repo := NewRepo(t)
 	req := pb.RequestEditStory{
-		Id:       "24c22836-26fa-486d-b660-262e123a1a5c",
+		Id:       seedStory(t, repo.DB),
 		Title:    "Sleeping well",
 		Content:  "About Sleeping well",
 		Location: "in mindset",
@@ -52,56 +54,389 @@ func TestEditStory(t *testing.T) {
 		Images:   []string{},
 	}
 
-	_, err := NewRepo().EditStory(&req)
+	edited, err := repo.EditStory(ctx, &req, nil)
 	if err != nil {
-		t.Error(err)
+		t.Fatal(err)
+	}
+	if edited.AuthorId != testAuthorId {
+		t.Errorf("unexpected author id: %s", edited.AuthorId)
+	}
+	tags, _ := repo.GetStoryTags(ctx, req.Id)
+	if len(*tags) != 1 || (*tags)[0] != "go home" {
+		t.Errorf("expected the tags to be replaced, got %v", *tags)
+	}
+
+	// a failing image change rolls back the whole edit
+	_, err = repo.EditStory(ctx, &pb.RequestEditStory{Id: req.Id, Title: "Go Home"},
+		&models.StoryImagesEdit{RemoveIds: []string{"missing"}})
+	if err == nil {
+		t.Fatal("expected removing a missing image to fail")
+	}
+	story, _ := repo.GetStoryFullInfo(ctx, req.Id)
+	tags, _ = repo.GetStoryTags(ctx, req.Id)
+	if story.Title != req.Title || len(*tags) != 1 {
+		t.Errorf("expected the failed edit to be rolled back, got %v, %v", story, *tags)
 	}
 }
 
 func TestDeleteStoryTags(t *testing.T) {
-	storyId := "24c22836-26fa-486d-b660-262e123a1a5c"
+	ctx := context.Background()
+	repo := NewRepo(t)
+	storyId := seedStory(t, repo.DB)
+	repo.CreateStoryTags(ctx, storyId, &[]string{"go home"})
 
-	err := NewRepo().DeleteStoryTags(storyId)
+	err := repo.DeleteStoryTags(ctx, storyId)
 	if err != nil {
 		t.Error(err)
 	}
 }
 
 func TestGetStories(t *testing.T) {
+	ctx := context.Background()
+	repo := NewRepo(t)
+	seedStory(t, repo.DB)
+	seedStory(t, repo.DB)
+
 	req := pb.RequestGetStories{
 		Page:  0,
 		Limit: 10,
 	}
-	_, err := NewRepo().GetStories(&req)
+	stories, err := repo.GetStories(ctx, &req)
 	if err != nil {
 		t.Error(err)
 	}
+	if len(*stories) != 2 {
+		t.Errorf("expected 2 stories, got %d", len(*stories))
+	}
 }
 
 func TestFindNumberOfStories(t *testing.T) {
-	_, err := NewRepo().FindNumberOfStories()
+	ctx := context.Background()
+	repo := NewRepo(t)
+	seedStory(t, repo.DB)
+
+	count, err := repo.FindNumberOfStories(ctx)
 	if err != nil {
 		t.Error(err)
 	}
+	if count != 1 {
+		t.Errorf("expected 1 story, got %d", count)
+	}
 }
 
 func TestGetStoryFullInfo(t *testing.T) {
-	_, err := NewRepo().GetStoryFullInfo("cefbcf04-172e-4b01-88ed-763ab5848d45")
+	ctx := context.Background()
+	repo := NewRepo(t)
+	_, err := repo.GetStoryFullInfo(ctx, seedStory(t, repo.DB))
 	if err != nil {
 		t.Error(err)
 	}
 }
 
 func TestGetStoryTags(t *testing.T) {
-	_, err := NewRepo().GetStoryTags("cefbcf04-172e-4b01-88ed-763ab5848d45")
+	ctx := context.Background()
+	repo := NewRepo(t)
+	storyId := seedStory(t, repo.DB)
+	repo.CreateStoryTags(ctx, storyId, &[]string{"beach", "food"})
+
+	tags, err := repo.GetStoryTags(ctx, storyId)
 	if err != nil {
 		t.Error(err)
 	}
+	if len(*tags) != 2 {
+		t.Errorf("expected 2 tags, got %v", *tags)
+	}
 }
 
 func TestDeleteStory(t *testing.T) {
-	err := NewRepo().DeleteStory("cefbcf04-172e-4b01-88ed-763ab5848d45")
+	ctx := context.Background()
+	repo := NewRepo(t)
+	storyId := seedStory(t, repo.DB)
+
+	err := repo.DeleteStory(ctx, storyId)
 	if err != nil {
 		t.Error(err)
 	}
+	if err := repo.DeleteStory(ctx, storyId); err == nil {
+		t.Error("expected deleted story not to be found")
+	}
+}
+
+func TestStoryImages(t *testing.T) {
+	ctx := context.Background()
+	repo := NewRepo(t)
+	storyId := seedStory(t, repo.DB)
+	images := []models.Image{
+		{Key: "stories/a.jpg", Url: "http://media/stories/a.jpg",
+			ThumbnailKey: "stories/a_thumb.jpg", ThumbnailUrl: "http://media/stories/a_thumb.jpg",
+			ContentType: "image/jpeg", Width: 800, Height: 600},
+		{Key: "stories/b.png", Url: "http://media/stories/b.png",
+			ThumbnailKey: "stories/b_thumb.png", ThumbnailUrl: "http://media/stories/b_thumb.png",
+			ContentType: "image/png", Width: 10, Height: 10},
+	}
+
+	if err := repo.CreateStoryImages(ctx, storyId, images); err != nil {
+		t.Fatal(err)
+	}
+	res, err := repo.GetStoryImages(ctx, storyId)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(*res) != 2 || (*res)[0] != images[0] || (*res)[1] != images[1] {
+		t.Errorf("unexpected images: %v", *res)
+	}
+
+	stories, err := repo.GetStories(ctx, &pb.RequestGetStories{Limit: 10})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cover := (*stories)[0].CoverImage; cover == nil || *cover != images[0] {
+		t.Errorf("expected the first image as cover, got %v", cover)
+	}
+	story, err := repo.GetStoryFullInfo(ctx, storyId)
+	if err != nil || len(story.Images) != 2 {
+		t.Errorf("expected the story with its images, got %v, %v", story, err)
+	}
+
+	// drafts keep no revisions, so the images they remove are deleted
+	_, err = repo.DB.Exec(`update stories set status = 'draft' where id = $1`, storyId)
+	if err != nil {
+		t.Fatal(err)
+	}
+	edit := pb.RequestEditStory{Id: storyId, Title: "Bali"}
+	_, err = repo.EditStory(ctx, &edit, &models.StoryImagesEdit{
+		RemoveIds: []string{images[0].Id, "missing"}})
+	if err == nil {
+		t.Error("expected removing a missing image to fail")
+	}
+	edited, err := repo.EditStory(ctx, &edit, &models.StoryImagesEdit{
+		Added: images[:1], RemoveIds: []string{images[0].Id}})
+	if err != nil || len(edited.Deleted) != 1 || edited.Deleted[0].Key != images[0].Key {
+		t.Fatalf("expected the first image to be removed, got %v, %v", edited, err)
+	}
+	if len(edited.Images) != 2 || edited.Images[1].Key != images[0].Key {
+		t.Errorf("expected the added image to be last, got %v", edited.Images)
+	}
+
+	edited, err = repo.EditStory(ctx, &edit, &models.StoryImagesEdit{Replace: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	res, _ = repo.GetStoryImages(ctx, storyId)
+	if len(edited.Deleted) != 2 || len(*res) != 0 {
+		t.Errorf("expected both images to be deleted, got %v, left %v",
+			edited.Deleted, *res)
+	}
+}
+
+func TestDrafts(t *testing.T) {
+	ctx := context.Background()
+	repo := NewRepo(t)
+	id, err := repo.CreateDraft(ctx, &pb.RequestCreateStory{
+		AuthorId: testAuthorId,
+		Title:    "Go Home",
+		Content:  "About going home",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if count, _ := repo.FindNumberOfStories(ctx); count != 0 {
+		t.Errorf("expected drafts not to be counted, got %d", count)
+	}
+	if _, err := repo.GetStoryFullInfo(ctx, id); err == nil {
+		t.Error("expected drafts not to be found")
+	}
+	drafts, err := repo.GetDrafts(ctx, testAuthorId, 0, 10)
+	if err != nil || len(*drafts) != 1 || (*drafts)[0].Status != models.StoryDraft {
+		t.Fatalf("expected the draft, got %v, %v", drafts, err)
+	}
+	if count, _ := repo.CountDrafts(ctx, testAuthorId); count != 1 {
+		t.Errorf("expected 1 draft, got %d", count)
+	}
+	_, err = repo.EditStory(ctx, &pb.RequestEditStory{Id: id, Title: "Going Home"}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if count, _ := repo.CountStoryRevisions(ctx, id); count != 0 {
+		t.Errorf("expected draft edits to keep no revisions, got %d", count)
+	}
+
+	publishAt := time.Now().Add(time.Hour)
+	if err := repo.PublishStory(ctx, id, models.StoryScheduled, publishAt); err != nil {
+		t.Fatal(err)
+	}
+	draft, err := repo.GetDraft(ctx, id)
+	if err != nil || draft.Status != models.StoryScheduled || draft.PublishAt == "" {
+		t.Fatalf("expected the story to be scheduled, got %v, %v", draft, err)
+	}
+
+	ids, err := repo.PublishDueStories(ctx, time.Now())
+	if err != nil || len(*ids) != 0 {
+		t.Fatalf("expected nothing to be due, got %v, %v", ids, err)
+	}
+	scheduled, _ := repo.GetCurrentRevision(ctx, id)
+	ids, err = repo.PublishDueStories(ctx, publishAt)
+	if err != nil || len(*ids) != 1 || (*ids)[0] != id {
+		t.Fatalf("expected the story to be published, got %v, %v", ids, err)
+	}
+	published, _ := repo.GetCurrentRevision(ctx, id)
+	if published.CreatedAt == scheduled.CreatedAt {
+		t.Error("expected publishing to bump updated_at")
+	}
+	if _, err := repo.GetStoryFullInfo(ctx, id); err != nil {
+		t.Errorf("expected the published story to be found, got %s", err)
+	}
+	if err := repo.PublishStory(ctx, id, models.StoryPublished, time.Now()); err == nil {
+		t.Error("expected publishing a published story to fail")
+	}
+
+	if err := repo.ArchiveStory(ctx, id); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := repo.GetStoryFullInfo(ctx, id); err == nil {
+		t.Error("expected the archived story not to be found")
+	}
+	if err := repo.ArchiveStory(ctx, id); err == nil {
+		t.Error("expected archiving an archived story to fail")
+	}
+}
+
+func TestStoryRevisions(t *testing.T) {
+	ctx := context.Background()
+	repo := NewRepo(t)
+	id := seedStory(t, repo.DB)
+	tags := []string{"culture", "beach"}
+	if err := repo.CreateStoryTags(ctx, id, &tags); err != nil {
+		t.Fatal(err)
+	}
+	before, err := repo.GetCurrentRevision(ctx, id)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, title := range []string{"Sleeping well", "Go Home"} {
+		_, err := repo.EditStory(ctx, &pb.RequestEditStory{Id: id, Title: title,
+			Content: "About " + title}, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	after, err := repo.GetCurrentRevision(ctx, id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	beforeAt, _ := time.Parse(time.RFC3339Nano, before.CreatedAt)
+	afterAt, _ := time.Parse(time.RFC3339Nano, after.CreatedAt)
+	if after.Title != "Go Home" || !afterAt.After(beforeAt) {
+		t.Errorf("expected the edit to bump updated_at, got %s then %s",
+			before.CreatedAt, after.CreatedAt)
+	}
+
+	first, err := repo.GetStoryRevision(ctx, id, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if first.Title != before.Title || first.Content != before.Content ||
+		len(first.Tags) != 2 || first.Tags[0] != "beach" {
+		t.Errorf("expected the original story as revision 1, got %v", first)
+	}
+
+	revisions, err := repo.GetStoryRevisions(ctx, id, 0, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(*revisions) != 2 || (*revisions)[0].Revision != 2 ||
+		(*revisions)[0].Title != "Sleeping well" {
+		t.Errorf("unexpected revisions: %v", *revisions)
+	}
+	if count, _ := repo.CountStoryRevisions(ctx, id); count != 2 {
+		t.Errorf("expected 2 revisions, got %d", count)
+	}
+	if _, err := repo.GetStoryRevision(ctx, id, 3); err == nil {
+		t.Error("expected a missing revision to fail")
+	}
+}
+
+func TestRevisionImages(t *testing.T) {
+	ctx := context.Background()
+	repo := NewRepo(t)
+	id := seedStory(t, repo.DB)
+	images := []models.Image{
+		{Key: "stories/a.jpg", Url: "http://media/stories/a.jpg",
+			ThumbnailKey: "stories/a_thumb.jpg", ThumbnailUrl: "http://media/stories/a_thumb.jpg",
+			ContentType: "image/jpeg", Width: 800, Height: 600},
+		{Key: "stories/b.png", Url: "http://media/stories/b.png",
+			ThumbnailKey: "stories/b_thumb.png", ThumbnailUrl: "http://media/stories/b_thumb.png",
+			ContentType: "image/png", Width: 10, Height: 10},
+	}
+	if err := repo.CreateStoryImages(ctx, id, images[:1]); err != nil {
+		t.Fatal(err)
+	}
+
+	// revision 1 keeps the first image, replaced by the edit
+	edit := pb.RequestEditStory{Id: id, Title: "Go Home"}
+	edited, err := repo.EditStory(ctx, &edit, &models.StoryImagesEdit{
+		Added: images[1:], Replace: true})
+	if err != nil || len(edited.Deleted) != 0 {
+		t.Fatalf("expected the image of the revision to be kept, got %v, %v",
+			edited, err)
+	}
+	if len(edited.Images) != 1 || edited.Images[0].Id != images[1].Id {
+		t.Fatalf("expected only the added image, got %v", edited.Images)
+	}
+
+	revision, err := repo.GetStoryRevision(ctx, id, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(revision.Images) != 1 || revision.Images[0] != images[0] {
+		t.Fatalf("expected the revision to keep the first image, got %v",
+			revision.Images)
+	}
+
+	// restoring saves revision 2, which keeps the second image
+	edited, err = repo.EditStory(ctx, &edit, &models.StoryImagesEdit{
+		Restore: []string{images[0].Id}})
+	if err != nil || len(edited.Deleted) != 0 {
+		t.Fatalf("expected nothing to be deleted, got %v, %v", edited, err)
+	}
+	current, _ := repo.GetStoryImages(ctx, id)
+	if len(*current) != 1 || (*current)[0] != images[0] {
+		t.Errorf("expected the first image to be restored, got %v", *current)
+	}
+	_, err = repo.EditStory(ctx, &edit, &models.StoryImagesEdit{
+		Restore: []string{"missing"}})
+	if err == nil {
+		t.Error("expected restoring a missing image to fail")
+	}
+}
+
+func TestRestoreStory(t *testing.T) {
+	ctx := context.Background()
+	repo := NewRepo(t)
+	storyId := seedStory(t, repo.DB)
+	since := time.Now().Add(-time.Hour)
+
+	if err := repo.RestoreStory(ctx, storyId, testAuthorId, since); err == nil {
+		t.Error("expected a story not deleted not to be restored")
+	}
+	if err := repo.DeleteStory(ctx, storyId); err != nil {
+		t.Fatal(err)
+	}
+	if err := repo.RestoreStory(ctx, storyId, testUserId, since); err == nil {
+		t.Error("expected another author not to restore the story")
+	}
+	if err := repo.RestoreStory(ctx, storyId, testAuthorId, since); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := repo.GetStoryFullInfo(ctx, storyId); err != nil {
+		t.Errorf("expected the story to be restored, got %v", err)
+	}
+
+	repo.DeleteStory(ctx, storyId)
+	if err := repo.RestoreStory(ctx, storyId, testAuthorId, time.Now().Add(time.Hour)); err == nil {
+		t.Error("expected a story deleted before the grace period not to be restored")
+	}
 }
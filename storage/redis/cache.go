@@ -0,0 +1,130 @@
+package redis
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+	"time"
+	"travel/config"
+
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+)
+
+// ErrCacheMiss is returned by Cache.Get when nothing is cached under the key.
+var ErrCacheMiss = redis.Nil
+
+// Cache is the key-value store behind the typed caches of this package.
+// Values are stored JSON encoded.
+type Cache interface {
+	Get(ctx context.Context, key string, value interface{}) error
+	Set(ctx context.Context, key string, value interface{}, ttl time.Duration) error
+	Delete(ctx context.Context, keys ...string) error
+	// Lock takes a lock that expires after ttl. It returns an empty token if
+	// the lock is already held.
+	Lock(ctx context.Context, key string, ttl time.Duration) (string, error)
+	Unlock(ctx context.Context, key, token string) error
+	// Ping reports whether the cache is reachable.
+	Ping(ctx context.Context) error
+}
+
+// NewCache returns the cache selected by CACHE_BACKEND: "redis", "memory" for
+// a process-local cache or "none" to disable caching.
+func NewCache(cfg *config.Config) Cache {
+	backend := cfg.CACHE_BACKEND
+	switch backend {
+	case "redis":
+		return NewRedisCache(NewRedicClient(cfg))
+	case "memory":
+		return NewMemoryCache()
+	case "none":
+		return NoopCache{}
+	}
+	log.Fatalf("unknown cache backend: %s", backend)
+	return nil
+}
+
+// unlockScript deletes the lock only if it is still held by the caller, so a
+// slow refresh can't release a lock that already expired and was re-taken.
+var unlockScript = redis.NewScript(`
+	if redis.call("get", KEYS[1]) == ARGV[1] then
+		return redis.call("del", KEYS[1])
+	end
+	return 0
+`)
+
+type RedisCache struct {
+	Redis *redis.Client
+}
+
+func NewRedisCache(client *redis.Client) *RedisCache {
+	return &RedisCache{Redis: client}
+}
+
+func (r *RedisCache) Get(ctx context.Context, key string, value interface{}) error {
+	res, err := r.Redis.Get(ctx, key).Bytes()
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(res, value)
+}
+
+func (r *RedisCache) Set(ctx context.Context, key string, value interface{},
+	ttl time.Duration) error {
+	val, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+	return r.Redis.Set(ctx, key, string(val), ttl).Err()
+}
+
+func (r *RedisCache) Delete(ctx context.Context, keys ...string) error {
+	return r.Redis.Del(ctx, keys...).Err()
+}
+
+func (r *RedisCache) Lock(ctx context.Context, key string, ttl time.Duration) (
+	string, error) {
+	token := uuid.NewString()
+	ok, err := r.Redis.SetNX(ctx, key, token, ttl).Result()
+	if err != nil || !ok {
+		return "", err
+	}
+	return token, nil
+}
+
+func (r *RedisCache) Unlock(ctx context.Context, key, token string) error {
+	return unlockScript.Run(ctx, r.Redis, []string{key}, token).Err()
+}
+
+func (r *RedisCache) Ping(ctx context.Context) error {
+	return r.Redis.Ping(ctx).Err()
+}
+
+// NoopCache caches nothing: every read misses and every lock is granted.
+type NoopCache struct{}
+
+func (NoopCache) Get(ctx context.Context, key string, value interface{}) error {
+	return ErrCacheMiss
+}
+
+func (NoopCache) Set(ctx context.Context, key string, value interface{},
+	ttl time.Duration) error {
+	return nil
+}
+
+func (NoopCache) Delete(ctx context.Context, keys ...string) error {
+	return nil
+}
+
+func (NoopCache) Lock(ctx context.Context, key string, ttl time.Duration) (
+	string, error) {
+	return uuid.NewString(), nil
+}
+
+func (NoopCache) Unlock(ctx context.Context, key, token string) error {
+	return nil
+}
+
+func (NoopCache) Ping(ctx context.Context) error {
+	return nil
+}
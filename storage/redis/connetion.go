@@ -1,13 +1,28 @@
 package redis
 
 import (
+	"crypto/tls"
+	"log"
+	"travel/config"
+
+	"github.com/redis/go-redis/extra/redisotel/v9"
 	"github.com/redis/go-redis/v9"
 )
 
-func NewRedicClient() redis.Client {
-	return *redis.NewClient(&redis.Options{
-		Addr:     "localhost:6379",
-		DB:       0,
-		Password: "",
-	})
+func NewRedicClient(cfg *config.Config) *redis.Client {
+	opts := redis.Options{
+		Addr:     cfg.REDIS_ADDR,
+		DB:       cfg.REDIS_DB,
+		Password: cfg.REDIS_PASSWORD,
+		PoolSize: cfg.REDIS_POOL_SIZE,
+	}
+	if cfg.REDIS_TLS {
+		opts.TLSConfig = &tls.Config{MinVersion: tls.VersionTLS12}
+	}
+
+	client := redis.NewClient(&opts)
+	if err := redisotel.InstrumentTracing(client); err != nil {
+		log.Printf("error with tracing redis client: %s", err)
+	}
+	return client
 }
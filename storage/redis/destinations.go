@@ -2,44 +2,85 @@ package redis
 
 import (
 	"context"
-	"encoding/json"
+	"fmt"
 	"time"
 
 	pb "travel/genproto/itineraries"
 
-	"github.com/redis/go-redis/v9"
+	"github.com/google/uuid"
+)
+
+const (
+	topDestinationsTTL     = time.Hour
+	topDestinationsLockTTL = 5 * time.Second
+	// the pages are cached under the current generation, so bumping it
+	// invalidates all of them at once
+	topDestinationsGenerationKey = "TopDestinations:generation"
 )
 
 type DestinationRedisClient struct {
-	Redis redis.Client
+	Cache Cache
 }
 
-func NewDestinationRedisClient() *DestinationRedisClient {
+func NewDestinationRedisClient(cache Cache) *DestinationRedisClient {
 	return &DestinationRedisClient{
-		Redis: NewRedicClient(),
+		Cache: cache,
+	}
+}
+
+func TopDestinationsKey(req *pb.RequestGetDestinations) string {
+	return fmt.Sprintf("TopDestinations:%d:%d", req.Page, req.Limit)
+}
+
+// pageKey returns the key of the page of req in the current generation.
+func (r *DestinationRedisClient) pageKey(ctx context.Context,
+	req *pb.RequestGetDestinations) (string, error) {
+	generation := ""
+	err := r.Cache.Get(ctx, topDestinationsGenerationKey, &generation)
+	if err != nil && err != ErrCacheMiss {
+		return "", err
 	}
+	return TopDestinationsKey(req) + ":" + generation, nil
 }
 
-func (r *DestinationRedisClient) GetTopDestinations(ctx context.Context) (
-	*pb.ResponseGetDestinations, error) {
-	des, err := r.Redis.Get(ctx, "TopDestinations").Bytes()
-	if err == redis.Nil || err != nil {
+func (r *DestinationRedisClient) GetTopDestinations(ctx context.Context,
+	req *pb.RequestGetDestinations) (*pb.ResponseGetDestinations, error) {
+	key, err := r.pageKey(ctx, req)
+	if err != nil {
 		return nil, err
 	}
 	resp := pb.ResponseGetDestinations{}
-	err = json.Unmarshal(des, &resp)
-	return &resp, err
+	err = r.Cache.Get(ctx, key, &resp)
+	if err != nil {
+		return nil, err
+	}
+	return &resp, nil
 }
 
-func (r *DestinationRedisClient) SetTopDestinations(ctx context.Context, req *pb.ResponseGetDestinations) error {
-
-	reqMar, err := json.Marshal(req)
+func (r *DestinationRedisClient) SetTopDestinations(ctx context.Context,
+	req *pb.RequestGetDestinations, resp *pb.ResponseGetDestinations) error {
+	key, err := r.pageKey(ctx, req)
 	if err != nil {
 		return err
 	}
-	des := r.Redis.Set(ctx, "TopDestinations", string(reqMar), time.Hour)
-	if des.Err() != nil {
-		return des.Err()
-	}
-	return nil
+	return r.Cache.Set(ctx, key, resp, topDestinationsTTL)
+}
+
+// InvalidateTopDestinations drops every cached page of top destinations, the
+// old ones expiring with their TTL.
+func (r *DestinationRedisClient) InvalidateTopDestinations(ctx context.Context) error {
+	return r.Cache.Set(ctx, topDestinationsGenerationKey, uuid.NewString(), 0)
+}
+
+// LockTopDestinations takes the refresh lock of one page of top destinations.
+// It returns an empty token if the lock is already held by someone else.
+func (r *DestinationRedisClient) LockTopDestinations(ctx context.Context,
+	req *pb.RequestGetDestinations) (string, error) {
+	return r.Cache.Lock(ctx, "lock:"+TopDestinationsKey(req),
+		topDestinationsLockTTL)
+}
+
+func (r *DestinationRedisClient) UnlockTopDestinations(ctx context.Context,
+	req *pb.RequestGetDestinations, token string) error {
+	return r.Cache.Unlock(ctx, "lock:"+TopDestinationsKey(req), token)
 }
@@ -4,15 +4,98 @@ import (
 	"context"
 	"testing"
 
+	pb "travel/genproto/itineraries"
+
+	"github.com/alicebob/miniredis/v2"
 	"github.com/redis/go-redis/v9"
 )
 
-func NewRedis() *DestinationRedisClient {
-	return NewDestinationRedisClient()
+func NewRedis(t *testing.T) (*DestinationRedisClient, *miniredis.Miniredis) {
+	mr := miniredis.RunT(t)
+	return &DestinationRedisClient{
+		Cache: NewRedisCache(redis.NewClient(&redis.Options{Addr: mr.Addr()})),
+	}, mr
 }
+
 func TestGetTopDestinations(t *testing.T) {
-	_, err := NewRedis().GetTopDestinations(context.Background())
-	if err == redis.Nil || err != nil {
-		t.Error(err)
+	r, _ := NewRedis(t)
+	ctx := context.Background()
+	req := &pb.RequestGetDestinations{Page: 0, Limit: 2}
+
+	_, err := r.GetTopDestinations(ctx, req)
+	if err != redis.Nil {
+		t.Fatalf("expected cache miss, got %v", err)
+	}
+
+	err = r.SetTopDestinations(ctx, req, &pb.ResponseGetDestinations{
+		Destinations: []*pb.DestionationInfo{{Id: "1"}, {Id: "2"}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	res, err := r.GetTopDestinations(ctx, req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(res.Destinations) != 2 {
+		t.Errorf("expected 2 destinations, got %d", len(res.Destinations))
+	}
+
+	_, err = r.GetTopDestinations(ctx, &pb.RequestGetDestinations{Page: 1, Limit: 2})
+	if err != redis.Nil {
+		t.Errorf("expected other pages to miss, got %v", err)
+	}
+}
+
+func TestInvalidateTopDestinations(t *testing.T) {
+	r, _ := NewRedis(t)
+	ctx := context.Background()
+	for _, page := range []int32{0, 1} {
+		req := &pb.RequestGetDestinations{Page: page, Limit: 2}
+		err := r.SetTopDestinations(ctx, req, &pb.ResponseGetDestinations{})
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if err := r.InvalidateTopDestinations(ctx); err != nil {
+		t.Fatal(err)
+	}
+	for _, page := range []int32{0, 1} {
+		req := &pb.RequestGetDestinations{Page: page, Limit: 2}
+		if _, err := r.GetTopDestinations(ctx, req); err != redis.Nil {
+			t.Errorf("expected page %d to miss after invalidation, got %v", page, err)
+		}
+	}
+}
+
+func TestLockTopDestinations(t *testing.T) {
+	r, mr := NewRedis(t)
+	ctx := context.Background()
+	req := &pb.RequestGetDestinations{Page: 0, Limit: 5}
+
+	token, err := r.LockTopDestinations(ctx, req)
+	if err != nil || token == "" {
+		t.Fatalf("expected to take the lock, got %q %v", token, err)
+	}
+
+	other, err := r.LockTopDestinations(ctx, req)
+	if err != nil || other != "" {
+		t.Fatalf("expected lock to be held, got %q %v", other, err)
+	}
+
+	if err := r.UnlockTopDestinations(ctx, req, "stale"); err != nil {
+		t.Fatal(err)
+	}
+	if !mr.Exists("lock:" + TopDestinationsKey(req)) {
+		t.Fatal("stale token must not release the lock")
+	}
+
+	if err := r.UnlockTopDestinations(ctx, req, token); err != nil {
+		t.Fatal(err)
+	}
+	if mr.Exists("lock:" + TopDestinationsKey(req)) {
+		t.Error("expected lock to be released")
 	}
 }
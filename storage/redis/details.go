@@ -0,0 +1,59 @@
+package redis
+
+import (
+	"context"
+	"time"
+)
+
+const detailsTTL = 10 * time.Minute
+
+type DetailsRedisClient struct {
+	Cache Cache
+	TTL   time.Duration
+}
+
+func NewDetailsRedisClient(cache Cache) *DetailsRedisClient {
+	return &DetailsRedisClient{
+		Cache: cache,
+		TTL:   detailsTTL,
+	}
+}
+
+func StoryKey(id string) string {
+	return "story:" + id
+}
+
+func ItineraryKey(id string) string {
+	return "itinerary:" + id
+}
+
+func (r *DetailsRedisClient) Get(ctx context.Context, key string, value interface{}) error {
+	return r.Cache.Get(ctx, key, value)
+}
+
+func (r *DetailsRedisClient) Set(ctx context.Context, key string, value interface{}) error {
+	return r.Cache.Set(ctx, key, value, r.TTL)
+}
+
+func (r *DetailsRedisClient) Delete(ctx context.Context, keys ...string) error {
+	return r.Cache.Delete(ctx, keys...)
+}
+
+// ReadThrough returns the value cached under key or, on a miss, loads it
+// with load and caches the result. Cache errors never fail the read, the
+// value is loaded from the source instead.
+func ReadThrough[T any](ctx context.Context, r *DetailsRedisClient, key string,
+	load func() (*T, error)) (*T, error) {
+
+	res := new(T)
+	if err := r.Get(ctx, key, res); err == nil {
+		return res, nil
+	}
+
+	res, err := load()
+	if err != nil {
+		return nil, err
+	}
+	r.Set(ctx, key, res)
+	return res, nil
+}
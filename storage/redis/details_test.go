@@ -0,0 +1,91 @@
+package redis
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	pb "travel/genproto/stories"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+)
+
+func NewDetailsRedis(t *testing.T) (*DetailsRedisClient, *miniredis.Miniredis) {
+	mr := miniredis.RunT(t)
+	return &DetailsRedisClient{
+		Cache: NewRedisCache(redis.NewClient(&redis.Options{Addr: mr.Addr()})),
+		TTL:   time.Minute,
+	}, mr
+}
+
+func TestReadThrough(t *testing.T) {
+	r, _ := NewDetailsRedis(t)
+	calls := 0
+	load := func() (*pb.ResponseGetStoryFullInfo, error) {
+		calls++
+		return &pb.ResponseGetStoryFullInfo{Id: "1", Title: "Bali"}, nil
+	}
+
+	for i := 0; i < 3; i++ {
+		res, err := ReadThrough(context.Background(), r, StoryKey("1"), load)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if res.Title != "Bali" {
+			t.Errorf("unexpected title: %s", res.Title)
+		}
+	}
+	if calls != 1 {
+		t.Errorf("expected 1 load, got %d", calls)
+	}
+}
+
+func TestReadThroughLoadError(t *testing.T) {
+	r, mr := NewDetailsRedis(t)
+	_, err := ReadThrough(context.Background(), r, StoryKey("1"),
+		func() (*pb.ResponseGetStoryFullInfo, error) {
+			return nil, errors.New("not found")
+		})
+	if err == nil {
+		t.Error("expected load error")
+	}
+	if mr.Exists(StoryKey("1")) {
+		t.Error("failed load must not be cached")
+	}
+}
+
+func TestReadThroughRedisDown(t *testing.T) {
+	r, mr := NewDetailsRedis(t)
+	mr.Close()
+
+	res, err := ReadThrough(context.Background(), r, StoryKey("1"),
+		func() (*pb.ResponseGetStoryFullInfo, error) {
+			return &pb.ResponseGetStoryFullInfo{Id: "1"}, nil
+		})
+	if err != nil || res.Id != "1" {
+		t.Errorf("expected fallback to loader, got %v %v", res, err)
+	}
+}
+
+func TestDetailsDelete(t *testing.T) {
+	r, mr := NewDetailsRedis(t)
+	ctx := context.Background()
+
+	err := r.Set(ctx, ItineraryKey("1"), &pb.ResponseGetStoryFullInfo{Id: "1"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	mr.FastForward(30 * time.Second)
+	if !mr.Exists(ItineraryKey("1")) {
+		t.Fatal("expected key to be cached")
+	}
+
+	if err := r.Delete(ctx, ItineraryKey("1")); err != nil {
+		t.Fatal(err)
+	}
+	if mr.Exists(ItineraryKey("1")) {
+		t.Error("expected key to be invalidated")
+	}
+}
@@ -0,0 +1,146 @@
+package redis
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// UserDeleted is the type of the event the users service adds to the stream
+// once an account is deleted.
+const UserDeleted = "user.deleted"
+
+type UserEvent struct {
+	Id     string
+	Type   string
+	UserId string
+	// Deliveries counts the times the event was read, this one included.
+	Deliveries int64
+}
+
+// UserEvents reads the events of the users service from a Redis stream as a
+// member of a consumer group, so every event is handled by one instance.
+// Events not acknowledged are read again after Retry, and moved to the
+// <Stream>:parked stream by Park once given up on.
+type UserEvents struct {
+	Redis    *redis.Client
+	Stream   string
+	Group    string
+	Consumer string
+	Block    time.Duration
+	created  bool
+	pending  bool
+}
+
+func NewUserEvents(client *redis.Client, stream, group,
+	consumer string) *UserEvents {
+	return &UserEvents{
+		Redis:    client,
+		Stream:   stream,
+		Group:    group,
+		Consumer: consumer,
+		Block:    5 * time.Second,
+		pending:  true,
+	}
+}
+
+// Read returns the next events, waiting up to Block for them. The events
+// delivered before but not acknowledged come first.
+func (u *UserEvents) Read(ctx context.Context) ([]UserEvent, error) {
+	if !u.created {
+		err := u.Redis.XGroupCreateMkStream(ctx, u.Stream, u.Group, "0").Err()
+		if err != nil && !strings.HasPrefix(err.Error(), "BUSYGROUP") {
+			return nil, fmt.Errorf("error with creating consumer group: %s", err)
+		}
+		u.created = true
+	}
+
+	id := ">"
+	if u.pending {
+		id = "0"
+	}
+	streams, err := u.Redis.XReadGroup(ctx, &redis.XReadGroupArgs{
+		Group:    u.Group,
+		Consumer: u.Consumer,
+		Streams:  []string{u.Stream, id},
+		Count:    10,
+		Block:    u.Block,
+	}).Result()
+	if errors.Is(err, redis.Nil) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	events := []UserEvent{}
+	for _, stream := range streams {
+		for _, msg := range stream.Messages {
+			event := UserEvent{Id: msg.ID, Deliveries: 1}
+			event.Type, _ = msg.Values["type"].(string)
+			event.UserId, _ = msg.Values["user_id"].(string)
+			events = append(events, event)
+		}
+	}
+	if u.pending && len(events) == 0 {
+		u.pending = false
+	}
+	if u.pending {
+		if err := u.countDeliveries(ctx, events); err != nil {
+			return nil, err
+		}
+	}
+	return events, nil
+}
+
+// countDeliveries sets the delivery counts of the pending events from
+// XPENDING.
+func (u *UserEvents) countDeliveries(ctx context.Context, events []UserEvent) error {
+	pending, err := u.Redis.XPendingExt(ctx, &redis.XPendingExtArgs{
+		Stream:   u.Stream,
+		Group:    u.Group,
+		Start:    events[0].Id,
+		End:      events[len(events)-1].Id,
+		Count:    int64(len(events)),
+		Consumer: u.Consumer,
+	}).Result()
+	if err != nil {
+		return fmt.Errorf("error with getting pending user events: %s", err)
+	}
+	deliveries := map[string]int64{}
+	for _, p := range pending {
+		deliveries[p.ID] = p.RetryCount
+	}
+	for n := range events {
+		if count, ok := deliveries[events[n].Id]; ok {
+			events[n].Deliveries = count
+		}
+	}
+	return nil
+}
+
+func (u *UserEvents) Ack(ctx context.Context, id string) error {
+	return u.Redis.XAck(ctx, u.Stream, u.Group, id).Err()
+}
+
+// Park moves the event to the parked stream and acknowledges it, so it is not
+// delivered again.
+func (u *UserEvents) Park(ctx context.Context, event UserEvent) error {
+	_, err := u.Redis.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.XAdd(ctx, &redis.XAddArgs{Stream: u.Stream + ":parked", Values: map[string]any{
+			"id": event.Id, "type": event.Type, "user_id": event.UserId,
+		}})
+		pipe.XAck(ctx, u.Stream, u.Group, event.Id)
+		return nil
+	})
+	return err
+}
+
+// Retry makes the next Read start over from the events not acknowledged.
+func (u *UserEvents) Retry() {
+	u.pending = true
+}
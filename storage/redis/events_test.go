@@ -0,0 +1,65 @@
+package redis
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+)
+
+func TestUserEvents(t *testing.T) {
+	mr := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	ctx := context.Background()
+	events := NewUserEvents(client, "users", "content-service", "test")
+	events.Block = 10 * time.Millisecond
+
+	res, err := events.Read(ctx)
+	if err != nil || len(res) != 0 {
+		t.Fatalf("expected no events, got %v, %v", res, err)
+	}
+
+	for _, userId := range []string{"1", "2"} {
+		err := client.XAdd(ctx, &redis.XAddArgs{Stream: "users", Values: map[string]any{
+			"type": UserDeleted, "user_id": userId,
+		}}).Err()
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	res, err = events.Read(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(res) != 2 || res[0].Type != UserDeleted || res[0].UserId != "1" {
+		t.Fatalf("unexpected events: %v", res)
+	}
+	if err := events.Ack(ctx, res[0].Id); err != nil {
+		t.Fatal(err)
+	}
+
+	// the event not acknowledged is delivered again
+	events.Retry()
+	res, err = events.Read(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(res) != 1 || res[0].UserId != "2" || res[0].Deliveries != 2 {
+		t.Fatalf("expected the second event again, got %v", res)
+	}
+
+	if err := events.Park(ctx, res[0]); err != nil {
+		t.Fatal(err)
+	}
+	parked, err := client.XRange(ctx, "users:parked", "-", "+").Result()
+	if err != nil || len(parked) != 1 || parked[0].Values["user_id"] != "2" {
+		t.Errorf("expected the event to be parked, got %v, %v", parked, err)
+	}
+	events.Retry()
+	if res, err := events.Read(ctx); err != nil || len(res) != 0 {
+		t.Errorf("expected the parked event not to be delivered again, got %v, %v",
+			res, err)
+	}
+}
@@ -0,0 +1,123 @@
+package redis
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// memorySweepInterval is how often the expired keys never read again are
+// dropped, on the next write.
+const memorySweepInterval = time.Minute
+
+type memoryItem struct {
+	value     []byte
+	expiresAt time.Time
+}
+
+// MemoryCache is a process-local Cache. It is meant for a single replica and
+// for local development, invalidations are not seen by other processes.
+type MemoryCache struct {
+	mu        sync.Mutex
+	items     map[string]memoryItem
+	now       func() time.Time
+	nextSweep time.Time
+}
+
+func NewMemoryCache() *MemoryCache {
+	return &MemoryCache{
+		items: map[string]memoryItem{},
+		now:   time.Now,
+	}
+}
+
+func (m *MemoryCache) get(key string) (memoryItem, bool) {
+	item, ok := m.items[key]
+	if ok && !item.expiresAt.IsZero() && !m.now().Before(item.expiresAt) {
+		delete(m.items, key)
+		return memoryItem{}, false
+	}
+	return item, ok
+}
+
+func (m *MemoryCache) set(key string, value []byte, ttl time.Duration) {
+	now := m.now()
+	if !now.Before(m.nextSweep) {
+		m.sweep(now)
+	}
+	item := memoryItem{value: value}
+	if ttl > 0 {
+		item.expiresAt = now.Add(ttl)
+	}
+	m.items[key] = item
+}
+
+// sweep drops the expired keys, so the ones never read again don't pile up.
+func (m *MemoryCache) sweep(now time.Time) {
+	for key, item := range m.items {
+		if !item.expiresAt.IsZero() && !now.Before(item.expiresAt) {
+			delete(m.items, key)
+		}
+	}
+	m.nextSweep = now.Add(memorySweepInterval)
+}
+
+func (m *MemoryCache) Get(ctx context.Context, key string, value interface{}) error {
+	m.mu.Lock()
+	item, ok := m.get(key)
+	m.mu.Unlock()
+	if !ok {
+		return ErrCacheMiss
+	}
+	return json.Unmarshal(item.value, value)
+}
+
+func (m *MemoryCache) Set(ctx context.Context, key string, value interface{},
+	ttl time.Duration) error {
+	val, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.set(key, val, ttl)
+	return nil
+}
+
+func (m *MemoryCache) Delete(ctx context.Context, keys ...string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for _, key := range keys {
+		delete(m.items, key)
+	}
+	return nil
+}
+
+func (m *MemoryCache) Lock(ctx context.Context, key string, ttl time.Duration) (
+	string, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if _, ok := m.get(key); ok {
+		return "", nil
+	}
+	token := uuid.NewString()
+	m.set(key, []byte(token), ttl)
+	return token, nil
+}
+
+func (m *MemoryCache) Unlock(ctx context.Context, key, token string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if item, ok := m.get(key); ok && string(item.value) == token {
+		delete(m.items, key)
+	}
+	return nil
+}
+
+func (m *MemoryCache) Ping(ctx context.Context) error {
+	return nil
+}
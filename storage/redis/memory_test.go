@@ -0,0 +1,83 @@
+package redis
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	pb "travel/genproto/itineraries"
+)
+
+func TestMemoryCache(t *testing.T) {
+	m := NewMemoryCache()
+	now := time.Now()
+	m.now = func() time.Time { return now }
+	ctx := context.Background()
+
+	err := m.Set(ctx, "key", &pb.DestionationInfo{Id: "1"}, time.Minute)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	res := pb.DestionationInfo{}
+	if err := m.Get(ctx, "key", &res); err != nil || res.Id != "1" {
+		t.Fatalf("expected cached value, got %v %v", res.Id, err)
+	}
+
+	now = now.Add(time.Minute)
+	if err := m.Get(ctx, "key", &res); err != ErrCacheMiss {
+		t.Errorf("expected expired key to miss, got %v", err)
+	}
+}
+
+func TestMemoryCacheSweep(t *testing.T) {
+	m := NewMemoryCache()
+	now := time.Now()
+	m.now = func() time.Time { return now }
+	ctx := context.Background()
+
+	m.Set(ctx, "expiring", "value", time.Second)
+	m.Set(ctx, "kept", "value", 0)
+	now = now.Add(memorySweepInterval)
+	m.Set(ctx, "new", "value", time.Minute)
+	if _, ok := m.items["expiring"]; ok || len(m.items) != 2 {
+		t.Errorf("expected the expired key to be swept, got %v", m.items)
+	}
+}
+
+func TestMemoryCacheLock(t *testing.T) {
+	m := NewMemoryCache()
+	ctx := context.Background()
+
+	token, _ := m.Lock(ctx, "lock", time.Minute)
+	if token == "" {
+		t.Fatal("expected to take the lock")
+	}
+	if other, _ := m.Lock(ctx, "lock", time.Minute); other != "" {
+		t.Fatal("expected lock to be held")
+	}
+
+	m.Unlock(ctx, "lock", "stale")
+	if other, _ := m.Lock(ctx, "lock", time.Minute); other != "" {
+		t.Fatal("stale token must not release the lock")
+	}
+
+	m.Unlock(ctx, "lock", token)
+	if other, _ := m.Lock(ctx, "lock", time.Minute); other == "" {
+		t.Error("expected lock to be released")
+	}
+}
+
+func TestNoopCache(t *testing.T) {
+	ctx := context.Background()
+	c := NoopCache{}
+
+	c.Set(ctx, "key", "value", time.Minute)
+	var res string
+	if err := c.Get(ctx, "key", &res); err != ErrCacheMiss {
+		t.Errorf("expected miss, got %v", err)
+	}
+	if token, _ := c.Lock(ctx, "lock", time.Minute); token == "" {
+		t.Error("expected lock to be granted")
+	}
+}
@@ -0,0 +1,94 @@
+package storage
+
+import (
+	"context"
+	"time"
+	pbInter "travel/genproto/interactions"
+	pbItiner "travel/genproto/itineraries"
+	pb "travel/genproto/stories"
+	"travel/models"
+)
+
+type StoriesStorage interface {
+	CreateStory(ctx context.Context, story *pb.RequestCreateStory) (string, error)
+	CreateStoryTags(ctx context.Context, storyId string, tags *[]string) error
+	EditStory(ctx context.Context, story *pb.RequestEditStory,
+		images *models.StoryImagesEdit) (*models.EditedStory, error)
+	DeleteStoryTags(ctx context.Context, storyId string) error
+	GetStories(ctx context.Context,
+		filter *pb.RequestGetStories) (*[]models.Story, error)
+	FindNumberOfStories(ctx context.Context) (int, error)
+	GetStoryFullInfo(ctx context.Context, id string) (*models.StoryFullInfo, error)
+	GetStoryTags(ctx context.Context, storyId string) (*[]string, error)
+	DeleteStory(ctx context.Context, id string) error
+	RestoreStory(ctx context.Context, id, authorId string, since time.Time) error
+	CreateStoryImages(ctx context.Context, storyId string, images []models.Image) error
+	GetStoryImages(ctx context.Context, storyId string) (*[]models.Image, error)
+	CreateDraft(ctx context.Context, story *pb.RequestCreateStory) (string, error)
+	GetStoryStatus(ctx context.Context, id string) (string, string, error)
+	GetDraft(ctx context.Context, id string) (*models.StoryFullInfo, error)
+	GetDrafts(ctx context.Context, authorId string, page,
+		limit int32) (*[]models.StoryFullInfo, error)
+	CountDrafts(ctx context.Context, authorId string) (int, error)
+	PublishStory(ctx context.Context, id, status string, publishAt time.Time) error
+	ArchiveStory(ctx context.Context, id string) error
+	PublishDueStories(ctx context.Context, now time.Time) (*[]string, error)
+	GetStoryRevisions(ctx context.Context, storyId string, page,
+		limit int32) (*[]models.StoryRevision, error)
+	CountStoryRevisions(ctx context.Context, storyId string) (int, error)
+	GetStoryRevision(ctx context.Context, storyId string,
+		revision int) (*models.StoryRevision, error)
+	GetCurrentRevision(ctx context.Context,
+		storyId string) (*models.StoryRevision, error)
+}
+
+type InteractionsStorage interface {
+	CreateComment(ctx context.Context,
+		req *pbInter.RequestCreateComment) (string, error)
+	GetComments(ctx context.Context,
+		req *pbInter.RequestGetComments) (*[]models.Comment, error)
+	CountComments(ctx context.Context, storyId string) (int, error)
+	LikeStory(ctx context.Context, storyId string) error
+	CreateLike(ctx context.Context, req *pbInter.RequestLikeStory) error
+}
+
+type ItinerariesStorage interface {
+	CreateItinerary(ctx context.Context, req *pbItiner.RequestCreateItineraries) (
+		string, error)
+	UpdateItinerary(ctx context.Context, req *pbItiner.RequestEditItineraries) error
+	DeleteItinerary(ctx context.Context, id string) error
+	RestoreItinerary(ctx context.Context, id, authorId string, since time.Time) error
+	GetAllItineraries(ctx context.Context, req *pbItiner.RequestGetAllItineraries) (
+		*[]models.Itinerary, error)
+	FindNumberOfItineraries(ctx context.Context) (int, error)
+	GetItinerariesFullInfo(ctx context.Context,
+		id string) (*models.ItineraryFullInfo, error)
+	GetItinerariesDestinations(ctx context.Context,
+		id string) (*[]*pbItiner.DestinationEdit, error)
+	WriteCommentToItinerary(ctx context.Context,
+		req *pbItiner.RequestWriteCommentToItinerary) (
+		string, error)
+	CreateDestination(ctx context.Context,
+		req *pbItiner.RequestCreateDestination) (string, error)
+	GetTopDestinations(ctx context.Context, req *pbItiner.RequestGetDestinations) (
+		*pbItiner.ResponseGetDestinations, error)
+}
+
+type AdminStorage interface {
+	ListDeleted(ctx context.Context, kind string, page,
+		limit int32) (*[]models.DeletedItem, error)
+	CountDeleted(ctx context.Context, kind string) (int, error)
+	PurgeStories(ctx context.Context, cutoff time.Time,
+		limit int) (*models.PurgeResult, error)
+	PurgeItineraries(ctx context.Context, cutoff time.Time,
+		limit int) (*models.PurgeResult, error)
+	PurgeComments(ctx context.Context, cutoff time.Time) (*models.PurgeResult, error)
+	PurgeUserContent(ctx context.Context, userId, mode string,
+		now time.Time) (*models.UserContentResult, error)
+	GetUserStories(ctx context.Context, userId string) (*[]models.UserStory, error)
+	GetUserItineraries(ctx context.Context,
+		userId string) (*[]models.UserItinerary, error)
+	GetUserComments(ctx context.Context, userId string) (*[]models.UserComment, error)
+	GetUserLikes(ctx context.Context, userId string) (*[]models.Like, error)
+	GetUserMessages(ctx context.Context, userId string) (*[]models.Message, error)
+}